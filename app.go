@@ -3,12 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -17,13 +19,27 @@ import (
 
 // VocabApp struct
 type VocabApp struct {
-	ctx    context.Context
-	client *openai.Client
+	ctx      context.Context
+	client   *openai.Client
+	provider string     // provider of the active profile
+	quirks   endpointQuirks
+	caps     capabilityState // features degraded after the server rejected them
+	mu       storeLock  // guards the JSON stores in the data directory, across instances
+	list     workingList
+	running  int32       // generations in flight, updated atomically
+	shared   localServer // shared question bank host
+	api      localServer // REST API
+	ipc      ipcServer   // automation socket
+	local    localProcess
+	log      *logBuffer      // recent log lines for diagnostics
+	failures failureRecorder // last failed API call for diagnostics
+	guard    closeGuard
+	queue    generationQueue // lets one instance generate at a time
 }
 
 // NewVocabApp creates a new App application struct
 func NewVocabApp() *VocabApp {
-	return &VocabApp{}
+	return &VocabApp{log: newLogBuffer()}
 }
 
 // --- Wails Lifecycle ---
@@ -32,11 +48,61 @@ func NewVocabApp() *VocabApp {
 // the context, and to initialize things.
 func (a *VocabApp) startup(ctx context.Context) {
 	a.ctx = ctx
-	apiKey := loadAPIKey()
-	if apiKey != "" {
-		a.client = openai.NewClient(apiKey)
-	} else {
-		runtime.LogErrorf(a.ctx, "API 키를 찾을 수 없습니다. api.json 파일을 확인하세요.")
+	if err := a.reloadClient(); err != nil {
+		runtime.LogErrorf(a.ctx, "%v", err)
+	}
+	if err := a.purgeExpiredTrash(); err != nil {
+		runtime.LogErrorf(a.ctx, "휴지통 정리 오류: %v", err)
+	}
+	go func() {
+		settings, err := a.GetSettings()
+		if err != nil {
+			return
+		}
+		if settings.SharedBank.Host {
+			if _, err := a.StartBankServer(); err != nil {
+				runtime.LogErrorf(a.ctx, "%v", err)
+			}
+		}
+		if settings.API.Enabled {
+			if _, err := a.StartAPIServer(); err != nil {
+				runtime.LogErrorf(a.ctx, "%v", err)
+			}
+		}
+		if settings.IPC.Enabled {
+			if _, err := a.StartIPCServer(); err != nil {
+				runtime.LogErrorf(a.ctx, "%v", err)
+			}
+		}
+	}()
+	go a.runScheduler()
+	if settings, err := a.GetSettings(); err == nil {
+		if p, ok := settings.activeProfile(); ok && p.Provider == ProviderLocal {
+			go func() {
+				// An executable the user has not approved is never started
+				// on its own; the frontend asks for approval instead.
+				if err := a.StartLocalServer(); err == errServerNotTrusted {
+					runtime.EventsEmit(a.ctx, "local:untrusted", p.Name)
+				} else if err != nil {
+					runtime.LogErrorf(a.ctx, "%v", err)
+				}
+			}()
+		}
+	}
+	go func() {
+		if settings, err := a.GetSettings(); err == nil && settings.Sync.Enabled {
+			if _, err := a.SyncNow(); err != nil {
+				runtime.LogErrorf(a.ctx, "동기화 오류: %v", err)
+			}
+		}
+	}()
+}
+
+// shutdown is called when the app is closing. The local model server would
+// otherwise keep running in the background.
+func (a *VocabApp) shutdown(ctx context.Context) {
+	if err := a.StopLocalServer(); err != nil {
+		runtime.LogErrorf(a.ctx, "로컬 모델 서버 종료 오류: %v", err)
 	}
 }
 
@@ -49,6 +115,17 @@ type APIKeyConfig struct {
 type VocabPair struct {
 	Word   string
 	Senses []string
+	// SenseFlags marks single senses as SenseSkip or SensePriority.
+	SenseFlags map[string]string `json:",omitempty"`
+	// SenseNotes holds the annotation written in parentheses after a sense
+	// (Hanja, an English gloss or a usage note), keyed by the bare sense.
+	SenseNotes map[string]string `json:",omitempty"`
+	// Seed is an example sentence, typically from the class textbook, that
+	// the questions for the word use or imitate ("word = 뜻 = sentence").
+	Seed string `json:",omitempty"`
+	// Note is a teacher note (usage warning, exam tip) printed in exports
+	// but never sent to the model.
+	Note string `json:",omitempty"`
 }
 
 // --- Go functions callable from Javascript ---
@@ -79,9 +156,12 @@ func (a *VocabApp) OpenFile() (string, error) {
 }
 
 func (a *VocabApp) SaveFile(contentToSave string, suggestedFilename string) (string, error) {
-	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
-		Title:           "결과 저장",
-		DefaultFilename: suggestedFilename,
+	name, err := a.planExportName(contentToSave, "", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	filePath, auto, err := a.savePath(&name, ".txt", runtime.SaveDialogOptions{
+		Title: "결과 저장",
 		Filters: []runtime.FileFilter{
 			{
 				DisplayName: "텍스트 파일 (*.txt)",
@@ -92,14 +172,12 @@ func (a *VocabApp) SaveFile(contentToSave string, suggestedFilename string) (str
 	if err != nil {
 		return "", err
 	}
-	if filePath == "" {
-		return "", fmt.Errorf("저장 경로가 선택되지 않았습니다")
-	}
 
 	err = os.WriteFile(filePath, []byte(contentToSave), 0644)
 	if err != nil {
 		return "", fmt.Errorf("파일 저장 오류: %w", err)
 	}
+	a.exportSaved(name, filePath, auto)
 	return fmt.Sprintf("저장 완료: %s", filepath.Base(filePath)), nil
 }
 
@@ -108,7 +186,7 @@ func (a *VocabApp) Generate(vocabBlock string, modelID string, questionType stri
 		return "", fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
 	}
 
-	parsed := parseVocabBlock(vocabBlock)
+	parsed, issues := parseVocabLines(vocabBlock)
 	if len(parsed) == 0 {
 		return "", fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
 	}
@@ -116,18 +194,217 @@ func (a *VocabApp) Generate(vocabBlock string, modelID string, questionType stri
 	rand.Seed(time.Now().UnixNano())
 	rand.Shuffle(len(parsed), func(i, j int) { parsed[i], parsed[j] = parsed[j], parsed[i] })
 
-	systemPrompt, userPrompt := buildPrompts(parsed, questionType, numSentences)
-	
-	outputText, err := a.callChatGPT(modelID, systemPrompt, userPrompt)
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
 	if err != nil {
 		return "", err
 	}
-	return outputText, nil
+	if settings.StrictParse {
+		if err := strictParseError(issues); err != nil {
+			return "", err
+		}
+	}
+	return a.generate(settings, parsed, modelID, questionType, numSentences)
 }
 
-
 // --- Internal Go Logic ---
 
+// generate runs one question type over an already parsed and shuffled list.
+func (a *VocabApp) generate(settings Settings, parsed []VocabPair, modelID string, questionType string, numSentences int) (string, error) {
+	if a.guard.isClosing() {
+		return "", errClosing
+	}
+	atomic.AddInt32(&a.running, 1)
+	defer atomic.AddInt32(&a.running, -1)
+	a.enterQueue()
+	defer a.leaveQueue()
+
+	systemPrompt, err := settings.systemPromptForModel(modelID, questionType, numSentences)
+	if err != nil {
+		return "", err
+	}
+	stamp := a.runStamp(settings, modelID, questionType, numSentences, systemPrompt)
+	a.mu.Lock()
+	examples, err := fewShotExamples(settings.Generation, questionType)
+	var exclusions []ExcludedWord
+	if err == nil {
+		exclusions, err = loadExclusions()
+	}
+	var unit TextbookUnit
+	grounded := false
+	if err == nil {
+		unit, grounded, err = selectedTextbookUnit(settings.Generation)
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	parsed, normalized := normalizeEntries(parsed, settings.Normalize)
+	a.reportNormalized(normalized)
+	parsed, excluded := excludeWords(applySenseFlags(parsed), exclusions)
+	if len(excluded) > 0 {
+		runtime.EventsEmit(a.ctx, "generate:excluded", ExclusionReport{QuestionType: questionType, Excluded: excluded})
+	}
+	if len(parsed) == 0 {
+		return "", fmt.Errorf("출제할 단어가 없습니다. 모든 단어가 제외 목록에 있거나 출제하지 않을 뜻으로 표시되어 있습니다")
+	}
+	entries, err := a.cappedEntries(settings.Generation, parsed, questionType)
+	if err != nil {
+		return "", err
+	}
+	cost := func(e VocabPair) int { return settings.Generation.questionCost(e, questionType) }
+	if grounded {
+		if section := textbookSection(unit, entries); section != "" {
+			systemPrompt += "\n\n" + section
+		}
+	}
+	if k := settings.Generation.Exemplars; k > 0 {
+		// Retrieval only improves style; a failed lookup must not fail the run.
+		section, err := a.exemplarsFor(entries, questionType, k)
+		if err != nil {
+			runtime.LogErrorf(a.ctx, "예문 검색 오류: %v", err)
+		} else if section != "" {
+			systemPrompt += "\n\n" + section
+		}
+	}
+	hideNotes := settings.Generation.HideSenseNotes && hasSenseNotes(entries)
+	if hideNotes {
+		systemPrompt += "\n\n" + senseNoteRule
+	}
+	plan, err := planPrompt(a.capabilities(modelID).limit(), systemPrompt, examples, entries, cost, numSentences)
+	if err != nil {
+		return "", err
+	}
+	if dropped := len(examples) - len(plan.Examples); dropped > 0 || len(plan.Chunks) > 1 {
+		limit := a.capabilities(modelID).limit()
+		runtime.EventsEmit(a.ctx, "generate:budget", BudgetReport{
+			Model:           modelID,
+			ContextWindow:   limit.Context,
+			MaxOutput:       limit.Output,
+			DroppedExamples: dropped,
+			Chunks:          len(plan.Chunks),
+		})
+	}
+	if section := fewShotSection(plan.Examples); section != "" {
+		systemPrompt += "\n\n" + section
+	}
+
+	var outputs []string
+	alternatives := map[int][]Question{}
+	confidence := map[int]float64{}
+	numbered := 0
+	for i, chunk := range plan.Chunks {
+		if i > 0 && a.guard.isClosing() {
+			var remaining []VocabPair
+			for _, rest := range plan.Chunks[i:] {
+				remaining = append(remaining, rest...)
+			}
+			a.saveCheckpoint(Checkpoint{Model: modelID, QuestionType: questionType, NumSentences: numSentences, Outputs: outputs, Remaining: remaining})
+			return "", errClosing
+		}
+		total := 0
+		for _, e := range chunk {
+			total += cost(e)
+		}
+		eta := a.startETA(modelID, questionType, total)
+		var output string
+		if k := settings.Generation.Candidates; k > 1 {
+			var alts map[int][]Question
+			var sampled map[int]float64
+			output, alts, sampled, err = a.sampleCandidates(modelID, systemPrompt, buildUserPrompt(chunk), k, chunk, questionType, numSentences)
+			for n, qs := range alts {
+				alternatives[numbered+n] = qs
+			}
+			for n, c := range sampled {
+				confidence[numbered+n] = c
+			}
+		} else {
+			var logprobs []openai.ChatCompletionTokenLogprob
+			output, logprobs, err = a.callChatGPTStream(modelID, systemPrompt, buildUserPrompt(chunk), eta.progress)
+			for n, c := range answerConfidence(output, logprobs) {
+				confidence[numbered+n] = c
+			}
+		}
+		if err != nil {
+			return "", err
+		}
+		questions := len(parseOutput(output).Questions)
+		eta.finish(questions)
+		numbered += questions
+		outputs = append(outputs, output)
+	}
+	outputText := outputs[0]
+	if len(outputs) > 1 {
+		outputText = joinSections(outputs)
+	}
+	if review := settings.Generation.ReviewModel; review != "" && review != modelID {
+		outputText = a.reviewDraftAndReport(modelID, review, systemPrompt, outputText, entries, questionType, numSentences)
+	}
+	if hideNotes {
+		outputText = stripSenseNotes(outputText, entries)
+	}
+	outputText, _ = normalizeSpelling(outputText, settings.Generation.Spelling, parsed)
+	if questionType == "뜻풀이 판단" && settings.Generation.VerifyDefinitions {
+		outputText = a.verifyDefinitionsAndReport(modelID, outputText)
+	}
+	if len(settings.Generation.ForbiddenWords) > 0 || settings.Generation.AvoidOtherTargets {
+		outputText = a.checkForbiddenAndReport(modelID, outputText, questionType, entries, settings.Generation)
+	}
+	if settings.Generation.CheckDuplicates {
+		outputText = a.checkDuplicatesAndReport(modelID, outputText)
+	}
+	if settings.Grammar.Enabled {
+		outputText = a.checkGrammarAndReport(settings.Grammar, outputText)
+	}
+	if questionType == "영영풀이" && settings.Dictionary.Provider != "" {
+		a.checkDictionaryAndReport(settings.Dictionary, outputText)
+	}
+	if questionType == "빈칸 추론" && settings.Generation.CheckBlanks {
+		outputText = a.checkBlanksAndReport(modelID, outputText)
+	}
+	if questionType == "빈칸 추론" && settings.Generation.AllowInflections {
+		a.reportInflections(outputText)
+	}
+	if settings.Generation.MatchChoicePOS && wordChoiceTypes[questionType] {
+		outputText = a.matchChoicePOSAndReport(modelID, outputText)
+	}
+	if settings.Generation.CheckSenses {
+		a.checkSensesAndReport(modelID, outputText, entries, parsed)
+	}
+	if settings.Generation.TranslateSentences {
+		outputText = a.addTranslationsOrLog(modelID, outputText)
+	}
+	a.reportConfidence(confidence)
+	if a.guard.isClosing() {
+		a.saveCheckpoint(Checkpoint{Model: modelID, QuestionType: questionType, NumSentences: numSentences, Outputs: []string{outputText}})
+	}
+	runID, err := a.recordRun(GenerationRun{
+		Model:        modelID,
+		QuestionType: questionType,
+		Entries:      entries,
+		Output:       outputText,
+		Alternatives: alternatives,
+		Confidence:   confidence,
+		Excluded:     excluded,
+		Stamp:        stamp,
+	})
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "생성 기록 저장 오류: %v", err)
+	}
+	a.recordStats(questionType, validateOutput(outputText, entries, questionType, numSentences))
+	a.fireWebhook(settings.Webhook, WebhookPayload{
+		Event:        WebhookGenerated,
+		RunID:        runID,
+		Model:        modelID,
+		QuestionType: questionType,
+		Words:        len(entries),
+		Questions:    len(parseOutput(outputText).Questions),
+	})
+	return outputText, nil
+}
+
+
 func loadAPIKey() string {
 	exePath, err := os.Executable()
 	if err != nil {
@@ -159,52 +436,196 @@ func loadAPIKey() string {
 }
 
 
+// newVocabPair builds an entry from editor input, trimming blanks and
+// dropping empty senses the same way parseVocabBlock does.
+func newVocabPair(word string, senses []string) (VocabPair, error) {
+	pair := VocabPair{Word: strings.TrimSpace(word)}
+	for _, s := range senses {
+		pair.addSense(s)
+	}
+	if pair.Word == "" || len(pair.Senses) == 0 {
+		return VocabPair{}, fmt.Errorf("단어와 뜻을 모두 입력하세요")
+	}
+	return pair, nil
+}
+
 func parseVocabBlock(vocabBlock string) []VocabPair {
+	pairs, _ := parseVocabLines(vocabBlock)
+	return pairs
+}
+
+// parseVocabLines is parseVocabBlock that also reports every non-blank line
+// it had to skip, with its 1-based line number.
+func parseVocabLines(vocabBlock string) ([]VocabPair, []ParseIssue) {
 	var pairs []VocabPair
-	re := regexp.MustCompile(`[;,]`)
-	for _, raw := range strings.Split(vocabBlock, "\n") {
+	var issues []ParseIssue
+	for i, raw := range strings.Split(vocabBlock, "\n") {
 		line := strings.TrimSpace(raw)
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) < 2 {
+		skip := func(reason string) {
+			issues = append(issues, ParseIssue{Line: i + 1, Text: line, Reason: reason})
+		}
+		word, meaningsRaw, seed, ok := splitVocabLine(line)
+		if !ok {
+			skip("'=' 또는 '–' 구분 기호가 없습니다")
 			continue
 		}
-		word := strings.TrimSpace(parts[0])
-		meaningsRaw := strings.TrimSpace(parts[1])
-		sensesRaw := re.Split(meaningsRaw, -1)
-		var senses []string
-		for _, s := range sensesRaw {
-			if trimmed := strings.TrimSpace(s); trimmed != "" {
-				senses = append(senses, trimmed)
-			}
+		// "버리다 = abandon, desert" gives one entry per English word.
+		words := []string{word}
+		if swappedColumns(word, meaningsRaw) {
+			words, meaningsRaw = splitSenseList(meaningsRaw), word
 		}
-		if word != "" && len(senses) > 0 {
-			pairs = append(pairs, VocabPair{Word: word, Senses: senses})
+		sensesRaw := splitSenseList(meaningsRaw)
+		for _, word := range words {
+			pair := VocabPair{Word: strings.TrimSpace(word), Seed: seed}
+			for _, s := range sensesRaw {
+				pair.addSense(s)
+			}
+			switch {
+			case pair.Word == "":
+				skip("단어가 비어 있습니다")
+			case len(pair.Senses) == 0:
+				skip("뜻이 비어 있습니다")
+			default:
+				pairs = append(pairs, pair)
+			}
 		}
 	}
-	return pairs
+	return pairs, issues
 }
 
-func buildPrompts(parsed []VocabPair, questionType string, numSentences int) (string, string) {
-	distributionRule := "2. CRITICAL: The position of the correct answer MUST be truly and unpredictably randomized to ensure a balanced distribution. For the entire set of questions, each choice position (①, ②, ③, ④, ⑤) should be the correct answer approximately 20% of the time. DO NOT use any discernible pattern (e.g., 1, 2, 3, 4, 5 or 5, 4, 3, 2, 1). The sequence of correct answers must appear random and chaotic."
-	selfCorrectionRule := "### Final Review\nBefore concluding your response, you MUST review the entire generated text one last time to ensure every single rule has been followed. Pay special attention that every question has exactly 5 numbered choices (① to ⑤). If you find any mistake, you must correct it before finishing."
+const (
+	distributionRule   = "2. CRITICAL: The position of the correct answer MUST be truly and unpredictably randomized to ensure a balanced distribution. For the entire set of questions, each choice position (①, ②, ③, ④, ⑤) should be the correct answer approximately 20% of the time. DO NOT use any discernible pattern (e.g., 1, 2, 3, 4, 5 or 5, 4, 3, 2, 1). The sequence of correct answers must appear random and chaotic."
+	selfCorrectionRule = "### Final Review\nBefore concluding your response, you MUST review the entire generated text one last time to ensure every single rule has been followed. Pay special attention that every question has exactly 5 numbered choices (① to ⑤). If you find any mistake, you must correct it before finishing."
+)
+
+// defaultQuestionTitles are the built-in question stems per question type.
+// <WORD> is replaced by the model with the word being tested.
+var defaultQuestionTitles = map[string]string{
+	"빈칸 추론":   "다음 빈칸에 공통으로 들어갈 말로 가장 적절한 것은?",
+	"영영풀이":    "다음 영어 설명에 해당하는 단어는?",
+	"뜻풀이 판단":  "다음 단어 <WORD>의 영영풀이로 가장 적절한 것은?",
+	"의미 다른 것": "다음 중 의미가 다른 하나는?",
+	"유추":      "다음 관계와 같도록 빈칸에 들어갈 말로 가장 적절한 것은?",
+	"문장 삽입":   "글의 흐름으로 보아, 주어진 문장이 들어가기에 가장 적절한 곳은?",
+	"순서 배열":   "주어진 글 다음에 이어질 글의 순서로 가장 적절한 것은?",
+	"단어 쓰기":   "다음 우리말 뜻을 참고하여 빈칸에 알맞은 단어를 쓰시오.",
+}
+
+// passageWordsPerQuestion is how many list words the passage-based types
+// (문장 삽입, 순서 배열) put into one question.
+const passageWordsPerQuestion = 3
+
+// promptOptions collects everything the built-in system prompt depends on.
+type promptOptions struct {
+	QuestionType     string
+	NumSentences     int
+	Title            string
+	ContextTheme     string // topic domain of the example sentences, if any
+	Level            GradeLevel
+	Spelling         string // SpellingBritish, SpellingAmerican or empty
+	Names            string // Names* constant or empty
+	Places           string // Places* constant or empty
+	SenseCoverage    string
+	AllowInflections bool
+	MatchChoicePOS   bool
+	StyleReference   string // example questions to imitate, if any
+	ForbiddenWords   []string
+	AvoidTargets     bool // sentences must not use the other list words
+}
+
+// inflectionRule is appended to the 빈칸 추론 choice instruction.
+func (o promptOptions) inflectionRule() string {
+	if !o.AllowInflections {
+		return ""
+	}
+	return " Write every choice in its base (dictionary) form. The blanked word in a sentence may be an inflected form of the answer (e.g., 'ran' or 'running' for 'run') when the sentence needs it; then list the forms used after that question's answer in the [정답] section, e.g., '1. ③ (ran, running)'. Every blank must be fillable by a form of the answer only."
+}
+
+// blankMainRule is the 빈칸 추론 main rule. When the list has been split in
+// Go (questionEntries), every line is exactly one question.
+func (o promptOptions) blankMainRule() string {
+	switch o.SenseCoverage {
+	case SenseCoveragePerSense, SenseCoverageCapped:
+		return "Each line of the vocabulary list is one question: generate exactly one complete question block per line, in the order given. When a line lists several senses, the context sentences must together use the word in each of those senses."
+	case SenseCoveragePerWord:
+		return "For each WORD, generate exactly one complete question block. When a word has several senses, spread them across the context sentences so that the same blank is filled by the word in different meanings."
+	}
+	return "For each WORD and for each of its SENSEs, you must generate a complete question block."
+}
+
+// audienceLine is the opening line of the system prompt.
+func (o promptOptions) audienceLine() string {
+	if o.Level.audience == "" {
+		return "You are an expert English vocabulary test maker for Korean students."
+	}
+	return fmt.Sprintf("You are an expert English vocabulary test maker for %s.", o.Level.audience)
+}
+
+// styleRules is the word selection section, with the level's difficulty
+// guidance and the spelling convention added when they are set.
+func (o promptOptions) styleRules() string {
+	rules := []string{
+		"PRIORITY: Focus on polysemous words—those with multiple, distinct meanings (e.g., different parts of speech like 'conduct' as a noun vs. verb, or different senses like 'bank' of a river vs. a financial institution).",
+		"GOAL: The questions should be intentionally challenging, designed to confuse the test-taker and test their ability to discern the correct meaning from context.",
+	}
+	if o.Level.ID != "" {
+		rules = append(rules,
+			"SENTENCE LEVEL: "+o.Level.sentences,
+			"DISTRACTOR LEVEL: "+o.Level.distractors,
+		)
+	}
+	if rule := spellingRule(o.Spelling); rule != "" {
+		rules = append(rules, rule)
+	}
+	if rule := namesRule(o.Names, o.Places); rule != "" {
+		rules = append(rules, rule)
+	}
+	if rule := choicePOSRule(o.MatchChoicePOS); rule != "" {
+		rules = append(rules, rule)
+	}
+	if rule := forbiddenWordsRule(o.ForbiddenWords, o.AvoidTargets); rule != "" {
+		rules = append(rules, rule)
+	}
+	lines := []string{"### Word Selection & Question Style Rule"}
+	for i, r := range rules {
+		lines = append(lines, fmt.Sprintf("%d. %s", i+1, r))
+	}
+	return strings.Join(lines, "\n")
+}
 
+// titleRule is the output-structure line asking for the question title.
+func (o promptOptions) titleRule() string {
+	if strings.Contains(o.Title, "<WORD>") {
+		return fmt.Sprintf("2. Add the title: '%s' (replace <WORD> with the actual word).", o.Title)
+	}
+	return fmt.Sprintf("2. Add the title: '%s'", o.Title)
+}
+
+// contextRule is appended to the context-sentence instruction.
+func (o promptOptions) contextRule() string {
+	if o.ContextTheme == "" {
+		return ""
+	}
+	return fmt.Sprintf(" All sentences must be set in the domain of %s, so that they read like material from a textbook unit on that topic.", o.ContextTheme)
+}
+
+func buildSystemPrompt(opts promptOptions) string {
+	questionType, numSentences := opts.QuestionType, opts.NumSentences
 	var systemPrompt string
 	switch questionType {
 	case "빈칸 추론":
 		systemPrompt = strings.Join([]string{
-			"You are an expert English vocabulary test maker for Korean students.",
+			opts.audienceLine(),
 			"Your task is to create multiple-choice questions that test understanding of words in context.",
 			"Strictly follow all rules below.",
 			"",
 			"### Main Rule",
-			"For each WORD and for each of its SENSEs, you must generate a complete question block.",
+			opts.blankMainRule(),
 			"",
-			"### Word Selection & Question Style Rule",
-			"1. PRIORITY: Focus on polysemous words—those with multiple, distinct meanings (e.g., different parts of speech like 'conduct' as a noun vs. verb, or different senses like 'bank' of a river vs. a financial institution).",
-			"2. GOAL: The questions should be intentionally challenging, designed to confuse the test-taker and test their ability to discern the correct meaning from context.",
+			opts.styleRules(),
 			"",
 			"### Answer Generation Rules",
 			"1. CRITICAL: DO NOT mark the correct answer in the choices. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and its correct choice number.",
@@ -212,26 +633,24 @@ func buildPrompts(parsed []VocabPair, questionType string, numSentences int) (st
 			"",
 			"### Output Structure (per question)",
 			"1. Start with the question number (e.g., '1.').",
-			"2. Add the title: '다음 빈칸에 공통으로 들어갈 말로 가장 적절한 것은?'",
-			fmt.Sprintf("3. Provide exactly %d distinct English sentences as context. Each sentence must have the word blanked out as '_______'.", numSentences),
+			opts.titleRule(),
+			fmt.Sprintf("3. Provide exactly %d distinct English sentences as context. Each sentence must have the word blanked out as '_______'.%s", numSentences, opts.contextRule()),
 			"4. Provide exactly 5 answer choices (①, ②, ③, ④, ⑤).",
-			"5. The choices must include one correct answer (the original WORD) and four plausible but incorrect distractors.",
+			"5. The choices must include one correct answer (the original WORD) and four plausible but incorrect distractors." + opts.inflectionRule(),
 			"6. Separate each full question block with a '---' line.",
 			"",
 			selfCorrectionRule,
 		}, "\n")
 	case "영영풀이":
 		systemPrompt = strings.Join([]string{
-			"You are an expert English vocabulary test maker for Korean students.",
+			opts.audienceLine(),
 			"Your task is to create multiple-choice questions based on English definitions.",
 			"Strictly follow all rules below.",
 			"",
 			"### Main Rule",
 			"For each WORD, you must generate one complete multiple-choice question.",
 			"",
-			"### Word Selection & Question Style Rule",
-			"1. PRIORITY: Focus on polysemous words—those with multiple, distinct meanings (e.g., different parts of speech like 'conduct' as a noun vs. verb, or different senses like 'bank' of a river vs. a financial institution).",
-			"2. GOAL: The questions should be intentionally challenging, designed to confuse the test-taker and test their ability to discern the correct meaning from context.",
+			opts.styleRules(),
 			"",
 			"### Answer Generation Rules",
 			"1. CRITICAL: DO NOT mark the correct answer in the choices. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and its correct choice number.",
@@ -239,7 +658,7 @@ func buildPrompts(parsed []VocabPair, questionType string, numSentences int) (st
 			"",
 			"### Output Structure (per question)",
 			"1. Start with the question number (e.g., '1.').",
-			"2. Add the title: '다음 영어 설명에 해당하는 단어는?'",
+			opts.titleRule(),
 			"3. Provide the English definition of the WORD as the question body.",
 			"4. Provide exactly 5 answer choices (①, ②, ③, ④, ⑤): one correct answer (the original WORD) and four plausible distractors (e.g., synonyms, related words).",
 			"5. Separate each full question block with a '---' line.",
@@ -248,16 +667,14 @@ func buildPrompts(parsed []VocabPair, questionType string, numSentences int) (st
 		}, "\n")
 	case "뜻풀이 판단":
 		systemPrompt = strings.Join([]string{
-			"You are an expert English vocabulary test maker for Korean students.",
+			opts.audienceLine(),
 			"Your task is to create multiple-choice questions that test the precise definition of a word.",
 			"Strictly follow all rules below.",
 			"",
 			"### Main Rule",
 			"For each WORD, you must generate one complete multiple-choice question asking for its correct definition.",
 			"",
-			"### Word Selection & Question Style Rule",
-			"1. PRIORITY: Focus on polysemous words—those with multiple, distinct meanings (e.g., different parts of speech like 'conduct' as a noun vs. verb, or different senses like 'bank' of a river vs. a financial institution).",
-			"2. GOAL: The questions should be intentionally challenging, designed to confuse the test-taker and test their ability to discern the correct meaning from context.",
+			opts.styleRules(),
 			"",
 			"### Answer Generation Rules",
 			"1. CRITICAL: DO NOT mark the correct answer in the choices. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and its correct choice number.",
@@ -265,27 +682,170 @@ func buildPrompts(parsed []VocabPair, questionType string, numSentences int) (st
 			"",
 			"### Output Structure (per question)",
 			"1. Start with the question number (e.g., '1.').",
-			"2. Add the title: '다음 단어 <WORD>의 영영풀이로 가장 적절한 것은?' (replace <WORD> with the actual word).",
+			opts.titleRule(),
 			"3. Provide exactly 5 definition choices (①, ②, ③, ④, ⑤): one perfectly correct definition and four subtly incorrect but plausible definitions.",
 			"4. Separate each full question block with a '---' line.",
 			"",
 			selfCorrectionRule,
 		}, "\n")
+	case "의미 다른 것":
+		systemPrompt = strings.Join([]string{
+			opts.audienceLine(),
+			"Your task is to create odd-one-out questions that review clusters of words with related meanings.",
+			"Strictly follow all rules below.",
+			"",
+			"### Main Rule",
+			"For each WORD, you must generate one complete multiple-choice question built around one of its SENSEs.",
+			"",
+			opts.styleRules(),
+			"",
+			"### Answer Generation Rules",
+			"1. CRITICAL: DO NOT mark the correct answer in the choices. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and its correct choice number.",
+			distributionRule,
+			"",
+			"### Output Structure (per question)",
+			"1. Start with the question number (e.g., '1.').",
+			opts.titleRule(),
+			"3. Provide exactly 5 answer choices (①, ②, ③, ④, ⑤), each a single English word or phrase of the same part of speech.",
+			"4. Four choices must share one meaning: the WORD itself (in the chosen SENSE) and three close synonyms. Prefer other words from the vocabulary list as synonyms when they fit, and suggest your own otherwise.",
+			"5. The fifth choice, the correct answer, must be a word that looks related (same topic or a similar-looking word) but clearly differs in meaning from the other four.",
+			"6. Separate each full question block with a '---' line.",
+			"",
+			selfCorrectionRule,
+		}, "\n")
+	case "유추":
+		systemPrompt = strings.Join([]string{
+			opts.audienceLine(),
+			"Your task is to create word analogy questions (A : B = C : ?) that test the meaning of words through their relationships.",
+			"Strictly follow all rules below.",
+			"",
+			"### Main Rule",
+			"For each WORD, you must generate one complete multiple-choice question whose answer is the WORD, used in one of its SENSEs.",
+			"",
+			opts.styleRules(),
+			"",
+			"### Answer Generation Rules",
+			"1. CRITICAL: DO NOT mark the correct answer in the choices. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and its correct choice number.",
+			"2. After each answer in the `[정답]` section, state the relation in square brackets in Korean and English, e.g., '1. ③ [반의어 / antonym]'.",
+			strings.Replace(distributionRule, "2. ", "3. ", 1),
+			"",
+			"### Output Structure (per question)",
+			"1. Start with the question number (e.g., '1.').",
+			opts.titleRule(),
+			"3. Give the analogy on one line as 'A : B = C : _______', where A : B shows a clear relation (synonym, antonym, part-whole, cause-effect, degree, tool-function, category-member, and so on) and the WORD completes the same relation with C.",
+			"4. Provide exactly 5 answer choices (①, ②, ③, ④, ⑤) of the same part of speech as the WORD: the WORD and four distractors that relate to C in a different way.",
+			"5. Separate each full question block with a '---' line.",
+			"",
+			selfCorrectionRule,
+		}, "\n")
+	case "문장 삽입":
+		systemPrompt = strings.Join([]string{
+			opts.audienceLine(),
+			"Your task is to create 수능-style sentence insertion questions whose passages use the vocabulary naturally.",
+			"Strictly follow all rules below.",
+			"",
+			"### Main Rule",
+			fmt.Sprintf("Create one question for every %d WORDs of the list, in order (the last question may use fewer). Each question's passage must use its WORDs in their given senses.", passageWordsPerQuestion),
+			"",
+			opts.styleRules(),
+			"",
+			"### Answer Generation Rules",
+			"1. CRITICAL: DO NOT mark the correct answer in the passage. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and the number of the correct position.",
+			distributionRule,
+			"",
+			"### Output Structure (per question)",
+			"1. Start with the question number (e.g., '1.').",
+			opts.titleRule(),
+			"3. On the next line, give the sentence to be inserted, starting with '주어진 문장:'.",
+			"4. Then give a coherent paragraph of 6-8 sentences from which that sentence was removed, with exactly five insertion points marked inline as ( ① ), ( ② ), ( ③ ), ( ④ ), ( ⑤ ) in order. Only one position must fit logically.",
+			"5. Separate each full question block with a '---' line.",
+			"",
+			selfCorrectionRule,
+		}, "\n")
+	case "순서 배열":
+		systemPrompt = strings.Join([]string{
+			opts.audienceLine(),
+			"Your task is to create 수능-style sentence ordering questions whose passages use the vocabulary naturally.",
+			"Strictly follow all rules below.",
+			"",
+			"### Main Rule",
+			fmt.Sprintf("Create one question for every %d WORDs of the list, in order (the last question may use fewer). Each question's passage must use its WORDs in their given senses.", passageWordsPerQuestion),
+			"",
+			opts.styleRules(),
+			"",
+			"### Answer Generation Rules",
+			"1. CRITICAL: DO NOT mark the correct answer in the choices. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and its correct choice number.",
+			distributionRule,
+			"",
+			"### Output Structure (per question)",
+			"1. Start with the question number (e.g., '1.').",
+			opts.titleRule(),
+			"3. Give the opening part of a coherent passage (1-2 sentences).",
+			"4. Give the rest of the passage split into three scrambled parts, each on its own line starting with (A), (B) and (C).",
+			"5. Provide exactly 5 answer choices (①, ②, ③, ④, ⑤), each on its own line, each a different order such as '(B) - (A) - (C)'. Exactly one order must be logically correct.",
+			"6. Separate each full question block with a '---' line.",
+			"",
+			selfCorrectionRule,
+		}, "\n")
+	case "단어 쓰기":
+		systemPrompt = strings.Join([]string{
+			opts.audienceLine(),
+			"Your task is to create short-answer questions in which students write the word themselves.",
+			"Strictly follow all rules below.",
+			"",
+			"### Main Rule",
+			"For each WORD, you must generate one complete question for one of its SENSEs.",
+			"",
+			opts.styleRules(),
+			"",
+			"### Answer Generation Rules",
+			"1. CRITICAL: DO NOT show the answer in the question. Instead, create a separate `[정답]` section at the very end of the entire output, listing each question number and the exact word form that fills the blank, e.g., '1. abandoned'.",
+			"",
+			"### Output Structure (per question)",
+			"1. Start with the question number (e.g., '1.').",
+			opts.titleRule(),
+			"3. On the next line, give the Korean meaning of the SENSE, starting with '뜻:'.",
+			"4. Give one English sentence in which the WORD is blanked out as '_______', followed by its first letter in parentheses, e.g., '(a)'. The blanked form may be inflected when the sentence needs it." + opts.contextRule(),
+			"5. Do not provide any answer choices.",
+			"6. Separate each full question block with a '---' line.",
+			"",
+			selfCorrectionRule,
+		}, "\n")
 	}
+	if ref := opts.styleReferenceSection(); ref != "" {
+		systemPrompt += "\n\n" + ref
+	}
+	return systemPrompt
+}
 
+func buildUserPrompt(parsed []VocabPair) string {
 	var parsedForModelText []string
+	priority, notes, seeds := false, false, false
 	for _, pair := range parsed {
-		parsedForModelText = append(parsedForModelText, fmt.Sprintf("%s = %s", pair.Word, strings.Join(pair.Senses, ", ")))
+		parsedForModelText = append(parsedForModelText, formatVocabBlock([]VocabPair{pair}))
+		priority = priority || len(pair.SenseFlags) > 0
+		notes = notes || len(pair.SenseNotes) > 0
+		seeds = seeds || pair.Seed != ""
 	}
 
+	intro := "Here is the list of vocabulary. Create test questions based on these words, strictly following all rules defined in the system instructions."
+	if priority {
+		intro += " Senses marked with * are priority senses: when a word has one, test it rather than the other senses."
+	}
+	if notes {
+		intro += " Text in parentheses after a sense (Hanja, an English gloss or a usage note) is a hint that pins down the meaning to test."
+	}
+	if seeds {
+		intro += " A sentence after a second '=' is a seed sentence from the class textbook: use it as the context of that word's question, or write a sentence that closely imitates it."
+	}
 	userPrompt := strings.Join([]string{
-		"Here is the list of vocabulary. Create test questions based on these words, strictly following all rules defined in the system instructions.",
+		intro,
 		"",
 		"[Vocabulary List]",
 		strings.Join(parsedForModelText, "\n"),
 	}, "\n")
 
-	return systemPrompt, userPrompt
+	return userPrompt
 }
 
 func (a *VocabApp) callChatGPT(model string, systemPrompt string, userPrompt string) (string, error) {
@@ -308,9 +868,150 @@ func (a *VocabApp) callChatGPT(model string, systemPrompt string, userPrompt str
 		return "", fmt.Errorf("ChatGPT API 오류: %w", err)
 	}
 
+	a.recordUsage(model, resp.Usage)
+
 	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
 		return "", fmt.Errorf("API가 빈 텍스트를 반환했습니다")
 	}
 
 	return resp.Choices[0].Message.Content, nil
-}
\ No newline at end of file
+}
+
+// generationRequest builds the request of a generation call, asking for the
+// token log probabilities when the model and server support them.
+func (a *VocabApp) generationRequest(model string, systemPrompt string, userPrompt string) openai.ChatCompletionRequest {
+	return openai.ChatCompletionRequest{
+		Model: model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: systemPrompt},
+			{Role: openai.ChatMessageRoleUser, Content: userPrompt},
+		},
+		Temperature: 1.0,
+		LogProbs:    a.capabilities(model).Logprobs,
+	}
+}
+
+// callChatGPTStream is callChatGPT with a streamed response; onText is called
+// with the text received so far after every chunk. The token log
+// probabilities are returned for models that support them.
+func (a *VocabApp) callChatGPTStream(model string, systemPrompt string, userPrompt string, onText func(string)) (string, []openai.ChatCompletionTokenLogprob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+	defer cancel()
+
+	req := a.generationRequest(model, systemPrompt, userPrompt)
+	if !a.capabilities(model).Streaming {
+		return a.callChatGPTOnce(ctx, req, onText)
+	}
+	if !a.quirks.NoStreamUsage {
+		req.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	}
+	stream, err := a.client.CreateChatCompletionStream(ctx, req)
+	if err != nil && unsupportedRequest(err) {
+		// Retry without the options a server is most likely to reject, and
+		// without streaming if that was not it.
+		if req.LogProbs {
+			a.caps.degrade(model, featureLogprobs)
+			req.LogProbs = false
+			stream, err = a.client.CreateChatCompletionStream(ctx, req)
+		}
+		if err != nil && unsupportedRequest(err) {
+			a.caps.degrade(model, featureStreaming)
+			req.StreamOptions = nil
+			return a.callChatGPTOnce(ctx, req, onText)
+		}
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("ChatGPT API 오류: %w", err)
+	}
+	defer stream.Close()
+
+	var content strings.Builder
+	var logprobs []openai.ChatCompletionTokenLogprob
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("ChatGPT API 오류: %w", err)
+		}
+		if resp.Usage != nil {
+			a.recordUsage(model, *resp.Usage)
+		}
+		if len(resp.Choices) > 0 && resp.Choices[0].Logprobs != nil {
+			logprobs = append(logprobs, resp.Choices[0].Logprobs.Content...)
+		}
+		if len(resp.Choices) == 0 || resp.Choices[0].Delta.Content == "" {
+			continue
+		}
+		content.WriteString(resp.Choices[0].Delta.Content)
+		onText(content.String())
+	}
+
+	if content.Len() == 0 {
+		return "", nil, fmt.Errorf("API가 빈 텍스트를 반환했습니다")
+	}
+	return content.String(), logprobs, nil
+}
+
+// callChatGPTOnce sends a streaming request without streaming, for servers
+// that cannot stream; onText is called once with the whole reply.
+func (a *VocabApp) callChatGPTOnce(ctx context.Context, req openai.ChatCompletionRequest, onText func(string)) (string, []openai.ChatCompletionTokenLogprob, error) {
+	resp, err := a.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return "", nil, fmt.Errorf("ChatGPT API 오류: %w", err)
+	}
+	a.recordUsage(req.Model, resp.Usage)
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		return "", nil, fmt.Errorf("API가 빈 텍스트를 반환했습니다")
+	}
+	onText(resp.Choices[0].Message.Content)
+	return resp.Choices[0].Message.Content, tokenLogprobs(resp.Choices[0].LogProbs), nil
+}
+
+// callChatGPTJSON asks for a JSON object response and decodes it into v. It
+// is used for the checking calls made after generation and for structured
+// outputs such as study sheets.
+func (a *VocabApp) callChatGPTJSON(model string, systemPrompt string, userPrompt string, v interface{}) error {
+	return a.callChatGPTJSONMessages(model, []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: systemPrompt},
+		{Role: openai.ChatMessageRoleUser, Content: userPrompt},
+	}, v)
+}
+
+// callChatGPTJSONMessages is callChatGPTJSON for prebuilt messages, such as
+// user messages with images.
+func (a *VocabApp) callChatGPTJSONMessages(model string, messages []openai.ChatCompletionMessage, v interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+	defer cancel()
+
+	req := openai.ChatCompletionRequest{
+		Model:    model,
+		Messages: messages,
+	}
+	if a.capabilities(model).JSONMode {
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
+	resp, err := a.client.CreateChatCompletion(ctx, req)
+	if err != nil && req.ResponseFormat != nil && unsupportedRequest(err) {
+		// Fall back to reading the JSON out of a plain-text reply.
+		a.caps.degrade(model, featureJSONMode)
+		req.ResponseFormat = nil
+		resp, err = a.client.CreateChatCompletion(ctx, req)
+	}
+	if err != nil {
+		return fmt.Errorf("ChatGPT API 오류: %w", err)
+	}
+	a.recordUsage(model, resp.Usage)
+	if len(resp.Choices) == 0 {
+		return fmt.Errorf("API가 빈 텍스트를 반환했습니다")
+	}
+	content := resp.Choices[0].Message.Content
+	if req.ResponseFormat == nil {
+		content = extractJSONObject(content)
+	}
+	if err := json.Unmarshal([]byte(content), v); err != nil {
+		return fmt.Errorf("응답 형식 오류: %w", err)
+	}
+	return nil
+}
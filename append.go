@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Appending to Saved Tests ---
+
+// AppendOptions are the generation options of AppendToTest. An empty
+// QuestionType uses the test's own type.
+type AppendOptions struct {
+	Model        string `json:"model"`
+	QuestionType string `json:"questionType"`
+	NumSentences int    `json:"numSentences"`
+}
+
+// AppendResult reports what AppendToTest added.
+type AppendResult struct {
+	Test    SavedTest `json:"test"`
+	Added   int       `json:"added"`
+	Skipped []string  `json:"skipped"` // words the test already covered
+}
+
+// --- Go functions callable from Javascript ---
+
+// AppendToTest generates questions for the words of vocabBlock that the test
+// does not cover yet and appends them to the saved test, continuing its
+// numbering and merging the answer keys.
+func (a *VocabApp) AppendToTest(testID string, vocabBlock string, options AppendOptions) (AppendResult, error) {
+	if a.client == nil {
+		return AppendResult{}, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	parsed := parseVocabBlock(vocabBlock)
+	if len(parsed) == 0 {
+		return AppendResult{}, fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return AppendResult{}, err
+	}
+	if err := errIfLocked(test); err != nil {
+		return AppendResult{}, err
+	}
+	questionType := options.QuestionType
+	if questionType == "" {
+		questionType = test.QuestionType
+	}
+	if questionType == "" {
+		return AppendResult{}, fmt.Errorf("여러 유형이 섞인 시험지에는 추가할 문제 유형을 지정하세요")
+	}
+
+	a.mu.Lock()
+	settings, err := loadSettings()
+	var runs []GenerationRun
+	if err == nil {
+		runs, err = loadRuns()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return AppendResult{}, err
+	}
+	covered := map[string]bool{}
+	for _, e := range questionWords(test, runs) {
+		covered[strings.ToLower(e.Word)] = true
+	}
+	var result AppendResult
+	var fresh []VocabPair
+	for _, e := range parsed {
+		if covered[strings.ToLower(e.Word)] {
+			result.Skipped = append(result.Skipped, e.Word)
+			continue
+		}
+		fresh = append(fresh, e)
+	}
+	if len(fresh) == 0 {
+		return result, fmt.Errorf("추가할 새 단어가 없습니다. 모두 이미 시험지에 있습니다")
+	}
+
+	modelID := options.Model
+	if modelID == "" {
+		modelID = test.Model
+	}
+	output, err := a.generate(settings, fresh, modelID, questionType, options.NumSentences)
+	if err != nil {
+		return result, err
+	}
+	result.Added = len(parseOutput(output).Questions)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests, err := loadTests()
+	if err != nil {
+		return result, err
+	}
+	for i := range tests {
+		if tests[i].ID == testID && tests[i].DeletedAt == nil {
+			// The test may have been locked while the questions were generated.
+			if err := errIfLocked(tests[i]); err != nil {
+				return result, err
+			}
+			tests[i].Content = joinSections([]string{tests[i].Content, output})
+			if tests[i].QuestionType != questionType {
+				tests[i].QuestionType = ""
+			}
+			result.Test = tests[i]
+			return result, saveJSON(testsFile, tests)
+		}
+	}
+	return result, fmt.Errorf("시험지를 찾을 수 없습니다")
+}
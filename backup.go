@@ -0,0 +1,262 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Backup & Restore ---
+
+const backupManifestName = "backup-manifest.json"
+
+type backupManifest struct {
+	App       string    `json:"app"`
+	CreatedAt time.Time `json:"createdAt"`
+	Files     []string  `json:"files"`
+}
+
+// writeBackup zips every data file (settings, word lists, question bank,
+// history, ...) in the data directory into w. The settings go in without the
+// install settings (see keepInstallSettings). Must be called with a.mu held.
+func writeBackup(w io.Writer) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	manifest := backupManifest{App: appDataDirName, CreatedAt: time.Now()}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(p, ".tmp") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		var content []byte
+		if name == settingsFile {
+			content, err = backupSettings()
+		} else {
+			content, err = os.ReadFile(p)
+		}
+		if err != nil {
+			return err
+		}
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(content); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, name)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("백업 생성 오류: %w", err)
+	}
+
+	f, err := zw.Create(backupManifestName)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(manifest); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// unrestorable reports whether a backup entry holds something the app would
+// execute. Plugins are never restored: a crafted backup could otherwise make
+// the app run any program.
+func unrestorable(name string) bool {
+	return name == pluginsDir || strings.HasPrefix(name, pluginsDir+"/")
+}
+
+// keepInstallSettings gives dst the settings of src that belong to this
+// install rather than to the teacher's material: the API profiles, the
+// linked accounts, the credentials and the addresses data is sent to. A
+// backup carries none of them, and restoring one keeps the current ones, so
+// a shared backup neither leaks credentials nor points sync, webhooks or
+// local servers somewhere else.
+func keepInstallSettings(dst *Settings, src Settings) {
+	dst.Profiles = src.Profiles
+	dst.ActiveProfile = src.ActiveProfile
+	dst.Google = src.Google
+	dst.Sync = src.Sync
+	dst.SMTP = src.SMTP
+	dst.SharedBank = src.SharedBank
+	dst.Webhook = src.Webhook
+	dst.API = src.API
+	dst.IPC = src.IPC
+	dst.Dictionary.AppID = src.Dictionary.AppID
+	dst.Dictionary.APIKey = src.Dictionary.APIKey
+	dst.Grammar.Server = src.Grammar.Server
+	dst.Grammar.APIKey = src.Grammar.APIKey
+	dst.Grammar.User = src.Grammar.User
+	dst.OutputDir = src.OutputDir
+}
+
+// backupSettings returns the settings file as written into a backup, with
+// the install settings left out.
+func backupSettings() ([]byte, error) {
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	keepInstallSettings(&settings, Settings{})
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return encodeStore(content)
+}
+
+// restoreBackup extracts a backup zip over the data directory. Entries that
+// would escape the directory are rejected before anything is written;
+// plugins and the install settings are kept as they are.
+func restoreBackup(zipPath string) (int, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return 0, fmt.Errorf("백업 파일 열기 오류: %w", err)
+	}
+	defer zr.Close()
+
+	hasManifest := false
+	for _, f := range zr.File {
+		if !filepath.IsLocal(filepath.FromSlash(f.Name)) {
+			return 0, fmt.Errorf("잘못된 백업 항목입니다: %s", f.Name)
+		}
+		clean := path.Clean(f.Name)
+		if clean == backupManifestName {
+			hasManifest = true
+		}
+	}
+	if !hasManifest {
+		return 0, fmt.Errorf("이 앱의 백업 파일이 아닙니다")
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return 0, err
+	}
+	current, err := loadSettings()
+	if err != nil {
+		return 0, err
+	}
+	restored := 0
+	for _, f := range zr.File {
+		clean := path.Clean(f.Name)
+		if f.FileInfo().IsDir() || clean == backupManifestName || unrestorable(clean) {
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(clean))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return restored, err
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return restored, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return restored, fmt.Errorf("%s 읽기 오류: %w", f.Name, err)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return restored, fmt.Errorf("%s 복원 오류: %w", f.Name, err)
+		}
+		restored++
+	}
+
+	settings, err := loadSettings()
+	if err != nil {
+		return restored, err
+	}
+	keepInstallSettings(&settings, current)
+	return restored, saveJSON(settingsFile, settings)
+}
+
+// --- Go functions callable from Javascript ---
+
+func (a *VocabApp) ExportBackup() (string, error) {
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "백업 저장",
+		DefaultFilename: fmt.Sprintf("vocab-backup_%s.zip", time.Now().Format("20060102")),
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "백업 파일 (*.zip)",
+				Pattern:     "*.zip",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if filePath == "" {
+		return "", fmt.Errorf("저장 경로가 선택되지 않았습니다")
+	}
+
+	out, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.mu.Lock()
+	err = writeBackup(out)
+	a.mu.Unlock()
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return "", err
+	}
+	return fmt.Sprintf("백업 완료: %s", filepath.Base(filePath)), nil
+}
+
+// ImportBackup restores a backup made with ExportBackup, replacing the
+// current data files with the ones in the archive. The install settings of
+// this computer stay as they are.
+func (a *VocabApp) ImportBackup() (string, error) {
+	selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "백업 파일 선택",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "백업 파일 (*.zip)",
+				Pattern:     "*.zip",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if selection == "" {
+		return "", fmt.Errorf("파일이 선택되지 않았습니다")
+	}
+
+	a.mu.Lock()
+	restored, err := restoreBackup(selection)
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if err := a.reloadClient(); err != nil && err != errNoAPIKey {
+		runtime.LogErrorf(a.ctx, "%v", err)
+	}
+	return fmt.Sprintf("복원 완료: 파일 %d개", restored), nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Question Bank ---
+
+const bankFile = "bank.json"
+
+// BankQuestion is a single question kept for reuse in later tests.
+type BankQuestion struct {
+	ID           string     `json:"id"`
+	TestID       string     `json:"testId"`
+	QuestionType string     `json:"questionType"`
+	Question     Question   `json:"question"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
+	// Stats is the item analysis from the latest grading of the source test.
+	Stats *ItemStat `json:"stats,omitempty"`
+
+	// Status is one of the QuestionStatus constants; empty means active.
+	// StatusManual marks a status set by the teacher, which automatic
+	// flagging leaves alone.
+	Status       string `json:"status,omitempty"`
+	StatusReason string `json:"statusReason,omitempty"`
+	StatusManual bool   `json:"statusManual,omitempty"`
+	// Contributor is the teacher who shared the question over the LAN bank.
+	Contributor string `json:"contributor,omitempty"`
+	// Alternatives are other sampled versions of the question, see
+	// SwapBankAlternative.
+	Alternatives []Question `json:"alternatives,omitempty"`
+}
+
+const (
+	QuestionActive      = "active"
+	QuestionNeedsReview = "needs-review"
+	QuestionRetired     = "retired"
+)
+
+// reviewMinResponses is the number of graded students item statistics need
+// before they can flag a question.
+const reviewMinResponses = 5
+
+func loadBank() ([]BankQuestion, error) {
+	var bank []BankQuestion
+	return bank, loadJSON(bankFile, &bank)
+}
+
+func (q BankQuestion) retired() bool {
+	return q.Status == QuestionRetired
+}
+
+// flag marks an active question for review unless the teacher has set its
+// status by hand.
+func (q *BankQuestion) flag(reason string) {
+	if q.StatusManual || (q.Status != "" && q.Status != QuestionActive) {
+		return
+	}
+	q.Status = QuestionNeedsReview
+	q.StatusReason = reason
+}
+
+// statsReviewReason explains why item statistics call for a review, or
+// returns "" when they look fine.
+func statsReviewReason(s ItemStat) string {
+	if s.Responses < reviewMinResponses {
+		return ""
+	}
+	switch {
+	case s.Discrimination < 0 || s.PointBiserial < 0:
+		return "변별도가 음수입니다 (하위 학생이 더 잘 맞힘)"
+	case s.Difficulty < 0.2:
+		return "정답률이 20% 미만입니다 (정답 오류 가능)"
+	}
+	return ""
+}
+
+// --- Go functions callable from Javascript ---
+
+// AddTestToBank copies every question of a saved test that has a valid
+// answer into the question bank and returns how many were added.
+func (a *VocabApp) AddTestToBank(testID string) (int, error) {
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return 0, err
+	}
+	out := parseOutput(test.Content)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bank, err := loadBank()
+	if err != nil {
+		return 0, err
+	}
+	runs, err := loadRuns()
+	if err != nil {
+		return 0, err
+	}
+	// Answer key errors against the source word list flag the question; the
+	// structural checks need generation options the saved test lacks.
+	keyErrors := map[int]string{}
+	run, found := findRun(runs, test.Content)
+	if found {
+		for _, issue := range validateOutput(test.Content, run.Entries, test.QuestionType, 0).AnswerKeyErrors {
+			keyErrors[issue.Question] = issue.Message
+		}
+	}
+	added := 0
+	now := time.Now()
+	for _, q := range out.Questions {
+		if q.Answer < 1 || q.Answer > len(q.Choices) {
+			continue
+		}
+		b := BankQuestion{
+			ID:           newID(),
+			TestID:       test.ID,
+			QuestionType: test.QuestionType,
+			Question:     q,
+			CreatedAt:    now,
+			Alternatives: run.Alternatives[q.Number],
+		}
+		if msg, ok := keyErrors[q.Number]; ok {
+			b.flag("검증 실패: " + msg)
+		}
+		bank = append(bank, b)
+		added++
+	}
+	if added == 0 {
+		return 0, fmt.Errorf("정답이 있는 문제를 찾을 수 없습니다")
+	}
+	return added, saveJSON(bankFile, bank)
+}
+
+// ListBankQuestions returns live bank questions, optionally filtered by type.
+// Retired questions are left out unless includeRetired is set, so tests
+// assembled from the list do not reuse them.
+func (a *VocabApp) ListBankQuestions(questionType string, includeRetired bool) ([]BankQuestion, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bank, err := loadBank()
+	if err != nil {
+		return nil, err
+	}
+	result := []BankQuestion{}
+	for _, q := range bank {
+		if q.DeletedAt == nil && (questionType == "" || q.QuestionType == questionType) && (includeRetired || !q.retired()) {
+			result = append(result, q)
+		}
+	}
+	return result, nil
+}
+
+// SetQuestionStatus sets the status of a bank question by hand. An empty
+// status hands the question back to automatic flagging as active.
+func (a *VocabApp) SetQuestionStatus(id string, status string, reason string) (BankQuestion, error) {
+	switch status {
+	case "", QuestionActive, QuestionNeedsReview, QuestionRetired:
+	default:
+		return BankQuestion{}, fmt.Errorf("지원하지 않는 문항 상태입니다: '%s'", status)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bank, err := loadBank()
+	if err != nil {
+		return BankQuestion{}, err
+	}
+	for i := range bank {
+		if bank[i].ID == id && bank[i].DeletedAt == nil {
+			bank[i].Status = status
+			bank[i].StatusReason = strings.TrimSpace(reason)
+			bank[i].StatusManual = status != ""
+			return bank[i], saveJSON(bankFile, bank)
+		}
+	}
+	return BankQuestion{}, fmt.Errorf("문항을 찾을 수 없습니다")
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Question Bank Import ---
+
+const (
+	DuplicateSkip    = "skip"    // keep the bank's question (default)
+	DuplicateReplace = "replace" // overwrite the bank's question with the imported one
+	DuplicateKeep    = "keep"    // import it as a separate question
+)
+
+// BankImportConflict is an imported question whose text matches a bank
+// question with a different answer.
+type BankImportConflict struct {
+	Question   string `json:"question"`
+	Resolution string `json:"resolution"`
+}
+
+type BankImportReport struct {
+	Format     string               `json:"format"`
+	Added      int                  `json:"added"`
+	Replaced   int                  `json:"replaced"`
+	Duplicates int                  `json:"duplicates"` // identical questions left out
+	Conflicts  []BankImportConflict `json:"conflicts"`
+	Skipped    []string             `json:"skipped"` // entries that could not be read
+}
+
+// importedQuestion is one question read from a file, before it is merged.
+type importedQuestion struct {
+	QuestionType string
+	Question     Question
+}
+
+// importAnswer resolves an answer given as a number, a letter (A-E), a
+// circled marker or the text of a choice to a 1-based choice index.
+func importAnswer(answer string, choices []string) int {
+	answer = strings.TrimSpace(answer)
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+		return n
+	}
+	if r := []rune(answer); len(r) == 1 {
+		if i := choiceIndex(r[0]); i > 0 && i <= len(choices) {
+			return i
+		}
+		if u := strings.ToUpper(answer); u >= "A" && u <= "Z" && int(u[0]-'A') < len(choices) {
+			return int(u[0]-'A') + 1
+		}
+	}
+	for i, c := range choices {
+		if strings.EqualFold(c, answer) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// usable reports whether an imported question can be graded: a choice
+// question needs a valid answer, a written one its answer text.
+func (q importedQuestion) usable() bool {
+	if len(q.Question.Choices) == 0 {
+		return q.Question.AnswerText != ""
+	}
+	return q.Question.Answer >= 1 && q.Question.Answer <= len(q.Question.Choices)
+}
+
+func questionSummary(q Question) string {
+	return truncateRunes(strings.Join(q.Body, " "), 60)
+}
+
+// parseBankJSON reads the question bank file (bank.json) or a test exported
+// as JSON.
+func parseBankJSON(data []byte, questionType string) ([]importedQuestion, error) {
+	var bank []BankQuestion
+	if err := json.Unmarshal(data, &bank); err == nil {
+		var qs []importedQuestion
+		for _, b := range bank {
+			if b.DeletedAt == nil {
+				qs = append(qs, importedQuestion{b.QuestionType, b.Question})
+			}
+		}
+		return qs, nil
+	}
+	var test struct {
+		Questions []Question      `json:"questions"`
+		Metadata  *ExportMetadata `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &test); err != nil {
+		return nil, fmt.Errorf("JSON 형식 오류: %w", err)
+	}
+	if test.Metadata != nil && test.Metadata.QuestionType != "" {
+		questionType = test.Metadata.QuestionType
+	}
+	var qs []importedQuestion
+	for _, q := range test.Questions {
+		qs = append(qs, importedQuestion{questionType, q})
+	}
+	return qs, nil
+}
+
+// textBlocks splits a text file into blocks separated by blank lines.
+func textBlocks(text string) [][]string {
+	var blocks [][]string
+	var cur []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(cur) > 0 {
+				blocks = append(blocks, cur)
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, strings.TrimSpace(line))
+	}
+	if len(cur) > 0 {
+		blocks = append(blocks, cur)
+	}
+	return blocks
+}
+
+var (
+	aikenChoiceRe = regexp.MustCompile(`^([A-Z])[.)]\s+(.*)$`)
+	aikenAnswerRe = regexp.MustCompile(`(?mi)^\s*ANSWER:`)
+)
+
+// parseAiken reads the Aiken format: the question, choices "A. ..." or
+// "A) ...", and an "ANSWER: B" line.
+func parseAiken(text string, questionType string) ([]importedQuestion, []string) {
+	var qs []importedQuestion
+	var skipped []string
+	for _, block := range textBlocks(text) {
+		var q Question
+		answer := ""
+		for _, line := range block {
+			switch m := aikenChoiceRe.FindStringSubmatch(line); {
+			case strings.HasPrefix(strings.ToUpper(line), "ANSWER:"):
+				answer = strings.TrimSpace(line[len("ANSWER:"):])
+			case m != nil && answer == "":
+				q.Choices = append(q.Choices, strings.TrimSpace(m[2]))
+			case len(q.Choices) == 0:
+				q.Body = append(q.Body, line)
+			}
+		}
+		q.Answer = importAnswer(answer, q.Choices)
+		iq := importedQuestion{questionType, q}
+		if !iq.usable() {
+			skipped = append(skipped, block[0])
+			continue
+		}
+		qs = append(qs, iq)
+	}
+	return qs, skipped
+}
+
+var (
+	giftTitleRe  = regexp.MustCompile(`^::(.*?)::`)
+	giftWeightRe = regexp.MustCompile(`^%-?[0-9.]+%`)
+)
+
+// giftUnescape removes the GIFT escapes of special characters.
+func giftUnescape(s string) string {
+	return strings.NewReplacer(`\~`, "~", `\=`, "=", `\#`, "#", `\{`, "{", `\}`, "}", `\:`, ":", `\n`, " ").Replace(strings.TrimSpace(s))
+}
+
+// splitGIFTAnswers splits the answer block at unescaped "=" and "~",
+// returning each answer with its marker.
+func splitGIFTAnswers(block string) []string {
+	var parts []string
+	var cur strings.Builder
+	runes := []rune(block)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) {
+			cur.WriteRune(r)
+			cur.WriteRune(runes[i+1])
+			i++
+			continue
+		}
+		if (r == '=' || r == '~') && strings.TrimSpace(cur.String()) != "" {
+			parts = append(parts, cur.String())
+			cur.Reset()
+		}
+		cur.WriteRune(r)
+	}
+	if strings.TrimSpace(cur.String()) != "" {
+		parts = append(parts, cur.String())
+	}
+	return parts
+}
+
+// parseGIFT reads Moodle GIFT multiple-choice ({=right ~wrong}) and short
+// answer ({=answer}) questions. An answer block inside the text becomes a
+// blank; other question kinds are skipped.
+func parseGIFT(text string, questionType string) ([]importedQuestion, []string) {
+	var kept []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") && !strings.HasPrefix(strings.TrimSpace(line), "$CATEGORY") {
+			kept = append(kept, line)
+		}
+	}
+	var qs []importedQuestion
+	var skipped []string
+	for _, block := range textBlocks(strings.Join(kept, "\n")) {
+		src := strings.Join(block, " ")
+		var q Question
+		if m := giftTitleRe.FindStringSubmatch(src); m != nil {
+			q.Title = giftUnescape(m[1])
+			src = strings.TrimSpace(src[len(m[0]):])
+		}
+		open, close := strings.Index(src, "{"), strings.LastIndex(src, "}")
+		if open < 0 || close < open {
+			skipped = append(skipped, truncateRunes(src, 60))
+			continue
+		}
+		before, after := strings.TrimSpace(src[:open]), strings.TrimSpace(src[close+1:])
+		stem := before
+		if after != "" {
+			stem = strings.TrimSpace(before + " _____ " + after)
+		}
+		q.Body = []string{giftUnescape(stem)}
+
+		allRight := true
+		var right []string
+		for _, part := range splitGIFTAnswers(src[open+1 : close]) {
+			part = strings.TrimSpace(part)
+			marker, ans := part[0], part[1:]
+			if i := strings.Index(ans, "#"); i >= 0 && (i == 0 || ans[i-1] != '\\') {
+				ans = ans[:i] // feedback
+			}
+			ans = giftUnescape(giftWeightRe.ReplaceAllString(strings.TrimSpace(ans), ""))
+			if marker == '~' {
+				allRight = false
+			} else if marker == '=' {
+				right = append(right, ans)
+				if q.Answer == 0 {
+					q.Answer = len(q.Choices) + 1
+				}
+			} else {
+				continue
+			}
+			q.Choices = append(q.Choices, ans)
+		}
+		if allRight && len(right) > 0 {
+			q.Choices, q.Answer, q.AnswerText = nil, 0, right[0]
+		}
+		iq := importedQuestion{questionType, q}
+		if !iq.usable() {
+			skipped = append(skipped, truncateRunes(src, 60))
+			continue
+		}
+		qs = append(qs, iq)
+	}
+	return qs, skipped
+}
+
+// parseBankCSV reads rows of "question, choice 1, ..., choice n, answer".
+// Rows without choices are written-answer questions; a header row is
+// recognized by its unusable answer and left out.
+func parseBankCSV(data []byte, questionType string) ([]importedQuestion, []string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("CSV 형식 오류: %w", err)
+	}
+	var qs []importedQuestion
+	var skipped []string
+	for i, row := range rows {
+		for len(row) > 0 && strings.TrimSpace(row[len(row)-1]) == "" {
+			row = row[:len(row)-1]
+		}
+		if len(row) < 2 {
+			continue
+		}
+		q := Question{Body: []string{strings.TrimSpace(row[0])}}
+		for _, c := range row[1 : len(row)-1] {
+			if c = strings.TrimSpace(c); c != "" {
+				q.Choices = append(q.Choices, c)
+			}
+		}
+		answer := strings.TrimSpace(row[len(row)-1])
+		if len(q.Choices) == 0 {
+			q.AnswerText = answer
+		} else {
+			q.Answer = importAnswer(answer, q.Choices)
+		}
+		iq := importedQuestion{questionType, q}
+		if !iq.usable() {
+			if i > 0 {
+				skipped = append(skipped, fmt.Sprintf("%d행: %s", i+1, truncateRunes(row[0], 40)))
+			}
+			continue
+		}
+		qs = append(qs, iq)
+	}
+	return qs, skipped, nil
+}
+
+// readBankFile detects the format from the extension (and, for .txt, from
+// an Aiken "ANSWER:" line) and reads the questions.
+func readBankFile(path string, questionType string) (string, []importedQuestion, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("파일 읽기 오류: %w", err)
+	}
+	text := string(data)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		qs, err := parseBankJSON(data, questionType)
+		return "json", qs, nil, err
+	case ".csv":
+		qs, skipped, err := parseBankCSV(data, questionType)
+		return "csv", qs, skipped, err
+	case ".gift":
+		qs, skipped := parseGIFT(text, questionType)
+		return "gift", qs, skipped, nil
+	case ".txt":
+		if aikenAnswerRe.MatchString(text) {
+			qs, skipped := parseAiken(text, questionType)
+			return "aiken", qs, skipped, nil
+		}
+		qs, skipped := parseGIFT(text, questionType)
+		return "gift", qs, skipped, nil
+	default:
+		return "", nil, nil, fmt.Errorf("지원하지 않는 파일 형식입니다: '%s'", filepath.Ext(path))
+	}
+}
+
+func sameAnswer(a, b Question) bool {
+	return a.Answer == b.Answer && strings.EqualFold(a.AnswerText, b.AnswerText)
+}
+
+// mergeImported adds the imported questions to the bank. Identical
+// questions are left out; a question that matches one in the bank but has a
+// different answer is a conflict, resolved by onDuplicate.
+func mergeImported(bank []BankQuestion, imported []importedQuestion, onDuplicate string, report *BankImportReport) []BankQuestion {
+	now := time.Now()
+	for _, iq := range imported {
+		incoming := BankQuestion{QuestionType: iq.QuestionType, Question: iq.Question}
+		match := -1
+		for i := range bank {
+			if bank[i].DeletedAt == nil && sameQuestion(bank[i], incoming) {
+				match = i
+				break
+			}
+		}
+		if match >= 0 && sameAnswer(bank[match].Question, iq.Question) {
+			report.Duplicates++
+			continue
+		}
+		if match >= 0 {
+			conflict := BankImportConflict{Question: questionSummary(iq.Question), Resolution: onDuplicate}
+			report.Conflicts = append(report.Conflicts, conflict)
+			switch onDuplicate {
+			case DuplicateSkip:
+				continue
+			case DuplicateReplace:
+				bank[match].Question = iq.Question
+				bank[match].Stats = nil
+				report.Replaced++
+				continue
+			}
+		}
+		incoming.ID = newID()
+		incoming.CreatedAt = now
+		bank = append(bank, incoming)
+		report.Added++
+	}
+	return bank
+}
+
+// --- Go functions callable from Javascript ---
+
+// ImportBank reads questions from a file — the app's bank or JSON test
+// export, GIFT, Aiken or CSV — into the question bank. Without a path a file
+// dialog is shown. questionType is used for formats that do not carry one;
+// onDuplicate is one of the Duplicate constants.
+func (a *VocabApp) ImportBank(filePath string, questionType string, onDuplicate string) (BankImportReport, error) {
+	switch onDuplicate {
+	case "":
+		onDuplicate = DuplicateSkip
+	case DuplicateSkip, DuplicateReplace, DuplicateKeep:
+	default:
+		return BankImportReport{}, fmt.Errorf("지원하지 않는 중복 처리 방식입니다: '%s'", onDuplicate)
+	}
+	if _, ok := defaultQuestionTitles[questionType]; questionType != "" && !ok {
+		return BankImportReport{}, fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", questionType)
+	}
+	if filePath == "" {
+		selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "문제은행 파일 선택",
+			Filters: []runtime.FileFilter{
+				{
+					DisplayName: "문제은행 파일 (*.json, *.gift, *.txt, *.csv)",
+					Pattern:     "*.json;*.gift;*.txt;*.csv",
+				},
+			},
+		})
+		if err != nil {
+			return BankImportReport{}, err
+		}
+		if selection == "" {
+			return BankImportReport{}, fmt.Errorf("파일이 선택되지 않았습니다")
+		}
+		filePath = selection
+	}
+
+	format, imported, skipped, err := readBankFile(filePath, questionType)
+	if err != nil {
+		return BankImportReport{}, err
+	}
+	report := BankImportReport{Format: format, Conflicts: []BankImportConflict{}, Skipped: skipped}
+	if report.Skipped == nil {
+		report.Skipped = []string{}
+	}
+	if len(imported) == 0 {
+		return report, fmt.Errorf("가져올 수 있는 문항이 없습니다")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bank, err := loadBank()
+	if err != nil {
+		return report, err
+	}
+	bank = mergeImported(bank, imported, onDuplicate, &report)
+	return report, saveJSON(bankFile, bank)
+}
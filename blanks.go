@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Blank Consistency (빈칸 추론) ---
+
+const blankMarker = "_______"
+
+const blankCheckPrompt = `You are a strict proofreader of English fill-in-the-blank vocabulary questions for Korean students.
+For each question you get the answer word and its context sentences. Sentences marked OK are shown with the answer already inserted in [brackets]; sentences marked BROKEN do not have exactly one blank. When inflected forms are listed for a question, the bracketed answer may stand for any of those forms, so judge the sentence with the fitting form.
+Rewrite every BROKEN sentence, and every OK sentence that is ungrammatical or unnatural with the answer inserted, as a natural sentence in which the answer word fits exactly one blank written as _______. Keep the meaning and difficulty of the original sentence. Leave good sentences out of the response.
+Respond with JSON only: {"questions": [{"question": 1, "sentences": {"2": "rewritten sentence with _______"}}]}. Sentence numbers are 1-based.`
+
+// BlankCheckReport is emitted as "generate:blanks-checked".
+type BlankCheckReport struct {
+	Checked       int               `json:"checked"`
+	LocalRepairs  int               `json:"localRepairs"`  // sentences fixed in Go without a model call
+	ModelRepaired []int             `json:"modelRepaired"` // questions with sentences rewritten by the model
+	Unresolved    []ValidationIssue `json:"unresolved"`
+}
+
+type blankRepairResult struct {
+	Questions []struct {
+		Question  int               `json:"question"`
+		Sentences map[string]string `json:"sentences"`
+	} `json:"questions"`
+}
+
+// wordPattern matches the answer as a whole word, ignoring case.
+func wordPattern(word string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`)
+}
+
+// repairBlanksLocally normalizes blanks to blankMarker and blanks out the
+// answer in sentences the model forgot to blank. It returns the number of
+// sentences fixed and the 0-based indexes of sentences that still do not have
+// exactly one blank.
+func repairBlanksLocally(q *Question, answer string) (fixed int, broken []int) {
+	re := wordPattern(answer)
+	for i, line := range q.Body {
+		switch n := len(blankRe.FindAllString(line, -1)); {
+		case n == 1:
+			q.Body[i] = blankRe.ReplaceAllString(line, blankMarker)
+		case n == 0 && len(re.FindAllStringIndex(line, -1)) == 1:
+			q.Body[i] = re.ReplaceAllString(line, blankMarker)
+			fixed++
+		default:
+			broken = append(broken, i)
+		}
+	}
+	return fixed, broken
+}
+
+// describeBlankQuestions renders the questions for blankCheckPrompt.
+func describeBlankQuestions(questions []Question, broken map[int][]int) string {
+	var b strings.Builder
+	for _, q := range questions {
+		answer := q.Choices[q.Answer-1]
+		if len(q.Forms) > 0 {
+			fmt.Fprintf(&b, "Question %d (answer: %s; the blanks may take the forms %s)\n", q.Number, answer, strings.Join(q.Forms, ", "))
+		} else {
+			fmt.Fprintf(&b, "Question %d (answer: %s)\n", q.Number, answer)
+		}
+		for i, line := range q.Body {
+			if containsInt(broken[q.Number], i) {
+				fmt.Fprintf(&b, "  %d BROKEN: %s\n", i+1, line)
+			} else {
+				fmt.Fprintf(&b, "  %d OK: %s\n", i+1, strings.Replace(line, blankMarker, "["+answer+"]", 1))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// checkBlanks makes sure every context sentence of a 빈칸 추론 question has
+// exactly one blank and reads naturally with the answer filled in. Simple
+// cases are fixed in Go; the rest, and the grammar check, take one model call.
+func (a *VocabApp) checkBlanks(modelID string, output string) (string, BlankCheckReport, error) {
+	var report BlankCheckReport
+	out := parseOutput(output)
+	broken := map[int][]int{}
+	var keyed []Question
+	for i := range out.Questions {
+		q := &out.Questions[i]
+		if q.Answer < 1 || q.Answer > len(q.Choices) {
+			continue
+		}
+		fixed, bad := repairBlanksLocally(q, q.Choices[q.Answer-1])
+		report.LocalRepairs += fixed
+		if len(bad) > 0 {
+			broken[q.Number] = bad
+		}
+		keyed = append(keyed, *q)
+	}
+	report.Checked = len(keyed)
+	if len(keyed) == 0 {
+		return output, report, nil
+	}
+
+	var repair blankRepairResult
+	err := a.callChatGPTJSON(modelID, blankCheckPrompt, describeBlankQuestions(keyed, broken), &repair)
+	if err == nil {
+		index := map[int]int{}
+		for i, q := range out.Questions {
+			index[q.Number] = i
+		}
+		for _, r := range repair.Questions {
+			i, ok := index[r.Question]
+			if !ok {
+				continue
+			}
+			q := &out.Questions[i]
+			changed := false
+			for key, sentence := range r.Sentences {
+				n, convErr := strconv.Atoi(key)
+				if convErr != nil || n < 1 || n > len(q.Body) || len(blankRe.FindAllString(sentence, -1)) != 1 {
+					continue
+				}
+				q.Body[n-1] = blankRe.ReplaceAllString(strings.TrimSpace(sentence), blankMarker)
+				changed = true
+			}
+			if changed {
+				report.ModelRepaired = append(report.ModelRepaired, q.Number)
+			}
+		}
+	}
+
+	for _, q := range out.Questions {
+		for i, line := range q.Body {
+			if n := len(blankRe.FindAllString(line, -1)); n != 1 {
+				report.Unresolved = append(report.Unresolved, ValidationIssue{Question: q.Number, Message: fmt.Sprintf("예문 %d에 빈칸이 %d개입니다", i+1, n)})
+			}
+		}
+	}
+	return formatOutput(out), report, err
+}
+
+// checkBlanksAndReport runs checkBlanks for Generate. If the model call fails
+// the Go-side repairs are still kept.
+func (a *VocabApp) checkBlanksAndReport(modelID string, output string) string {
+	checked, report, err := a.checkBlanks(modelID, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "빈칸 검사 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:blanks-checked", report)
+	return checked
+}
@@ -0,0 +1,109 @@
+package main
+
+import "strings"
+
+// --- Blank Styles ---
+
+// Blank styles of GenerationSettings.BlankStyle.
+const (
+	// BlankUniform writes every blank as blankMarker, whatever the word.
+	BlankUniform = "uniform"
+	// BlankPerLetter writes one underscore per letter of the answer, so the
+	// blank hints at the word length ("give up" -> "____ __").
+	BlankPerLetter = "letters"
+)
+
+// blankAnswer is the word that fills the blanks of a question: the written
+// answer of production questions, otherwise the keyed choice.
+func blankAnswer(q Question) string {
+	if q.AnswerText != "" {
+		return q.AnswerText
+	}
+	if q.Answer >= 1 && q.Answer <= len(q.Choices) {
+		return strings.TrimSpace(q.Choices[q.Answer-1])
+	}
+	return ""
+}
+
+// letterBlank renders answer as one underscore per letter, keeping the
+// spaces and hyphens of multi-word answers.
+func letterBlank(answer string) string {
+	var b strings.Builder
+	for _, r := range answer {
+		switch r {
+		case ' ', '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// hintLetter is the first letter shown in the blanks of a question, or ""
+// when the forms used in the sentences do not all start with the same letter
+// as the answer (go / went).
+func hintLetter(q Question, answer string) string {
+	first := []rune(answer)[0]
+	for _, f := range q.Forms {
+		if r := []rune(strings.TrimSpace(f)); len(r) == 0 || r[0] != first {
+			return ""
+		}
+	}
+	return string(first)
+}
+
+// hintedRest keeps at least three underscores after the hint letter, so a
+// short word ("at" -> "a___") still prints as a blank to write in.
+func hintedRest(rest string) string {
+	first := rest
+	if i := strings.IndexAny(rest, " -"); i >= 0 {
+		first = rest[:i]
+	}
+	if len(first) < 3 {
+		return strings.Repeat("_", 3) + rest[len(first):]
+	}
+	return rest
+}
+
+// styleQuestionBlanks rewrites the blanks of questions in the chosen style,
+// with the first letter of the answer shown when firstLetter is set
+// ("a______"). With BlankPerLetter the length follows the keyed base form,
+// so questions whose sentences use inflected forms keep uniform blanks, as
+// do questions without a usable key. Styling is for printing only: short
+// blanks no longer match blankRe, so stored tests keep blankMarker.
+func styleQuestionBlanks(questions []Question, style string, firstLetter bool) bool {
+	if style == "" && !firstLetter {
+		return false
+	}
+	changed := false
+	for i := range questions {
+		q := &questions[i]
+		answer := blankAnswer(*q)
+		blank := blankMarker
+		if style == BlankPerLetter && answer != "" && (len(q.Forms) == 0 || q.AnswerText != "") {
+			blank = letterBlank(answer)
+		}
+		if firstLetter && answer != "" {
+			if letter := hintLetter(*q, answer); letter != "" {
+				blank = letter + hintedRest(blank[1:])
+			}
+		}
+		for j, line := range q.Body {
+			if styled := blankRe.ReplaceAllLiteralString(line, blank); styled != line {
+				q.Body[j] = styled
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// styleBlanks is styleQuestionBlanks for the text of a whole test.
+func styleBlanks(output string, style string, firstLetter bool) string {
+	out := parseOutput(output)
+	if !styleQuestionBlanks(out.Questions, style, firstLetter) {
+		return output
+	}
+	return formatOutput(out)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Prompt Token Budget ---
+
+// modelLimit is the context window and the maximum completion of a model, in
+// tokens.
+type modelLimit struct {
+	Context int
+	Output  int
+}
+
+var modelLimits = map[string]modelLimit{
+	"gpt-5-pro":    {400000, 272000},
+	"gpt-5":        {400000, 128000},
+	"gpt-5-mini":   {400000, 128000},
+	"gpt-5-nano":   {400000, 128000},
+	"gpt-4.1":      {1047576, 32768},
+	"gpt-4.1-mini": {1047576, 32768},
+	"gpt-4.1-nano": {1047576, 32768},
+	"gpt-4o":       {128000, 16384},
+	"gpt-4o-mini":  {128000, 16384},
+}
+
+// defaultModelLimit is assumed for models not in the table.
+var defaultModelLimit = modelLimit{128000, 16384}
+
+// limitFor looks up the limits of a model, using the base model's for
+// fine-tuned IDs (ft:<base>:...).
+func limitFor(model string) modelLimit {
+	if strings.HasPrefix(model, "ft:") {
+		model = strings.Split(model, ":")[1]
+	}
+	if l, ok := modelLimits[model]; ok {
+		return l
+	}
+	return defaultModelLimit
+}
+
+// expectedCompletionTokens estimates the output of a run: the question block
+// with its sentences and choices, plus the answer key line.
+func expectedCompletionTokens(questions int, numSentences int) int {
+	if numSentences < 1 {
+		numSentences = 1
+	}
+	return questions*(90+45*numSentences) + 50
+}
+
+// BudgetReport is emitted as "generate:budget" when a prompt had to be cut
+// down to fit the model.
+type BudgetReport struct {
+	Model           string `json:"model"`
+	ContextWindow   int    `json:"contextWindow"`
+	MaxOutput       int    `json:"maxOutput"`
+	DroppedExamples int    `json:"droppedExamples"`
+	Chunks          int    `json:"chunks"`
+}
+
+// promptPlan is how a run is sent: the few-shot examples that fit and the
+// word list split into chunks, one API call each.
+type promptPlan struct {
+	Examples []string
+	Chunks   [][]VocabPair
+}
+
+// planPrompt fits a run into the model's limits. Few-shot examples are
+// dropped from the end first; if the run still does not fit, the list is
+// split into the largest chunks that do. Chunk sizes stay multiples of
+// passageWordsPerQuestion so passage questions keep their word groups.
+func planPrompt(limit modelLimit, systemPrompt string, examples []string, entries []VocabPair, cost func(VocabPair) int, numSentences int) (promptPlan, error) {
+	fits := func(examples []string, chunk []VocabPair) bool {
+		questions := 0
+		for _, e := range chunk {
+			questions += cost(e)
+		}
+		completion := expectedCompletionTokens(questions, numSentences)
+		prompt := estimateTokens(systemPrompt) + estimateTokens(fewShotSection(examples)) + estimateTokens(buildUserPrompt(chunk))
+		return completion <= limit.Output && prompt+completion <= limit.Context
+	}
+	chunked := func(size int) [][]VocabPair {
+		var chunks [][]VocabPair
+		for start := 0; start < len(entries); start += size {
+			end := start + size
+			if end > len(entries) {
+				end = len(entries)
+			}
+			chunks = append(chunks, entries[start:end])
+		}
+		return chunks
+	}
+
+	for n := len(examples); n >= 0; n-- {
+		if fits(examples[:n], entries) {
+			return promptPlan{Examples: examples[:n], Chunks: [][]VocabPair{entries}}, nil
+		}
+	}
+	for size := len(entries) - len(entries)%passageWordsPerQuestion; size >= passageWordsPerQuestion; size -= passageWordsPerQuestion {
+		chunks := chunked(size)
+		ok := true
+		for _, c := range chunks {
+			ok = ok && fits(nil, c)
+		}
+		if ok {
+			return promptPlan{Chunks: chunks}, nil
+		}
+	}
+	if chunks := chunked(1); len(chunks) > 0 && fits(nil, chunks[0]) {
+		return promptPlan{Chunks: chunks}, nil
+	}
+	return promptPlan{}, fmt.Errorf("프롬프트가 모델의 컨텍스트 한도(%d 토큰)보다 깁니다", limit.Context)
+}
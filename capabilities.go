@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// --- Provider Capabilities ---
+
+// Capabilities is what the active provider can do with a model. The pipeline
+// picks its strategy from it: without JSON mode the JSON is read from plain
+// text, without streaming the whole reply arrives at once, and so on.
+type Capabilities struct {
+	JSONMode   bool `json:"jsonMode"`
+	Tools      bool `json:"tools"`
+	Streaming  bool `json:"streaming"`
+	Vision     bool `json:"vision"`
+	Logprobs   bool `json:"logprobs"`
+	MaxContext int  `json:"maxContext"`
+	MaxOutput  int  `json:"maxOutput"`
+	// Degraded lists the features turned off after the server rejected them.
+	Degraded []string `json:"degraded,omitempty"`
+}
+
+// Features turned off when a request using them is rejected.
+const (
+	featureJSONMode  = "jsonMode"
+	featureStreaming = "streaming"
+	featureLogprobs  = "logprobs"
+)
+
+// visionModels are the model families that accept image input.
+var visionModels = []string{"gpt-4o", "gpt-4.1", "gpt-5", "o1", "o3", "o4"}
+
+func supportsVision(model string) bool {
+	if strings.HasPrefix(model, "ft:") {
+		model = strings.Split(model, ":")[1]
+	}
+	for _, prefix := range visionModels {
+		if strings.HasPrefix(model, prefix) && !strings.HasPrefix(model, "o1-mini") && !strings.HasPrefix(model, "o3-mini") {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilityState remembers, per model, the features the server rejected in
+// this session, so later calls do not fail the same way. It is reset when the
+// active profile changes.
+type capabilityState struct {
+	mu       sync.Mutex
+	degraded map[string]map[string]bool
+}
+
+func (s *capabilityState) reset() {
+	s.mu.Lock()
+	s.degraded = nil
+	s.mu.Unlock()
+}
+
+func (s *capabilityState) degrade(model, feature string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.degraded == nil {
+		s.degraded = map[string]map[string]bool{}
+	}
+	if s.degraded[model] == nil {
+		s.degraded[model] = map[string]bool{}
+	}
+	s.degraded[model][feature] = true
+}
+
+func (s *capabilityState) features(model string) map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	features := map[string]bool{}
+	for f := range s.degraded[model] {
+		features[f] = true
+	}
+	return features
+}
+
+// capabilities combines the model table, the provider's known quirks and the
+// features degraded in this session.
+func (a *VocabApp) capabilities(model string) Capabilities {
+	limit := limitFor(model)
+	caps := Capabilities{
+		JSONMode:   true,
+		Tools:      true,
+		Streaming:  true,
+		Vision:     supportsVision(model),
+		Logprobs:   supportsLogprobs(model),
+		MaxContext: limit.Context,
+		MaxOutput:  limit.Output,
+	}
+	if a.provider == ProviderLocal || a.provider == ProviderCustom {
+		// Local servers run open models: no tool calling or image input is
+		// assumed, and the context is whatever the server was started with.
+		caps.Tools = false
+		caps.Vision = false
+		if a.quirks.ContextSize > 0 {
+			caps.MaxContext = a.quirks.ContextSize
+			caps.MaxOutput = a.quirks.ContextSize / 2
+		}
+	}
+	caps.JSONMode = caps.JSONMode && !a.quirks.NoJSONMode
+	caps.Logprobs = caps.Logprobs && !a.quirks.NoLogprobs
+
+	degraded := a.caps.features(model)
+	for _, f := range []string{featureJSONMode, featureStreaming, featureLogprobs} {
+		if !degraded[f] {
+			continue
+		}
+		caps.Degraded = append(caps.Degraded, f)
+		switch f {
+		case featureJSONMode:
+			caps.JSONMode = false
+		case featureStreaming:
+			caps.Streaming = false
+		case featureLogprobs:
+			caps.Logprobs = false
+		}
+	}
+	return caps
+}
+
+// limit returns the token limits of the model on the active provider.
+func (c Capabilities) limit() modelLimit {
+	return modelLimit{Context: c.MaxContext, Output: c.MaxOutput}
+}
+
+// unsupportedRequest reports whether err is the server refusing a request
+// option (as opposed to a network, key or quota problem), so that the call is
+// worth retrying without it.
+func unsupportedRequest(err error) bool {
+	status := 0
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	if errors.As(err, &apiErr) {
+		status = apiErr.HTTPStatusCode
+	} else if errors.As(err, &reqErr) {
+		status = reqErr.HTTPStatusCode
+	}
+	switch status {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// tokenLogprobs converts the log probabilities of a non-streamed reply to
+// the streamed form used by answerConfidence.
+func tokenLogprobs(lp *openai.LogProbs) []openai.ChatCompletionTokenLogprob {
+	if lp == nil {
+		return nil
+	}
+	out := make([]openai.ChatCompletionTokenLogprob, 0, len(lp.Content))
+	for _, t := range lp.Content {
+		out = append(out, openai.ChatCompletionTokenLogprob{Token: t.Token, Logprob: t.LogProb})
+	}
+	return out
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetCapabilities reports what the active provider supports for a model, so
+// the UI can hide options that would only be degraded.
+func (a *VocabApp) GetCapabilities(model string) (Capabilities, error) {
+	if err := validateModelID(model); err != nil {
+		return Capabilities{}, err
+	}
+	return a.capabilities(model), nil
+}
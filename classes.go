@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Class Profiles & Template Variables ---
+
+// ClassProfile holds the school-specific values templates can refer to.
+// Variables holds any extra user-defined ones, used as {{.Name}} like the
+// built-in fields.
+type ClassProfile struct {
+	Name        string            `json:"name"`
+	School      string            `json:"school"`
+	TeacherName string            `json:"teacherName"`
+	Textbook    string            `json:"textbook"`
+	Unit        string            `json:"unit"`
+	Variables   map[string]string `json:"variables"`
+}
+
+func (s Settings) activeClass() ClassProfile {
+	if c := s.classNamed(s.ActiveClass); c != nil {
+		return *c
+	}
+	return ClassProfile{}
+}
+
+// classVars returns the template variables of a class profile. Built-in
+// fields are always present (possibly empty) so templates shared between
+// classes do not fail just because one class leaves a field blank.
+func classVars(c ClassProfile) map[string]interface{} {
+	vars := map[string]interface{}{}
+	for k, v := range c.Variables {
+		vars[k] = v
+	}
+	vars["ClassName"] = c.Name
+	vars["School"] = c.School
+	vars["TeacherName"] = c.TeacherName
+	vars["Textbook"] = c.Textbook
+	vars["Unit"] = c.Unit
+	vars["Date"] = time.Now().Format("2006. 1. 2.")
+	return vars
+}
+
+func validateClassProfile(c ClassProfile) error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("반 이름이 비어 있습니다")
+	}
+	for k := range c.Variables {
+		if !isTemplateIdent(k) {
+			return fmt.Errorf("%s: 변수 이름은 영문자로 시작하는 영문/숫자만 사용할 수 있습니다: '%s'", c.Name, k)
+		}
+	}
+	return nil
+}
+
+// isTemplateIdent reports whether name can be written as {{.name}}.
+func isTemplateIdent(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		letter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		if !letter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Settings) classNamed(name string) *ClassProfile {
+	for i := range s.Classes {
+		if s.Classes[i].Name == name {
+			return &s.Classes[i]
+		}
+	}
+	return nil
+}
+
+// --- Go functions callable from Javascript ---
+
+func (a *VocabApp) SetActiveClass(name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if name != "" && settings.classNamed(name) == nil {
+		return fmt.Errorf("반을 찾을 수 없습니다: '%s'", name)
+	}
+	settings.ActiveClass = name
+	return saveJSON(settingsFile, settings)
+}
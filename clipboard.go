@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Clipboard ---
+
+// PasteResult is the clipboard content converted to the "word = 뜻" format
+// together with what the parser made of it.
+type PasteResult struct {
+	Text         string   `json:"text"`
+	Entries      int      `json:"entries"`
+	SkippedLines []string `json:"skippedLines"`
+}
+
+// tabularToVocab converts rows copied from Excel or a 한글 table (cells
+// separated by tabs) into "word = 뜻" lines. Lines that already use '=' are
+// kept as they are.
+func tabularToVocab(text string) string {
+	var lines []string
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.Contains(line, "=") || !strings.Contains(line, "\t") {
+			lines = append(lines, line)
+			continue
+		}
+		var cells []string
+		for _, c := range strings.Split(line, "\t") {
+			if c = strings.TrimSpace(c); c != "" {
+				cells = append(cells, c)
+			}
+		}
+		if len(cells) < 2 {
+			lines = append(lines, strings.Join(cells, " "))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s = %s", cells[0], strings.Join(cells[1:], ", ")))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// skippedVocabLines returns the non-empty lines parseVocabBlock would ignore.
+func skippedVocabLines(vocabBlock string) []string {
+	var skipped []string
+	for _, raw := range strings.Split(vocabBlock, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if len(parseVocabBlock(line)) == 0 {
+			skipped = append(skipped, line)
+		}
+	}
+	return skipped
+}
+
+// --- Go functions callable from Javascript ---
+
+// PasteFromClipboard reads a word list copied from 한글/Excel/a web page and
+// returns it in the input format, reporting lines that could not be parsed.
+func (a *VocabApp) PasteFromClipboard() (PasteResult, error) {
+	text, err := runtime.ClipboardGetText(a.ctx)
+	if err != nil {
+		return PasteResult{}, fmt.Errorf("클립보드 읽기 오류: %w", err)
+	}
+	if strings.TrimSpace(text) == "" {
+		return PasteResult{}, fmt.Errorf("클립보드가 비어 있습니다")
+	}
+
+	block := tabularToVocab(text)
+	result := PasteResult{
+		Text:         block,
+		Entries:      len(parseVocabBlock(block)),
+		SkippedLines: skippedVocabLines(block),
+	}
+	if result.Entries == 0 {
+		return result, fmt.Errorf("클립보드에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+	return result, nil
+}
+
+func (a *VocabApp) CopyResultToClipboard(result string) error {
+	if result == "" {
+		return fmt.Errorf("복사할 내용이 없습니다")
+	}
+	if err := runtime.ClipboardSetText(a.ctx, result); err != nil {
+		return fmt.Errorf("클립보드 쓰기 오류: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+)
+
+// --- Cloze Passages ---
+
+const (
+	clozeMaxWords    = 15 // longer passages lose coherence
+	clozeDistractors = 2  // unused list words added to the word bank
+)
+
+var clozeMarkRe = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+
+func clozePrompt(opts promptOptions) string {
+	lines := []string{
+		opts.audienceLine(),
+		"Write ONE coherent, natural English passage (a short essay or story with a clear topic) that uses every target word from the list exactly once, each in one of its given senses.",
+		"Mark each target word where it is used by wrapping the word as it appears in the passage in double square brackets, e.g. [[abandoned]]. Inflected forms are allowed. Do not mark anything else.",
+		"Give the passage a short English title.",
+		`Respond with JSON only: {"title": "...", "passage": "..."}.`,
+	}
+	if opts.ContextTheme != "" {
+		lines = append(lines, fmt.Sprintf("Set the passage in the domain of %s.", opts.ContextTheme))
+	}
+	if opts.Level.ID != "" {
+		lines = append(lines, opts.Level.sentences)
+	}
+	if rule := spellingRule(opts.Spelling); rule != "" {
+		lines = append(lines, rule)
+	}
+	if rule := namesRule(opts.Names, opts.Places); rule != "" {
+		lines = append(lines, rule)
+	}
+	// The passage uses every target word, so only the configured words are
+	// forbidden.
+	if rule := forbiddenWordsRule(opts.ForbiddenWords, false); rule != "" {
+		lines = append(lines, rule)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildCloze blanks the marked words of a passage and returns the test text.
+// Each target word is blanked once, at its first marked use; words the model
+// did not use are reported in missing and left out of the word bank.
+func buildCloze(title string, passage string, targets []VocabPair, extras []VocabPair) (string, []string) {
+	used := map[int]bool{}
+	var answers []string
+	text := clozeMarkRe.ReplaceAllStringFunc(passage, func(m string) string {
+		form := strings.TrimSpace(clozeMarkRe.FindStringSubmatch(m)[1])
+		for i, t := range targets {
+			if !used[i] && isInflectionOf(form, t.Word) {
+				used[i] = true
+				answers = append(answers, fmt.Sprintf("(%d) %s", len(answers)+1, form))
+				return fmt.Sprintf("(%d) %s", len(answers), blankMarker)
+			}
+		}
+		return form
+	})
+
+	var bank, missing []string
+	for i, t := range targets {
+		if used[i] {
+			bank = append(bank, t.Word)
+		} else {
+			missing = append(missing, t.Word)
+		}
+	}
+	for _, e := range extras {
+		bank = append(bank, e.Word)
+	}
+	rand.Shuffle(len(bank), func(i, j int) { bank[i], bank[j] = bank[j], bank[i] })
+
+	lines := []string{
+		fmt.Sprintf("다음 글의 빈칸 (1)~(%d)에 들어갈 알맞은 말을 <보기>에서 골라 쓰시오. (필요하면 형태를 바꾸시오.)", len(answers)),
+		"",
+		"<보기> " + strings.Join(bank, " / "),
+		"",
+	}
+	if title != "" {
+		lines = append(lines, title, "")
+	}
+	lines = append(lines, strings.TrimSpace(text), "", "[정답]")
+	lines = append(lines, answers...)
+	return strings.Join(lines, "\n"), missing
+}
+
+// --- Go functions callable from Javascript ---
+
+// GenerateCloze writes one passage using up to numWords words from the list
+// (all of them, up to clozeMaxWords, when numWords is 0), blanks them and adds
+// a word bank with a couple of unused list words as distractors.
+func (a *VocabApp) GenerateCloze(vocabBlock string, modelID string, numWords int) (string, error) {
+	if a.client == nil {
+		return "", fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	parsed := parseVocabBlock(vocabBlock)
+	if len(parsed) == 0 {
+		return "", fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+	if numWords <= 0 || numWords > clozeMaxWords {
+		numWords = clozeMaxWords
+	}
+	if numWords > len(parsed) {
+		numWords = len(parsed)
+	}
+	rand.Shuffle(len(parsed), func(i, j int) { parsed[i], parsed[j] = parsed[j], parsed[i] })
+	targets, rest := parsed[:numWords], parsed[numWords:]
+	if len(rest) > clozeDistractors {
+		rest = rest[:clozeDistractors]
+	}
+
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	opts, err := settings.promptOptions("빈칸 지문", 0)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Title   string `json:"title"`
+		Passage string `json:"passage"`
+	}
+	userPrompt := "[Target Words]\n" + formatVocabBlock(targets)
+	if err := a.callChatGPTJSON(modelID, clozePrompt(opts), userPrompt, &result); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(result.Passage) == "" {
+		return "", fmt.Errorf("API가 빈 지문을 반환했습니다")
+	}
+	text, missing := buildCloze(result.Title, result.Passage, targets, rest)
+	if len(missing) == len(targets) {
+		return "", fmt.Errorf("지문에서 목표 단어를 찾을 수 없습니다")
+	}
+	if len(missing) > 0 {
+		text += "\n\n(지문에 사용되지 않은 단어: " + strings.Join(missing, ", ") + ")"
+	}
+	return text, nil
+}
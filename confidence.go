@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Answer Confidence ---
+
+// lowConfidence is the answer token probability below which a question is
+// flagged for review.
+const lowConfidence = 0.8
+
+// keyAnswerRe finds the answer (choice marker, number or written word) of an
+// answer key line.
+var keyAnswerRe = regexp.MustCompile(`(?m)^(\d+)\s*번?\s*[.):\-–=]?\s*([①②③④⑤]|[1-5]\b|[A-Za-z][A-Za-z'\-]*)`)
+
+// QuestionConfidence is how sure the model was of a keyed answer.
+type QuestionConfidence struct {
+	Question   int     `json:"question"`
+	Confidence float64 `json:"confidence"` // probability of the answer tokens, 0-1
+	Low        bool    `json:"low"`
+}
+
+// ConfidenceReport is emitted as "generate:confidence" for runs with log
+// probabilities; Order lists every question, least confident first.
+type ConfidenceReport struct {
+	Order []QuestionConfidence `json:"order"`
+	Low   int                  `json:"low"`
+}
+
+// supportsLogprobs reports whether the model returns token log
+// probabilities; reasoning models do not.
+func supportsLogprobs(model string) bool {
+	return !fixedTemperature(model)
+}
+
+// answerConfidence reads the probability of every answer in the answer key
+// from the token log probabilities of the output. A multi-token answer gets
+// the probability of its least likely token.
+func answerConfidence(output string, logprobs []openai.ChatCompletionTokenLogprob) map[int]float64 {
+	if len(logprobs) == 0 {
+		return nil
+	}
+	keyStart := strings.Index(output, "[정답]")
+	if keyStart < 0 {
+		return nil
+	}
+	// Byte offset of every token in the output.
+	starts := make([]int, len(logprobs))
+	offset := 0
+	for i, t := range logprobs {
+		starts[i] = offset
+		if len(t.Bytes) > 0 {
+			offset += len(t.Bytes)
+		} else {
+			offset += len(t.Token)
+		}
+	}
+
+	confidence := map[int]float64{}
+	key := output[keyStart:]
+	if end := strings.Index(key, translationHeader); end >= 0 {
+		key = key[:end]
+	}
+	for _, m := range keyAnswerRe.FindAllStringSubmatchIndex(key, -1) {
+		number, err := strconv.Atoi(key[m[2]:m[3]])
+		if err != nil {
+			continue
+		}
+		from, to := keyStart+m[4], keyStart+m[5]
+		p := 1.0
+		found := false
+		for i, t := range logprobs {
+			end := starts[i] + len(t.Bytes)
+			if len(t.Bytes) == 0 {
+				end = starts[i] + len(t.Token)
+			}
+			if end > from && starts[i] < to {
+				p = math.Min(p, math.Exp(t.Logprob))
+				found = true
+			}
+		}
+		if found {
+			confidence[number] = p
+		}
+	}
+	return confidence
+}
+
+// confidenceOrder sorts questions least confident first.
+func confidenceOrder(confidence map[int]float64) ConfidenceReport {
+	var report ConfidenceReport
+	for n, c := range confidence {
+		low := c < lowConfidence
+		if low {
+			report.Low++
+		}
+		report.Order = append(report.Order, QuestionConfidence{Question: n, Confidence: c, Low: low})
+	}
+	sort.Slice(report.Order, func(i, j int) bool {
+		if report.Order[i].Confidence != report.Order[j].Confidence {
+			return report.Order[i].Confidence < report.Order[j].Confidence
+		}
+		return report.Order[i].Question < report.Order[j].Question
+	})
+	return report
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetReviewOrder returns the questions of generated content in the order a
+// teacher should check them, least confident answer first. Content from
+// models without log probabilities has no confidence recorded.
+func (a *VocabApp) GetReviewOrder(content string) (ConfidenceReport, error) {
+	a.mu.Lock()
+	runs, err := loadRuns()
+	a.mu.Unlock()
+	if err != nil {
+		return ConfidenceReport{}, err
+	}
+	run, ok := findRun(runs, content)
+	if !ok || len(run.Confidence) == 0 {
+		return ConfidenceReport{}, fmt.Errorf("이 시험지의 정답 신뢰도 기록이 없습니다")
+	}
+	return confidenceOrder(run.Confidence), nil
+}
+
+// reportConfidence emits the review order of a finished run.
+func (a *VocabApp) reportConfidence(confidence map[int]float64) {
+	if len(confidence) > 0 {
+		runtime.EventsEmit(a.ctx, "generate:confidence", confidenceOrder(confidence))
+	}
+}
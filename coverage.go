@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Standard List Coverage ---
+
+const standardListsFile = "standardlists.json"
+
+// coverageBandSize groups the words of a standard list for the report. The
+// lists are ordered by frequency, so early bands matter most.
+const coverageBandSize = 500
+
+// StandardList is a published vocabulary list the tested vocabulary is
+// measured against. The word data is imported from the publisher's file
+// (see ImportStandardList), in the order of the file.
+type StandardList struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Source     string    `json:"source"`
+	Words      []string  `json:"words,omitempty"`
+	ImportedAt time.Time `json:"importedAt,omitempty"`
+}
+
+// standardLists are the lists the app knows about. None ships with the
+// app: each is imported from the published file, and coverage against a list
+// reports an error until then.
+var standardLists = []StandardList{
+	{ID: "moe", Name: "교육부 기본 어휘", Source: "2015 개정 영어과 교육과정 기본 어휘 목록 (직접 가져오기)"},
+	{ID: "ngsl", Name: "NGSL", Source: "New General Service List 1.2 (newgeneralservicelist.com, 직접 가져오기)"},
+	{ID: "toefl", Name: "TOEFL", Source: "TOEFL 필수 어휘 목록 (직접 가져오기)"},
+}
+
+func loadStandardLists() ([]StandardList, error) {
+	var imported []StandardList
+	if err := loadJSON(standardListsFile, &imported); err != nil {
+		return nil, err
+	}
+	lists := make([]StandardList, len(standardLists))
+	copy(lists, standardLists)
+	for i := range lists {
+		for _, l := range imported {
+			if l.ID == lists[i].ID {
+				lists[i].Words = l.Words
+				lists[i].ImportedAt = l.ImportedAt
+			}
+		}
+	}
+	return lists, nil
+}
+
+func findStandardList(lists []StandardList, id string) (StandardList, error) {
+	for _, l := range lists {
+		if l.ID == id {
+			if len(l.Words) == 0 {
+				return l, fmt.Errorf("'%s' 목록을 먼저 가져오세요", l.Name)
+			}
+			return l, nil
+		}
+	}
+	return StandardList{}, fmt.Errorf("지원하지 않는 기준 목록입니다: '%s'", id)
+}
+
+// parseStandardList reads the words of a list file: one word per line, or
+// CSV/TSV rows whose first column is the word. Header rows, comments and
+// numbering are skipped.
+func parseStandardList(text string) []string {
+	var words []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == '\t' || r == ';' })
+		if len(fields) == 0 {
+			continue
+		}
+		field := strings.TrimLeftFunc(strings.TrimSpace(fields[0]), func(r rune) bool { return unicode.IsDigit(r) || r == '.' || r == ')' || r == ' ' })
+		word := strings.ToLower(strings.Trim(field, `"' `))
+		if word == "" || strings.IndexFunc(word, func(r rune) bool { return !(r >= 'a' && r <= 'z' || r == ' ' || r == '-' || r == '\'') }) >= 0 {
+			continue
+		}
+		if word == "word" || word == "lemma" || word == "headword" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	return words
+}
+
+// testedWords returns the lowercased words of all saved tests that are not
+// in the trash. Must be called with a.mu held.
+func testedWords() (map[string]bool, error) {
+	tests, err := loadTests()
+	if err != nil {
+		return nil, err
+	}
+	runs, err := loadRuns()
+	if err != nil {
+		return nil, err
+	}
+	tested := map[string]bool{}
+	for _, t := range tests {
+		if t.DeletedAt != nil {
+			continue
+		}
+		for _, e := range questionWords(t, runs) {
+			tested[strings.ToLower(strings.TrimSpace(e.Word))] = true
+		}
+	}
+	return tested, nil
+}
+
+// CoverageBand is the coverage of one frequency band of a list.
+type CoverageBand struct {
+	From     int     `json:"from"` // 1-based rank of the first word
+	To       int     `json:"to"`
+	Covered  int     `json:"covered"`
+	Coverage float64 `json:"coverage"` // 0-100
+}
+
+// CoverageReport is how much of a standard list has been tested. Gaps are
+// the untested words in list order, most frequent first.
+type CoverageReport struct {
+	ListID   string         `json:"listId"`
+	ListName string         `json:"listName"`
+	Total    int            `json:"total"`
+	Covered  int            `json:"covered"`
+	Coverage float64        `json:"coverage"` // 0-100
+	Bands    []CoverageBand `json:"bands"`
+	Gaps     []string       `json:"gaps"`
+}
+
+func coverageReport(list StandardList, tested map[string]bool) CoverageReport {
+	report := CoverageReport{ListID: list.ID, ListName: list.Name, Total: len(list.Words), Bands: []CoverageBand{}, Gaps: []string{}}
+	for i, w := range list.Words {
+		if i%coverageBandSize == 0 {
+			report.Bands = append(report.Bands, CoverageBand{From: i + 1})
+		}
+		band := &report.Bands[len(report.Bands)-1]
+		band.To = i + 1
+		if tested[w] {
+			band.Covered++
+			report.Covered++
+		} else {
+			report.Gaps = append(report.Gaps, w)
+		}
+	}
+	for i := range report.Bands {
+		b := &report.Bands[i]
+		b.Coverage = percent(float64(b.Covered), float64(b.To-b.From+1))
+	}
+	report.Coverage = percent(float64(report.Covered), float64(report.Total))
+	return report
+}
+
+// --- Go functions callable from Javascript ---
+
+// ListStandardLists returns the known standard lists; Words is left out.
+func (a *VocabApp) ListStandardLists() ([]StandardList, error) {
+	a.mu.Lock()
+	lists, err := loadStandardLists()
+	a.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].Words = nil
+	}
+	return lists, nil
+}
+
+// ImportStandardList reads the words of a standard list from its published
+// file. Without a path a file dialog is shown. It returns the word count.
+func (a *VocabApp) ImportStandardList(id string, filePath string) (int, error) {
+	known := false
+	for _, l := range standardLists {
+		known = known || l.ID == id
+	}
+	if !known {
+		return 0, fmt.Errorf("지원하지 않는 기준 목록입니다: '%s'", id)
+	}
+	if filePath == "" {
+		selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   "기준 어휘 목록 파일 선택",
+			Filters: []runtime.FileFilter{{DisplayName: "단어 목록 (*.txt, *.csv, *.tsv)", Pattern: "*.txt;*.csv;*.tsv"}},
+		})
+		if err != nil {
+			return 0, err
+		}
+		if selection == "" {
+			return 0, fmt.Errorf("파일이 선택되지 않았습니다")
+		}
+		filePath = selection
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("파일 읽기 오류: %w", err)
+	}
+	words := parseStandardList(string(data))
+	if len(words) == 0 {
+		return 0, fmt.Errorf("파일에서 단어를 찾지 못했습니다")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var imported []StandardList
+	if err := loadJSON(standardListsFile, &imported); err != nil {
+		return 0, err
+	}
+	entry := StandardList{ID: id, Words: words, ImportedAt: time.Now()}
+	replaced := false
+	for i := range imported {
+		if imported[i].ID == id {
+			imported[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		imported = append(imported, entry)
+	}
+	return len(words), saveJSON(standardListsFile, imported)
+}
+
+// GetCoverageReport measures the words of all saved tests against a
+// standard list.
+func (a *VocabApp) GetCoverageReport(listID string) (CoverageReport, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	lists, err := loadStandardLists()
+	if err != nil {
+		return CoverageReport{}, err
+	}
+	list, err := findStandardList(lists, listID)
+	if err != nil {
+		return CoverageReport{}, err
+	}
+	tested, err := testedWords()
+	if err != nil {
+		return CoverageReport{}, err
+	}
+	return coverageReport(list, tested), nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// --- Custom Endpoints ---
+
+// ProviderCustom talks to any server with an OpenAI-compatible API, such as
+// the local-server frontends in endpointPresets.
+const ProviderCustom = "custom"
+
+// CustomSettings configures a ProviderCustom profile. The quirk flags turn
+// off request options the server rejects; they are filled in from the preset
+// but may be changed by hand.
+type CustomSettings struct {
+	Preset  string `json:"preset,omitempty"`
+	BaseURL string `json:"baseUrl"`
+	// NoStreamUsage omits stream_options, which some servers reject.
+	NoStreamUsage bool `json:"noStreamUsage"`
+	// NoJSONMode omits response_format; the JSON is then read from plain text.
+	NoJSONMode bool `json:"noJsonMode"`
+	// NoLogprobs omits logprobs, which some servers reject while streaming.
+	NoLogprobs bool `json:"noLogprobs"`
+}
+
+// EndpointPreset is a quick-connect template for a well-known local server.
+type EndpointPreset struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Settings CustomSettings `json:"settings"`
+	Note     string         `json:"note"`
+}
+
+var endpointPresets = []EndpointPreset{
+	{
+		ID:   "lmstudio",
+		Name: "LM Studio",
+		Settings: CustomSettings{
+			BaseURL:    "http://localhost:1234/v1",
+			NoJSONMode: true, // only accepts response_format json_schema
+		},
+		Note: "LM Studio의 Developer 탭에서 서버를 시작하세요. 모델 ID에는 불러온 모델 이름을 입력합니다.",
+	},
+	{
+		ID:   "jan",
+		Name: "Jan",
+		Settings: CustomSettings{
+			BaseURL:       "http://localhost:1337/v1",
+			NoStreamUsage: true,
+			NoLogprobs:    true,
+		},
+		Note: "Jan 설정의 Local API Server를 켜세요. 모델 ID는 Jan에 표시된 모델 ID와 같아야 합니다.",
+	},
+	{
+		ID:   "textgen-webui",
+		Name: "text-generation-webui",
+		Settings: CustomSettings{
+			BaseURL:       "http://127.0.0.1:5000/v1",
+			NoStreamUsage: true,
+			NoJSONMode:    true,
+			NoLogprobs:    true,
+		},
+		Note: "--api 옵션으로 실행하세요. 모델 ID는 무시되고 웹 UI에서 불러온 모델이 사용됩니다.",
+	},
+}
+
+func findEndpointPreset(id string) (EndpointPreset, bool) {
+	for _, p := range endpointPresets {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return EndpointPreset{}, false
+}
+
+func validateCustomSettings(name string, c *CustomSettings) error {
+	if c == nil {
+		return fmt.Errorf("%s: 서버 주소를 입력하세요", name)
+	}
+	if c.Preset != "" {
+		if _, ok := findEndpointPreset(c.Preset); !ok {
+			return fmt.Errorf("%s: 알 수 없는 프리셋입니다: '%s'", name, c.Preset)
+		}
+	}
+	u, err := url.ParseRequestURI(c.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%s: 서버 주소 형식 오류: '%s'", name, c.BaseURL)
+	}
+	return nil
+}
+
+// endpointQuirks are the request options the active server cannot handle.
+type endpointQuirks struct {
+	NoStreamUsage bool
+	NoJSONMode    bool
+	NoLogprobs    bool
+	ContextSize   int // tokens, 0 when unknown
+}
+
+func quirksForProfile(p Profile) endpointQuirks {
+	switch p.Provider {
+	case ProviderAzure:
+		// Azure's default API version rejects stream_options.
+		return endpointQuirks{NoStreamUsage: true}
+	case ProviderLocal:
+		ctxSize := p.Local.ContextSize
+		if ctxSize == 0 {
+			ctxSize = defaultLocalContext
+		}
+		return endpointQuirks{ContextSize: ctxSize}
+	case ProviderCustom:
+		return endpointQuirks{NoStreamUsage: p.Custom.NoStreamUsage, NoJSONMode: p.Custom.NoJSONMode, NoLogprobs: p.Custom.NoLogprobs}
+	}
+	return endpointQuirks{}
+}
+
+// extractJSONObject cuts the outermost JSON object out of a plain-text reply,
+// dropping code fences and chatter around it.
+func extractJSONObject(text string) string {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return text
+	}
+	return text[start : end+1]
+}
+
+// --- Go functions callable from Javascript ---
+
+// ListEndpointPresets returns the quick-connect presets for local servers.
+func (a *VocabApp) ListEndpointPresets() []EndpointPreset {
+	return endpointPresets
+}
+
+// ApplyEndpointPreset returns a custom profile filled in from a preset, ready
+// to be edited and saved with the other profiles.
+func (a *VocabApp) ApplyEndpointPreset(profileName, presetID string) (Profile, error) {
+	preset, ok := findEndpointPreset(presetID)
+	if !ok {
+		return Profile{}, fmt.Errorf("알 수 없는 프리셋입니다: '%s'", presetID)
+	}
+	custom := preset.Settings
+	custom.Preset = preset.ID
+	if strings.TrimSpace(profileName) == "" {
+		profileName = preset.Name
+	}
+	return Profile{Name: profileName, Provider: ProviderCustom, Custom: &custom}, nil
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/wailsapp/wails/v2/pkg/logger"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Diagnostics ---
+
+const (
+	// maxLogLines is the number of recent log lines kept for diagnostics.
+	maxLogLines = 500
+	// maxCapturedBody caps the request and response bodies kept of a failed
+	// API call.
+	maxCapturedBody = 64 << 10
+)
+
+// logBuffer is the app's logger: it passes every line on to the default
+// logger and keeps the most recent ones for GenerateDiagnostics.
+type logBuffer struct {
+	next logger.Logger
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func newLogBuffer() *logBuffer {
+	return &logBuffer{next: logger.NewDefaultLogger()}
+}
+
+func (l *logBuffer) add(level, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf("%s %-7s %s", time.Now().Format(time.RFC3339), level, message))
+	if len(l.lines) > maxLogLines {
+		l.lines = l.lines[len(l.lines)-maxLogLines:]
+	}
+}
+
+func (l *logBuffer) recent() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...)
+}
+
+func (l *logBuffer) Print(message string)   { l.add("PRINT", message); l.next.Print(message) }
+func (l *logBuffer) Trace(message string)   { l.add("TRACE", message); l.next.Trace(message) }
+func (l *logBuffer) Debug(message string)   { l.add("DEBUG", message); l.next.Debug(message) }
+func (l *logBuffer) Info(message string)    { l.add("INFO", message); l.next.Info(message) }
+func (l *logBuffer) Warning(message string) { l.add("WARNING", message); l.next.Warning(message) }
+func (l *logBuffer) Error(message string)   { l.add("ERROR", message); l.next.Error(message) }
+func (l *logBuffer) Fatal(message string)   { l.add("FATAL", message); l.next.Fatal(message) }
+
+// FailedExchange is the last API call that failed. Headers are not kept, so
+// the API key never ends up in it, and the request body is reduced to a
+// summary: prompts carry word lists and images scanned student work.
+type FailedExchange struct {
+	At       time.Time      `json:"at"`
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Status   int            `json:"status"` // 0 when no response arrived
+	Error    string         `json:"error,omitempty"`
+	Request  RequestSummary `json:"request"`
+	Response string         `json:"response"`
+}
+
+// RequestSummary describes a request body without its content.
+type RequestSummary struct {
+	Bytes    int    `json:"bytes"`
+	Model    string `json:"model,omitempty"`
+	Messages int    `json:"messages,omitempty"`
+	Images   int    `json:"images,omitempty"` // inline base64 images
+}
+
+func summarizeRequest(body []byte) RequestSummary {
+	summary := RequestSummary{Bytes: len(body), Images: bytes.Count(body, []byte("data:image/"))}
+	var req struct {
+		Model    string            `json:"model"`
+		Messages []json.RawMessage `json:"messages"`
+	}
+	if json.Unmarshal(body, &req) == nil {
+		summary.Model = req.Model
+		summary.Messages = len(req.Messages)
+	}
+	return summary
+}
+
+// failureRecorder keeps the last failed API call.
+type failureRecorder struct {
+	mu   sync.Mutex
+	last *FailedExchange
+}
+
+func (r *failureRecorder) record(f FailedExchange) {
+	r.mu.Lock()
+	r.last = &f
+	r.mu.Unlock()
+}
+
+func (r *failureRecorder) lastFailure() *FailedExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// recordingDoer passes requests on to next and records the ones that fail.
+type recordingDoer struct {
+	next     openai.HTTPDoer
+	recorder *failureRecorder
+}
+
+func truncateBody(b []byte) string {
+	if len(b) > maxCapturedBody {
+		return string(b[:maxCapturedBody]) + "\n...(생략)"
+	}
+	return string(b)
+}
+
+func (d recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	failure := FailedExchange{At: time.Now(), Method: req.Method, URL: req.URL.Redacted(), Request: summarizeRequest(reqBody)}
+
+	resp, err := d.next.Do(req)
+	if err != nil {
+		failure.Error = err.Error()
+		d.recorder.record(failure)
+		return resp, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		failure.Status = resp.StatusCode
+		failure.Response = truncateBody(respBody)
+		d.recorder.record(failure)
+	}
+	return resp, nil
+}
+
+// newClient builds an API client whose failed calls are recorded for
+// GenerateDiagnostics.
+func (a *VocabApp) newClient(config openai.ClientConfig) *openai.Client {
+	config.HTTPClient = recordingDoer{next: config.HTTPClient, recorder: &a.failures}
+	return openai.NewClientWithConfig(config)
+}
+
+// secretKeys are the parts of JSON field names whose values are redacted
+// from the settings in a diagnostics bundle.
+var secretKeys = []string{"apikey", "secret", "token", "password", "accesskey"}
+
+// redactPersonal removes from settings what names the teacher, the school,
+// the classes or colleagues and where data is sent or stored: class
+// profiles, account names and addresses of the mail, sync, shared bank and
+// API servers, local paths and the style reference texts.
+func redactPersonal(s *Settings) {
+	const removed = "(삭제됨)"
+	redact := func(v *string) {
+		if *v != "" {
+			*v = removed
+		}
+	}
+	redact(&s.ActiveClass)
+	for i := range s.Classes {
+		c := &s.Classes[i]
+		redact(&c.Name)
+		redact(&c.School)
+		redact(&c.TeacherName)
+		for k := range c.Variables {
+			c.Variables[k] = removed
+		}
+	}
+	for i := range s.Profiles {
+		p := &s.Profiles[i]
+		if p.Azure != nil {
+			redact(&p.Azure.Endpoint)
+			redact(&p.Azure.TenantID)
+			redact(&p.Azure.ClientID)
+		}
+		if p.Local != nil {
+			redact(&p.Local.ServerPath)
+			redact(&p.Local.Model)
+		}
+		if p.Custom != nil {
+			redact(&p.Custom.BaseURL)
+		}
+	}
+	redact(&s.Google.ClientID)
+	redact(&s.Google.ExportFolderID)
+	redact(&s.SMTP.Host)
+	redact(&s.SMTP.Username)
+	redact(&s.SMTP.From)
+	redact(&s.Sync.WebDAVURL)
+	redact(&s.Sync.WebDAVUsername)
+	redact(&s.Sync.S3Endpoint)
+	redact(&s.Sync.S3Bucket)
+	redact(&s.Sync.S3Prefix)
+	redact(&s.Sync.DriveFolderID)
+	redact(&s.SharedBank.URL)
+	for i := range s.SharedBank.Tokens {
+		redact(&s.SharedBank.Tokens[i].Name)
+	}
+	redact(&s.Webhook.URL)
+	redact(&s.Dictionary.AppID)
+	redact(&s.Grammar.Server)
+	redact(&s.Grammar.User)
+	redact(&s.OutputDir)
+	for k := range s.Generation.StyleReferences {
+		s.Generation.StyleReferences[k] = removed
+	}
+}
+
+// redactHome replaces the user's home directory in the strings of v with
+// "~", so the paths left in a bundle do not name the OS user.
+func redactHome(v interface{}, home string) interface{} {
+	if home == "" {
+		return v
+	}
+	switch v := v.(type) {
+	case string:
+		return strings.ReplaceAll(v, home, "~")
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = redactHome(child, home)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactHome(child, home)
+		}
+	}
+	return v
+}
+
+// redactSecrets blanks every string under a field whose name looks like a
+// credential, at any depth.
+func redactSecrets(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			lower := strings.ToLower(k)
+			secret := false
+			for _, s := range secretKeys {
+				if strings.Contains(lower, s) {
+					secret = true
+				}
+			}
+			if s, ok := child.(string); secret && ok {
+				if s != "" {
+					v[k] = "(삭제됨)"
+				}
+				continue
+			}
+			v[k] = redactSecrets(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactSecrets(child)
+		}
+	}
+	return v
+}
+
+// diagnosticsInfo describes the app and system in a diagnostics bundle.
+type diagnosticsInfo struct {
+	AppVersion string           `json:"appVersion"`
+	OS         string           `json:"os"`
+	Arch       string           `json:"arch"`
+	GoVersion  string           `json:"goVersion"`
+	Provider   string           `json:"provider"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	DataSizes  map[string]int64 `json:"dataSizes"`
+}
+
+// writeDiagnostics zips the app info, the redacted settings, the recent log
+// and the last failed API call into w.
+func (a *VocabApp) writeDiagnostics(w io.Writer) error {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	var sizes map[string]int64
+	if err == nil {
+		sizes, _, err = dataSizes()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	redactPersonal(&settings)
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	var redacted interface{}
+	if err := json.Unmarshal(raw, &redacted); err != nil {
+		return err
+	}
+	info := diagnosticsInfo{
+		AppVersion: appVersion,
+		OS:         goruntime.GOOS,
+		Arch:       goruntime.GOARCH,
+		GoVersion:  goruntime.Version(),
+		Provider:   a.provider,
+		CreatedAt:  time.Now(),
+		DataSizes:  sizes,
+	}
+
+	zw := zip.NewWriter(w)
+	writeJSON := func(name string, v interface{}) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	if err := writeJSON("info.json", info); err != nil {
+		return err
+	}
+	home, _ := os.UserHomeDir()
+	if err := writeJSON("settings.json", redactHome(redactSecrets(redacted), home)); err != nil {
+		return err
+	}
+	if failure := a.failures.lastFailure(); failure != nil {
+		if err := writeJSON("last-failure.json", failure); err != nil {
+			return err
+		}
+	}
+	f, err := zw.Create("log.txt")
+	if err != nil {
+		return err
+	}
+	if a.log != nil {
+		text := strings.Join(a.log.recent(), "\n")
+		if home != "" {
+			text = strings.ReplaceAll(text, home, "~")
+		}
+		if _, err := io.WriteString(f, text); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// --- Go functions callable from Javascript ---
+
+// GenerateDiagnostics saves a zip to attach to a GitHub issue: app version,
+// system, settings with every key, password and personal detail removed,
+// the recent log and a summary of the last failed API request with its
+// response.
+func (a *VocabApp) GenerateDiagnostics() (string, error) {
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "진단 정보 저장",
+		DefaultFilename: fmt.Sprintf("vocab-diagnostics_%s.zip", time.Now().Format("20060102")),
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "진단 파일 (*.zip)",
+				Pattern:     "*.zip",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if filePath == "" {
+		return "", fmt.Errorf("저장 경로가 선택되지 않았습니다")
+	}
+
+	out, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	err = a.writeDiagnostics(out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return "", fmt.Errorf("진단 정보 생성 오류: %w", err)
+	}
+	return fmt.Sprintf("진단 정보 저장 완료: %s", filepath.Base(filePath)), nil
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Dictionary Verification (영영풀이) ---
+
+// Dictionary providers of DictionarySettings.Provider.
+const (
+	DictionaryFree   = "freedictionary" // dictionaryapi.dev, no key needed
+	DictionaryOxford = "oxford"         // Oxford Dictionaries API, app ID and key
+)
+
+// definitionOverlapMin is the share of a definition's content words that
+// must appear in one of the dictionary's definitions; below it the
+// definition is flagged as diverging.
+const definitionOverlapMin = 0.2
+
+// DictionarySettings configure the dictionary that model-written English
+// definitions are checked against. An empty Provider turns the check off.
+type DictionarySettings struct {
+	Provider string `json:"provider"`
+	AppID    string `json:"appId"`
+	APIKey   string `json:"apiKey"`
+}
+
+func validateDictionarySettings(s DictionarySettings) error {
+	switch s.Provider {
+	case "", DictionaryFree:
+	case DictionaryOxford:
+		if strings.TrimSpace(s.AppID) == "" || strings.TrimSpace(s.APIKey) == "" {
+			return fmt.Errorf("Oxford 사전을 사용하려면 앱 ID와 키를 입력하세요")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 사전입니다: '%s'", s.Provider)
+	}
+	return nil
+}
+
+var errNotInDictionary = fmt.Errorf("사전에 없는 단어입니다")
+
+// lookupDefinitions returns the dictionary's English definitions of word.
+func lookupDefinitions(s DictionarySettings, word string) ([]string, error) {
+	var req *http.Request
+	var err error
+	switch s.Provider {
+	case DictionaryOxford:
+		req, err = http.NewRequest(http.MethodGet, "https://od-api.oxforddictionaries.com/api/v2/entries/en-gb/"+url.PathEscape(strings.ToLower(word))+"?fields=definitions", nil)
+		if err == nil {
+			req.Header.Set("app_id", s.AppID)
+			req.Header.Set("app_key", s.APIKey)
+		}
+	default:
+		req, err = http.NewRequest(http.MethodGet, "https://api.dictionaryapi.dev/api/v2/entries/en/"+url.PathEscape(strings.ToLower(word)), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Timeout: 15 * time.Second}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotInDictionary
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("사전 응답 오류: %s", resp.Status)
+	}
+
+	var definitions []string
+	if s.Provider == DictionaryOxford {
+		type sense struct {
+			Definitions []string `json:"definitions"`
+			Subsenses   []struct {
+				Definitions []string `json:"definitions"`
+			} `json:"subsenses"`
+		}
+		var body struct {
+			Results []struct {
+				LexicalEntries []struct {
+					Entries []struct {
+						Senses []sense `json:"senses"`
+					} `json:"entries"`
+				} `json:"lexicalEntries"`
+			} `json:"results"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		for _, r := range body.Results {
+			for _, le := range r.LexicalEntries {
+				for _, e := range le.Entries {
+					for _, s := range e.Senses {
+						definitions = append(definitions, s.Definitions...)
+						for _, sub := range s.Subsenses {
+							definitions = append(definitions, sub.Definitions...)
+						}
+					}
+				}
+			}
+		}
+	} else {
+		var body []struct {
+			Meanings []struct {
+				Definitions []struct {
+					Definition string `json:"definition"`
+				} `json:"definitions"`
+			} `json:"meanings"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		for _, e := range body {
+			for _, m := range e.Meanings {
+				for _, d := range m.Definitions {
+					definitions = append(definitions, d.Definition)
+				}
+			}
+		}
+	}
+	if len(definitions) == 0 {
+		return nil, errNotInDictionary
+	}
+	return definitions, nil
+}
+
+// definitionStopWords carry no meaning of their own in a definition.
+var definitionStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "to": true, "in": true, "on": true, "at": true, "for": true,
+	"by": true, "with": true, "from": true, "or": true, "and": true, "as": true, "that": true, "which": true,
+	"who": true, "is": true, "are": true, "be": true, "been": true, "being": true, "it": true, "its": true,
+	"something": true, "someone": true, "somebody": true, "one": true, "very": true, "not": true, "into": true,
+	"you": true, "your": true, "their": true, "them": true, "this": true, "way": true, "often": true, "especially": true,
+}
+
+// contentWords returns the lowercased words of a definition that carry
+// meaning.
+func contentWords(text string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r == '-')
+	}) {
+		if len(w) > 1 && !definitionStopWords[w] {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// definitionOverlap is the largest share of the content words of
+// definition that one of the reference definitions also uses, counting
+// inflected forms as the same word.
+func definitionOverlap(definition string, references []string) (float64, string) {
+	words := contentWords(definition)
+	if len(words) == 0 {
+		return 1, ""
+	}
+	best, closest := 0.0, ""
+	for _, ref := range references {
+		refWords := contentWords(ref)
+		shared := 0
+		for _, w := range words {
+			for _, r := range refWords {
+				if w == r || isInflectionOf(w, r) || isInflectionOf(r, w) {
+					shared++
+					break
+				}
+			}
+		}
+		if overlap := float64(shared) / float64(len(words)); overlap > best || closest == "" {
+			best, closest = overlap, ref
+		}
+	}
+	return best, closest
+}
+
+// DictionaryCheckReport is emitted as "generate:dictionary-checked".
+type DictionaryCheckReport struct {
+	Checked   int               `json:"checked"`
+	NotFound  []string          `json:"notFound"`  // words the dictionary does not have
+	Diverging []ValidationIssue `json:"diverging"` // definitions unlike the dictionary's
+}
+
+// checkDictionary compares the definition in the body of every 영영풀이
+// question with the dictionary's definitions of its keyed word.
+func checkDictionary(s DictionarySettings, output string) (DictionaryCheckReport, error) {
+	report := DictionaryCheckReport{NotFound: []string{}, Diverging: []ValidationIssue{}}
+	for _, q := range parseOutput(output).Questions {
+		word := blankAnswer(q)
+		definition := strings.Join(q.Body, " ")
+		if word == "" || strings.TrimSpace(definition) == "" {
+			continue
+		}
+		references, err := lookupDefinitions(s, word)
+		if err == errNotInDictionary {
+			report.NotFound = append(report.NotFound, word)
+			continue
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Checked++
+		if overlap, closest := definitionOverlap(definition, references); overlap < definitionOverlapMin {
+			report.Diverging = append(report.Diverging, ValidationIssue{
+				Question: q.Number,
+				Message:  fmt.Sprintf("'%s'의 풀이가 사전과 다릅니다. 사전 풀이: %s", word, closest),
+			})
+		}
+	}
+	return report, nil
+}
+
+// checkDictionaryAndReport runs checkDictionary for Generate. Lookup errors
+// are logged; the output is never changed, only flagged.
+func (a *VocabApp) checkDictionaryAndReport(s DictionarySettings, output string) {
+	report, err := checkDictionary(s, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "사전 대조 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:dictionary-checked", report)
+}
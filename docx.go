@@ -0,0 +1,243 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- Minimal DOCX Writer ---
+
+// docxRun is a span of text with uniform formatting.
+type docxRun struct {
+	Text      string
+	Bold      bool
+	Underline bool
+}
+
+// docxParagraph is a paragraph as laid out, kept for previews.
+type docxParagraph struct {
+	Runs       []docxRun
+	HalfPts    int
+	Align      string
+	SpaceAfter int        // in twips
+	PageBreak  bool       // a hard page break rather than text
+	Table      [][]string // the rows of a table rather than text
+}
+
+// docxBuilder accumulates WordprocessingML paragraphs. It only implements
+// the handful of features the test layouts need, which keeps the output
+// readable by Word, 한글 and Google Docs alike.
+type docxBuilder struct {
+	body    strings.Builder
+	font    string
+	halfPts int // font size in half-points, as Word stores it
+	spacing int // line spacing in 240ths of a line
+	paras   []docxParagraph
+
+	fontData []byte // embedded font file, if any
+
+	// props are custom document properties (File > Properties > Custom in
+	// Word); they are not printed.
+	props [][2]string
+}
+
+func newDocxBuilder() *docxBuilder {
+	return &docxBuilder{font: "맑은 고딕", halfPts: 22, spacing: 276}
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func (b *docxBuilder) runXML(r docxRun, halfPts int) string {
+	var props strings.Builder
+	fmt.Fprintf(&props, `<w:rFonts w:ascii="%[1]s" w:hAnsi="%[1]s" w:eastAsia="%[1]s" w:cs="%[1]s"/>`, xmlEscape(b.font))
+	if r.Bold {
+		props.WriteString(`<w:b/>`)
+	}
+	if r.Underline {
+		props.WriteString(`<w:u w:val="single"/>`)
+	}
+	fmt.Fprintf(&props, `<w:sz w:val="%d"/><w:szCs w:val="%d"/>`, halfPts, halfPts)
+	return fmt.Sprintf(`<w:r><w:rPr>%s</w:rPr><w:t xml:space="preserve">%s</w:t></w:r>`, props.String(), xmlEscape(r.Text))
+}
+
+func (b *docxBuilder) writeParagraph(runs []docxRun, halfPts int, align string, spaceAfter int) {
+	b.paras = append(b.paras, docxParagraph{Runs: runs, HalfPts: halfPts, Align: align, SpaceAfter: spaceAfter})
+	fmt.Fprintf(&b.body, `<w:p><w:pPr><w:spacing w:after="%d" w:line="%d" w:lineRule="auto"/>`, spaceAfter, b.spacing)
+	if align != "" {
+		fmt.Fprintf(&b.body, `<w:jc w:val="%s"/>`, align)
+	}
+	b.body.WriteString(`</w:pPr>`)
+	for _, r := range runs {
+		b.body.WriteString(b.runXML(r, halfPts))
+	}
+	b.body.WriteString(`</w:p>`)
+}
+
+func (b *docxBuilder) Title(text string) {
+	b.writeParagraph([]docxRun{{Text: text, Bold: true}}, b.halfPts+10, "center", 240)
+}
+
+func (b *docxBuilder) Heading(text string) {
+	b.writeParagraph([]docxRun{{Text: text, Bold: true}}, b.halfPts+4, "", 120)
+}
+
+func (b *docxBuilder) Paragraph(runs ...docxRun) {
+	b.writeParagraph(runs, b.halfPts, "", 80)
+}
+
+func (b *docxBuilder) Text(text string) {
+	b.Paragraph(docxRun{Text: text})
+}
+
+func (b *docxBuilder) Blank() {
+	b.writeParagraph(nil, b.halfPts, "", 0)
+}
+
+func (b *docxBuilder) PageBreak() {
+	b.paras = append(b.paras, docxParagraph{PageBreak: true})
+	b.body.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+}
+
+// Table adds a bordered table spanning the page width, with equal columns.
+func (b *docxBuilder) Table(rows [][]string) {
+	cols := 0
+	for _, r := range rows {
+		if len(r) > cols {
+			cols = len(r)
+		}
+	}
+	if cols == 0 {
+		return
+	}
+	b.paras = append(b.paras, docxParagraph{Table: rows, HalfPts: b.halfPts})
+	width := (11906 - 2*1134) / cols
+	b.body.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(&b.body, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="auto"/>`, side)
+	}
+	b.body.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid>`)
+	for i := 0; i < cols; i++ {
+		fmt.Fprintf(&b.body, `<w:gridCol w:w="%d"/>`, width)
+	}
+	b.body.WriteString(`</w:tblGrid>`)
+	for _, r := range rows {
+		b.body.WriteString(`<w:tr>`)
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(r) {
+				cell = r[i]
+			}
+			fmt.Fprintf(&b.body, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/></w:tcPr><w:p><w:pPr><w:jc w:val="center"/></w:pPr>%s</w:p></w:tc>`, width, b.runXML(docxRun{Text: cell}, b.halfPts))
+		}
+		b.body.WriteString(`</w:tr>`)
+	}
+	b.body.WriteString(`</w:tbl>`)
+}
+
+// SetProperty adds a custom document property.
+func (b *docxBuilder) SetProperty(name string, value string) {
+	b.props = append(b.props, [2]string{name, value})
+}
+
+func (b *docxBuilder) customPropsXML() string {
+	var x strings.Builder
+	x.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes">`)
+	for i, p := range b.props {
+		// pid 0 and 1 are reserved
+		fmt.Fprintf(&x, `<property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="%d" name="%s"><vt:lpwstr>%s</vt:lpwstr></property>`, i+2, xmlEscape(p[0]), xmlEscape(p[1]))
+	}
+	x.WriteString(`</Properties>`)
+	return x.String()
+}
+
+const (
+	docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/><Override PartName="/docProps/custom.xml" ContentType="application/vnd.openxmlformats-officedocument.custom-properties+xml"/>%s</Types>`
+	docxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties" Target="docProps/custom.xml"/></Relationships>`
+	docxDocumentHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`
+	docxFontContentTypes = `<Default Extension="odttf" ContentType="application/vnd.openxmlformats-officedocument.obfuscatedFont"/><Override PartName="/word/fontTable.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.fontTable+xml"/><Override PartName="/word/settings.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.settings+xml"/>`
+	docxDocumentRels     = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/fontTable" Target="fontTable.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/settings" Target="settings.xml"/></Relationships>`
+	docxFontTableRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/font" Target="fonts/font1.odttf"/></Relationships>`
+	docxSettings = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:settings xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:embedTrueTypeFonts/></w:settings>`
+	docxDocumentFooter = `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="567" w:footer="567" w:gutter="0"/></w:sectPr></w:body></w:document>`
+)
+
+// EmbedFont embeds the TrueType/OpenType file of the document font, so the
+// document looks the same on computers without the font installed.
+func (b *docxBuilder) EmbedFont(data []byte) {
+	b.fontData = data
+}
+
+// obfuscateFont applies the font obfuscation of ECMA-376 Part 2: the first
+// 32 bytes are XORed with the key GUID, read as bytes in reverse order.
+func obfuscateFont(data []byte, key string) []byte {
+	hexKey := strings.NewReplacer("{", "", "}", "", "-", "").Replace(key)
+	var k [16]byte
+	for i := range k {
+		v, _ := strconv.ParseUint(hexKey[30-2*i:32-2*i], 16, 8)
+		k[i] = byte(v)
+	}
+	out := append([]byte(nil), data...)
+	for i := 0; i < 32 && i < len(out); i++ {
+		out[i] ^= k[i%16]
+	}
+	return out
+}
+
+// Bytes packages the document as a .docx (A4, 2cm margins).
+func (b *docxBuilder) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fontTypes := ""
+	if b.fontData != nil {
+		fontTypes = docxFontContentTypes
+	}
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", fmt.Sprintf(docxContentTypes, fontTypes)},
+		{"_rels/.rels", docxRootRels},
+		{"word/document.xml", docxDocumentHeader + b.body.String() + docxDocumentFooter},
+		{"docProps/custom.xml", b.customPropsXML()},
+	}
+	if b.fontData != nil {
+		k := make([]byte, 16)
+		rand.Read(k)
+		key := fmt.Sprintf("{%X-%X-%X-%X-%X}", k[0:4], k[4:6], k[6:8], k[8:10], k[10:16])
+		fontTable := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:fonts xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:font w:name="%s"><w:charset w:val="81"/><w:embedRegular r:id="rId1" w:fontKey="%s"/></w:font></w:fonts>`, xmlEscape(b.font), key)
+		files = append(files, []struct{ name, content string }{
+			{"word/_rels/document.xml.rels", docxDocumentRels},
+			{"word/settings.xml", docxSettings},
+			{"word/fontTable.xml", fontTable},
+			{"word/_rels/fontTable.xml.rels", docxFontTableRels},
+			{"word/fonts/font1.odttf", string(obfuscateFont(b.fontData, key))},
+		}...)
+	}
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
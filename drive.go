@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"time"
+)
+
+// --- Google Drive ---
+
+const (
+	driveExportFolderName = "영어 단어 문제"
+
+	mimeDocx      = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimeGoogleDoc = "application/vnd.google-apps.document"
+)
+
+type driveFile struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	MD5Checksum  string    `json:"md5Checksum"`
+	ModifiedTime time.Time `json:"modifiedTime"`
+	WebViewLink  string    `json:"webViewLink"`
+}
+
+// driveUpload creates (empty id) or replaces a Drive file with a multipart
+// upload and returns the resulting metadata.
+func driveUpload(client *http.Client, id string, metadata map[string]interface{}, mimeType string, content []byte) (driveFile, error) {
+	var file driveFile
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	metaPart, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json; charset=UTF-8"}})
+	json.NewEncoder(metaPart).Encode(metadata)
+	mediaPart, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {mimeType}})
+	mediaPart.Write(content)
+	mw.Close()
+
+	method := http.MethodPost
+	target := "https://www.googleapis.com/upload/drive/v3/files?uploadType=multipart&fields=id,name,md5Checksum,modifiedTime,webViewLink"
+	if id != "" {
+		method = http.MethodPatch
+		target = "https://www.googleapis.com/upload/drive/v3/files/" + url.PathEscape(id) + "?uploadType=multipart&fields=id,name,md5Checksum,modifiedTime,webViewLink"
+	}
+	req, err := http.NewRequest(method, target, &body)
+	if err != nil {
+		return file, err
+	}
+	req.Header.Set("Content-Type", "multipart/related; boundary="+mw.Boundary())
+	resp, err := client.Do(req)
+	if err != nil {
+		return file, err
+	}
+	defer resp.Body.Close()
+	if err := checkGoogleResponse(resp); err != nil {
+		return file, err
+	}
+	return file, json.NewDecoder(resp.Body).Decode(&file)
+}
+
+func driveCreateFolder(client *http.Client, name string) (driveFile, error) {
+	var folder driveFile
+	meta, _ := json.Marshal(map[string]string{
+		"name":     name,
+		"mimeType": "application/vnd.google-apps.folder",
+	})
+	resp, err := client.Post("https://www.googleapis.com/drive/v3/files?fields=id,name,webViewLink", "application/json", bytes.NewReader(meta))
+	if err != nil {
+		return folder, err
+	}
+	defer resp.Body.Close()
+	if err := checkGoogleResponse(resp); err != nil {
+		return folder, err
+	}
+	return folder, json.NewDecoder(resp.Body).Decode(&folder)
+}
+
+func driveList(client *http.Client, query string) ([]driveFile, error) {
+	target := "https://www.googleapis.com/drive/v3/files?" + url.Values{
+		"q":        {query},
+		"fields":   {"files(id,name,md5Checksum,modifiedTime,webViewLink)"},
+		"pageSize": {"1000"},
+	}.Encode()
+	resp, err := client.Get(target)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkGoogleResponse(resp); err != nil {
+		return nil, err
+	}
+	var result struct {
+		Files []driveFile `json:"files"`
+	}
+	return result.Files, json.NewDecoder(resp.Body).Decode(&result)
+}
+
+// shareAnyoneWithLink makes a Drive file readable by anyone holding the link.
+func shareAnyoneWithLink(client *http.Client, id string) error {
+	perm, _ := json.Marshal(map[string]string{"role": "reader", "type": "anyone"})
+	resp, err := client.Post("https://www.googleapis.com/drive/v3/files/"+url.PathEscape(id)+"/permissions", "application/json", bytes.NewReader(perm))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkGoogleResponse(resp)
+}
+
+// exportFolder returns the configured Drive export folder, creating the
+// default one on first use and remembering its ID.
+func (a *VocabApp) exportFolder(client *http.Client) (string, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if settings.Google.ExportFolderID != "" {
+		return settings.Google.ExportFolderID, nil
+	}
+
+	folder, err := driveCreateFolder(client, driveExportFolderName)
+	if err != nil {
+		return "", err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err = loadSettings()
+	if err != nil {
+		return "", err
+	}
+	settings.Google.ExportFolderID = folder.ID
+	return folder.ID, saveJSON(settingsFile, settings)
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExportToDrive uploads the test as a DOCX file, or converted to a native
+// Google Docs document when asGoogleDoc is set, and returns its link. The
+// file is shared with anyone holding the link only when
+// GoogleSettings.ShareWithLink is on.
+func (a *VocabApp) ExportToDrive(contentToSave string, suggestedFilename string, asGoogleDoc bool) (string, error) {
+	client, err := a.googleHTTPClient()
+	if err != nil {
+		return "", err
+	}
+	name, err := a.planExportName(contentToSave, "", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	title := name.Title
+	opts, err := a.testExportOptions(title, contentToSave)
+	if err != nil {
+		return "", err
+	}
+	content, err := renderTestDocx(contentToSave, opts)
+	if err != nil {
+		return "", fmt.Errorf("문서 생성 오류: %w", err)
+	}
+	folderID, err := a.exportFolder(client)
+	if err != nil {
+		return "", err
+	}
+
+	metadata := map[string]interface{}{
+		"name":    title + ".docx",
+		"parents": []string{folderID},
+	}
+	if asGoogleDoc {
+		metadata["name"] = title
+		metadata["mimeType"] = mimeGoogleDoc
+	}
+	file, err := driveUpload(client, "", metadata, mimeDocx, content)
+	if err != nil {
+		return "", fmt.Errorf("Google Drive 업로드 오류: %w", err)
+	}
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if settings.Google.ShareWithLink {
+		if err := shareAnyoneWithLink(client, file.ID); err != nil {
+			return "", fmt.Errorf("공유 링크 생성 오류: %w", err)
+		}
+	}
+	a.commitExportName(name)
+	return file.WebViewLink, nil
+}
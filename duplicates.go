@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Duplicate Sentences ---
+
+// duplicateSimilarity is the word overlap (Jaccard index) from which two
+// context sentences count as the same sentence.
+const duplicateSimilarity = 0.8
+
+const duplicateRepairPrompt = `You are an expert English vocabulary test maker for Korean students.
+Some context sentences of this test are nearly identical to sentences of other questions. Rewrite each listed sentence as a new, natural sentence with a different situation and wording that still tests the same answer in the same way: keep every blank written as _______ and the same number of blanks, and keep the difficulty.
+Respond with JSON only: {"questions": [{"question": 1, "sentences": {"2": "rewritten sentence"}}]}. Sentence numbers are 1-based.`
+
+// DuplicateCheckReport is emitted as "generate:duplicates-checked".
+type DuplicateCheckReport struct {
+	Checked    int               `json:"checked"`    // sentences compared
+	Duplicates int               `json:"duplicates"` // sentences that repeated an earlier one
+	Rewritten  []int             `json:"rewritten"`  // questions with sentences rewritten by the model
+	Unresolved []ValidationIssue `json:"unresolved"`
+}
+
+// sentenceWords returns the set of lowercased words of a sentence, with
+// every blank as the same word so sentences testing different answers still
+// compare equal.
+func sentenceWords(sentence string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(blankRe.ReplaceAllString(sentence, " _ ")), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'' || r == '_')
+	}) {
+		words[w] = true
+	}
+	return words
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// sentenceRef locates a context sentence: question index and body line.
+type sentenceRef struct {
+	question, line int
+}
+
+// findDuplicates returns the English context sentences that repeat an
+// earlier one, mapped to the sentence they repeat.
+func findDuplicates(questions []Question) (map[sentenceRef]sentenceRef, int) {
+	type seen struct {
+		ref   sentenceRef
+		words map[string]bool
+	}
+	var earlier []seen
+	duplicates := map[sentenceRef]sentenceRef{}
+	for qi, q := range questions {
+		for li, line := range q.Body {
+			if strings.TrimSpace(line) == "" || hasHangul(line) {
+				continue
+			}
+			ref, words := sentenceRef{qi, li}, sentenceWords(line)
+			for _, e := range earlier {
+				if jaccard(words, e.words) >= duplicateSimilarity {
+					duplicates[ref] = e.ref
+					break
+				}
+			}
+			earlier = append(earlier, seen{ref, words})
+		}
+	}
+	return duplicates, len(earlier)
+}
+
+// checkDuplicates finds context sentences that repeat another sentence of
+// the test, which happens often with common words, and has the model rewrite
+// the later copy. The first use of a sentence is kept.
+func (a *VocabApp) checkDuplicates(modelID string, output string) (string, DuplicateCheckReport, error) {
+	var report DuplicateCheckReport
+	out := parseOutput(output)
+	duplicates, checked := findDuplicates(out.Questions)
+	report.Checked = checked
+	report.Duplicates = len(duplicates)
+	if len(duplicates) == 0 {
+		return output, report, nil
+	}
+
+	var request strings.Builder
+	for qi, q := range out.Questions {
+		var lines []string
+		for li, line := range q.Body {
+			if orig, ok := duplicates[sentenceRef{qi, li}]; ok {
+				lines = append(lines, fmt.Sprintf("  %d: %s\n     (repeats question %d: %s)", li+1, line, out.Questions[orig.question].Number, out.Questions[orig.question].Body[orig.line]))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if answer := blankAnswer(q); answer != "" {
+			fmt.Fprintf(&request, "Question %d (answer: %s)\n", q.Number, answer)
+		} else {
+			fmt.Fprintf(&request, "Question %d\n", q.Number)
+		}
+		request.WriteString(strings.Join(lines, "\n") + "\n\n")
+	}
+
+	var repair blankRepairResult
+	err := a.callChatGPTJSON(modelID, duplicateRepairPrompt, request.String(), &repair)
+	if err == nil {
+		index := map[int]int{}
+		for i, q := range out.Questions {
+			index[q.Number] = i
+		}
+		for _, r := range repair.Questions {
+			qi, ok := index[r.Question]
+			if !ok {
+				continue
+			}
+			q := &out.Questions[qi]
+			changed := false
+			for key, sentence := range r.Sentences {
+				n, convErr := strconv.Atoi(key)
+				if convErr != nil || n < 1 || n > len(q.Body) {
+					continue
+				}
+				if _, dup := duplicates[sentenceRef{qi, n - 1}]; !dup || len(blankRe.FindAllString(sentence, -1)) != len(blankRe.FindAllString(q.Body[n-1], -1)) {
+					continue
+				}
+				q.Body[n-1] = blankRe.ReplaceAllString(strings.TrimSpace(sentence), blankMarker)
+				changed = true
+			}
+			if changed {
+				report.Rewritten = append(report.Rewritten, q.Number)
+			}
+		}
+	}
+
+	remaining, _ := findDuplicates(out.Questions)
+	for ref, orig := range remaining {
+		report.Unresolved = append(report.Unresolved, ValidationIssue{
+			Question: out.Questions[ref.question].Number,
+			Message:  fmt.Sprintf("%d번째 예문이 %d번 문제의 예문과 거의 같습니다", ref.line+1, out.Questions[orig.question].Number),
+		})
+	}
+	sort.Slice(report.Unresolved, func(i, j int) bool {
+		x, y := report.Unresolved[i], report.Unresolved[j]
+		return x.Question < y.Question || x.Question == y.Question && x.Message < y.Message
+	})
+	if len(report.Rewritten) == 0 {
+		return output, report, err
+	}
+	return formatOutput(out), report, err
+}
+
+// checkDuplicatesAndReport runs checkDuplicates for Generate. A failed
+// rewrite is logged and the duplicates are reported as unresolved.
+func (a *VocabApp) checkDuplicatesAndReport(modelID string, output string) string {
+	checked, report, err := a.checkDuplicates(modelID, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "중복 예문 검사 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:duplicates-checked", report)
+	return checked
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Email ---
+
+const outboxDir = "outbox"
+
+// SMTPSettings configures outgoing mail. Port 465 uses implicit TLS; any other
+// port upgrades with STARTTLS when the server offers it.
+type SMTPSettings struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
+
+func (s SMTPSettings) configured() bool {
+	return s.Host != "" && s.From != ""
+}
+
+// buildMailMessage creates a multipart/mixed message with a text body and one
+// attachment, with non-ASCII headers and filenames encoded per RFC 2047.
+func buildMailMessage(from string, to []string, subject string, body string, filename string, mimeType string, attachment []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mw.Boundary())
+
+	textPart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeBase64Lines(textPart, []byte(body))
+
+	encodedName := mime.BEncoding.Encode("UTF-8", filename)
+	filePart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {fmt.Sprintf("%s; name=\"%s\"", mimeType, encodedName)},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {fmt.Sprintf("attachment; filename=\"%s\"", encodedName)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeBase64Lines(filePart, attachment)
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBase64Lines writes data as base64 wrapped at 76 characters (RFC 2045).
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
+
+func sendSMTP(s SMTPSettings, to []string, message []byte) error {
+	port := s.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(port))
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	if port != 465 {
+		return smtp.SendMail(addr, auth, s.From, to, message)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: s.Host})
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func parseRecipients(recipients string) ([]string, error) {
+	list, err := mail.ParseAddressList(strings.ReplaceAll(recipients, ";", ","))
+	if err != nil {
+		return nil, fmt.Errorf("받는 사람 주소 형식 오류: %w", err)
+	}
+	var to []string
+	for _, addr := range list {
+		to = append(to, addr.Address)
+	}
+	return to, nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// SendExport emails the exported test as an attachment. Without SMTP settings
+// it falls back to the user's mail client: the attachment is written to the
+// outbox folder and a mailto: link with subject and body is opened.
+func (a *VocabApp) SendExport(contentToSend string, suggestedFilename string, format string, recipients string, subject string, body string) (string, error) {
+	to, err := parseRecipients(recipients)
+	if err != nil {
+		return "", err
+	}
+	name, err := a.planExportName(contentToSend, "", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	title := name.Title
+	opts, err := a.testExportOptions(title, contentToSend)
+	if err != nil {
+		return "", err
+	}
+	data, ext, mimeType, err := renderExport(format, contentToSend, opts)
+	if err != nil {
+		return "", err
+	}
+	filename := title + ext
+	if subject == "" {
+		subject = title
+	}
+
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	if settings.SMTP.configured() {
+		message, err := buildMailMessage(settings.SMTP.From, to, subject, body, filename, mimeType, data)
+		if err != nil {
+			return "", err
+		}
+		if err := sendSMTP(settings.SMTP, to, message); err != nil {
+			return "", fmt.Errorf("메일 전송 오류: %w", err)
+		}
+		a.commitExportName(name)
+		return fmt.Sprintf("메일 전송 완료: %s", strings.Join(to, ", ")), nil
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	attachmentPath := filepath.Join(dir, outboxDir, filename)
+	if err := os.MkdirAll(filepath.Dir(attachmentPath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(attachmentPath, data, 0644); err != nil {
+		return "", fmt.Errorf("첨부 파일 저장 오류: %w", err)
+	}
+	mailBody := body + "\n\n(첨부: " + attachmentPath + ")"
+	mailto := "mailto:" + strings.Join(to, ",") + "?" + strings.ReplaceAll(url.Values{
+		"subject": {subject},
+		"body":    {mailBody},
+	}.Encode(), "+", "%20")
+	runtime.BrowserOpenURL(a.ctx, mailto)
+	a.commitExportName(name)
+	return fmt.Sprintf("메일 앱에서 첨부 파일을 추가하세요: %s", attachmentPath), nil
+}
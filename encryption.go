@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// --- Encryption at Rest ---
+
+// With encryption on, every store written by saveJSON is sealed with
+// AES-256-GCM under a key derived from the user's passphrase. The key lives
+// in memory only; after a restart the stores stay unreadable until
+// UnlockStore is called.
+
+const (
+	encryptionFile   = ".encryption.json"
+	encryptionMagic  = "VGENC1\n"
+	pbkdf2Iterations = 600000
+	encryptionCheck  = "vocab-generator-wails"
+)
+
+var errStoreLocked = errors.New("데이터가 암호화되어 있습니다. 암호를 입력해 잠금을 해제하세요")
+
+// storeCipher seals the stores; nil while encryption is off or locked. It
+// is only changed with a.mu held, like the stores themselves.
+var storeCipher cipher.AEAD
+
+// encryptionMeta is stored unencrypted next to the stores. Check is a known
+// text sealed with the key, to tell a wrong passphrase apart.
+type encryptionMeta struct {
+	Salt       []byte `json:"salt"`
+	Iterations int    `json:"iterations"`
+	Check      []byte `json:"check"`
+}
+
+// EncryptionStatus is returned by GetEncryptionStatus.
+type EncryptionStatus struct {
+	Enabled  bool `json:"enabled"`
+	Unlocked bool `json:"unlocked"`
+}
+
+func newStoreCipher(passphrase string, meta encryptionMeta) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), meta.Salt, meta.Iterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plain []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return append([]byte(encryptionMagic), aead.Seal(nonce, nonce, plain, nil)...), nil
+}
+
+func isSealed(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(encryptionMagic))
+}
+
+func unseal(aead cipher.AEAD, content []byte) ([]byte, error) {
+	content = content[len(encryptionMagic):]
+	if len(content) < aead.NonceSize() {
+		return nil, fmt.Errorf("암호화된 데이터가 손상되었습니다")
+	}
+	nonce, sealed := content[:aead.NonceSize()], content[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("암호를 풀 수 없습니다: %w", err)
+	}
+	return plain, nil
+}
+
+// decodeStore undoes the encryption of a store file, if any.
+func decodeStore(content []byte) ([]byte, error) {
+	if !isSealed(content) {
+		return content, nil
+	}
+	if storeCipher == nil {
+		return nil, errStoreLocked
+	}
+	return unseal(storeCipher, content)
+}
+
+// encodeStore encrypts a store file when encryption is on.
+func encodeStore(content []byte) ([]byte, error) {
+	if storeCipher == nil {
+		if encryptionEnabled() {
+			return nil, errStoreLocked
+		}
+		return content, nil
+	}
+	return seal(storeCipher, content)
+}
+
+func encryptionPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, encryptionFile), nil
+}
+
+func encryptionEnabled() bool {
+	path, err := encryptionPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func loadEncryptionMeta() (encryptionMeta, error) {
+	var meta encryptionMeta
+	path, err := encryptionPath()
+	if err != nil {
+		return meta, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return meta, fmt.Errorf("암호화 설정 읽기 오류: %w", err)
+	}
+	return meta, json.Unmarshal(content, &meta)
+}
+
+// unlockWith checks the passphrase against the stored meta and returns the
+// store cipher.
+func unlockWith(passphrase string) (cipher.AEAD, error) {
+	meta, err := loadEncryptionMeta()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newStoreCipher(passphrase, meta)
+	if err != nil {
+		return nil, err
+	}
+	if check, err := unseal(aead, meta.Check); err != nil || string(check) != encryptionCheck {
+		return nil, fmt.Errorf("암호가 올바르지 않습니다")
+	}
+	return aead, nil
+}
+
+// storeFiles lists the JSON stores in the data directory.
+func storeFiles() ([]string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// rewriteStores reads every store and writes it back sealed with next, or
+// plain for nil. Must be called with a.mu held and storeCipher able to read
+// the stores.
+func rewriteStores(next cipher.AEAD) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	names, err := storeFiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		var v json.RawMessage
+		if err := loadJSON(name, &v); err != nil {
+			return err
+		}
+		content, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			if content, err = seal(next, content); err != nil {
+				return err
+			}
+		}
+		if err := writeDataFile(dir, name, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetEncryptionStatus tells the UI whether to ask for the passphrase.
+func (a *VocabApp) GetEncryptionStatus() EncryptionStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return EncryptionStatus{Enabled: encryptionEnabled(), Unlocked: storeCipher != nil}
+}
+
+// EnableEncryption encrypts all stores with a key derived from passphrase.
+// A lost passphrase cannot be recovered.
+func (a *VocabApp) EnableEncryption(passphrase string) error {
+	if len([]rune(passphrase)) < 8 {
+		return fmt.Errorf("암호는 8자 이상이어야 합니다")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if encryptionEnabled() {
+		return fmt.Errorf("이미 암호화되어 있습니다")
+	}
+	meta := encryptionMeta{Salt: make([]byte, 16), Iterations: pbkdf2Iterations}
+	if _, err := rand.Read(meta.Salt); err != nil {
+		return err
+	}
+	aead, err := newStoreCipher(passphrase, meta)
+	if err != nil {
+		return err
+	}
+	if meta.Check, err = seal(aead, []byte(encryptionCheck)); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	path, err := encryptionPath()
+	if err != nil {
+		return err
+	}
+	// The meta goes first: stores sealed before a failure stay readable,
+	// and the plain ones left are sealed on their next save.
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("암호화 설정 저장 오류: %w", err)
+	}
+	storeCipher = aead
+	if err := rewriteStores(aead); err != nil {
+		return fmt.Errorf("암호화 오류: %w", err)
+	}
+	return nil
+}
+
+// UnlockStore makes the encrypted stores readable for this session.
+func (a *VocabApp) UnlockStore(passphrase string) error {
+	a.mu.Lock()
+	aead, err := unlockWith(passphrase)
+	if err == nil {
+		storeCipher = aead
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := a.reloadClient(); err != nil && err != errNoAPIKey {
+		return err
+	}
+	return nil
+}
+
+// DisableEncryption decrypts all stores and turns encryption off.
+func (a *VocabApp) DisableEncryption(passphrase string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	aead, err := unlockWith(passphrase)
+	if err != nil {
+		return err
+	}
+	storeCipher = aead
+	path, err := encryptionPath()
+	if err != nil {
+		return err
+	}
+	// The meta is removed last, so stores left sealed by a failure can
+	// still be read with the passphrase.
+	if err := rewriteStores(nil); err != nil {
+		return fmt.Errorf("복호화 오류: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	storeCipher = nil
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Generation Time Estimates ---
+
+const latencyFile = "latency.json"
+
+// modelLatency is the running average generation time per question for one
+// model. Recent runs weigh more, so the estimate follows API slowdowns.
+type modelLatency struct {
+	Runs               int     `json:"runs"`
+	SecondsPerQuestion float64 `json:"secondsPerQuestion"`
+}
+
+const latencySmoothing = 0.3 // weight of the newest run
+
+// ETAUpdate is emitted as "generate:eta" when a run starts and whenever
+// another question block has streamed in. RemainingSeconds is negative while
+// no estimate is available (a model's first run, before any block is done).
+type ETAUpdate struct {
+	Model            string  `json:"model"`
+	QuestionType     string  `json:"questionType"`
+	Total            int     `json:"total"`
+	Done             int     `json:"done"`
+	ElapsedSeconds   float64 `json:"elapsedSeconds"`
+	RemainingSeconds float64 `json:"remainingSeconds"`
+}
+
+// etaTracker turns streamed output into ETA updates.
+type etaTracker struct {
+	app     *VocabApp
+	model   string
+	qType   string
+	total   int
+	history modelLatency
+	started time.Time
+	done    int
+}
+
+func (a *VocabApp) startETA(model string, questionType string, total int) *etaTracker {
+	a.mu.Lock()
+	var latencies map[string]modelLatency
+	err := loadJSON(latencyFile, &latencies)
+	a.mu.Unlock()
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "생성 시간 기록 읽기 오류: %v", err)
+	}
+	t := &etaTracker{app: a, model: model, qType: questionType, total: total, history: latencies[model], started: time.Now()}
+	t.emit()
+	return t
+}
+
+func (t *etaTracker) emit() {
+	elapsed := time.Since(t.started).Seconds()
+	remaining := -1.0
+	left := float64(t.total - t.done)
+	if left < 0 {
+		left = 0
+	}
+	switch {
+	case t.done > 0:
+		// Blend the live pace with the history; the live pace wins as more
+		// blocks complete.
+		live := elapsed / float64(t.done)
+		perQuestion := live
+		if t.history.Runs > 0 {
+			w := float64(t.done) / float64(t.total)
+			perQuestion = w*live + (1-w)*t.history.SecondsPerQuestion
+		}
+		remaining = left * perQuestion
+	case t.history.Runs > 0:
+		remaining = left*t.history.SecondsPerQuestion - elapsed
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+	runtime.EventsEmit(t.app.ctx, "generate:eta", ETAUpdate{
+		Model:            t.model,
+		QuestionType:     t.qType,
+		Total:            t.total,
+		Done:             t.done,
+		ElapsedSeconds:   elapsed,
+		RemainingSeconds: remaining,
+	})
+}
+
+// progress is called with the output streamed so far. A question block is
+// complete once its '---' separator has arrived. This runs for every streamed
+// chunk, so it only counts separators instead of parsing the output.
+func (t *etaTracker) progress(text string) {
+	done := strings.Count(text, "\n---")
+	if done > t.done && done < t.total {
+		t.done = done
+		t.emit()
+	}
+}
+
+// finish records the run's per-question time for later estimates.
+func (t *etaTracker) finish(questions int) {
+	if questions == 0 {
+		return
+	}
+	perQuestion := time.Since(t.started).Seconds() / float64(questions)
+	a := t.app
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var latencies map[string]modelLatency
+	if err := loadJSON(latencyFile, &latencies); err != nil {
+		runtime.LogErrorf(a.ctx, "생성 시간 기록 읽기 오류: %v", err)
+		return
+	}
+	if latencies == nil {
+		latencies = map[string]modelLatency{}
+	}
+	l := latencies[t.model]
+	if l.Runs == 0 {
+		l.SecondsPerQuestion = perQuestion
+	} else {
+		l.SecondsPerQuestion = latencySmoothing*perQuestion + (1-latencySmoothing)*l.SecondsPerQuestion
+	}
+	l.Runs++
+	latencies[t.model] = l
+	if err := saveJSON(latencyFile, latencies); err != nil {
+		runtime.LogErrorf(a.ctx, "생성 시간 기록 저장 오류: %v", err)
+	}
+}
+
+// --- Go functions callable from Javascript ---
+
+// EstimateGenerationTime returns the expected duration in seconds for a run of
+// the given size, or -1 when the model has no history yet.
+func (a *VocabApp) EstimateGenerationTime(modelID string, questions int) (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var latencies map[string]modelLatency
+	if err := loadJSON(latencyFile, &latencies); err != nil {
+		return 0, err
+	}
+	l, ok := latencies[modelID]
+	if !ok || l.Runs == 0 {
+		return -1, nil
+	}
+	return l.SecondsPerQuestion * float64(questions), nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/user"
+	"strings"
+	"time"
+)
+
+// --- Exam Mode Lock ---
+
+// Test audit actions.
+const (
+	AuditLock   = "lock"
+	AuditUnlock = "unlock"
+)
+
+// TestLock marks a finalized test: it can no longer be edited, extended,
+// re-pointed or trashed until it is unlocked again. ContentHash is the hash
+// of the content that was locked, so the stored test can be checked against
+// the printed one.
+type TestLock struct {
+	By          string    `json:"by"`
+	At          time.Time `json:"at"`
+	ContentHash string    `json:"contentHash"`
+}
+
+// TestAuditEntry is one lock or unlock of a test.
+type TestAuditEntry struct {
+	Action      string    `json:"action"`
+	By          string    `json:"by"`
+	At          time.Time `json:"at"`
+	Reason      string    `json:"reason,omitempty"`
+	ContentHash string    `json:"contentHash"`
+}
+
+// LockCheck is the result of VerifyTestLock.
+type LockCheck struct {
+	Locked bool `json:"locked"`
+	// Intact is false when the stored content no longer matches the content
+	// that was locked.
+	Intact      bool   `json:"intact"`
+	ContentHash string `json:"contentHash"`
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// errIfLocked refuses changes to a locked test.
+func errIfLocked(t SavedTest) error {
+	if t.Locked != nil {
+		return fmt.Errorf("'%s' 시험지는 %s에 %s님이 확정하여 수정할 수 없습니다", t.Name, t.Locked.At.Format("2006-01-02 15:04"), t.Locked.By)
+	}
+	return nil
+}
+
+// auditName is who locks or unlocks a test: the given name, else the active
+// class's teacher, else the OS user.
+func auditName(by string, settings Settings) string {
+	if by = strings.TrimSpace(by); by != "" {
+		return by
+	}
+	if name := strings.TrimSpace(settings.activeClass().TeacherName); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "unknown"
+}
+
+// updateTestLock applies change to a live test and stores it. Must be called
+// with a.mu held.
+func updateTestLock(testID string, change func(t *SavedTest) error) (SavedTest, error) {
+	tests, err := loadTests()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	for i := range tests {
+		if tests[i].ID == testID && tests[i].DeletedAt == nil {
+			if err := change(&tests[i]); err != nil {
+				return SavedTest{}, err
+			}
+			return tests[i], saveJSON(testsFile, tests)
+		}
+	}
+	return SavedTest{}, fmt.Errorf("시험지를 찾을 수 없습니다")
+}
+
+// --- Go functions callable from Javascript ---
+
+// LockTest finalizes a saved test for exam use. by is recorded in the audit
+// trail (empty = the active class's teacher or the OS user).
+func (a *VocabApp) LockTest(testID string, by string) (SavedTest, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	return updateTestLock(testID, func(t *SavedTest) error {
+		if t.Locked != nil {
+			return fmt.Errorf("이미 확정된 시험지입니다")
+		}
+		lock := TestLock{By: auditName(by, settings), At: time.Now(), ContentHash: contentHash(t.Content)}
+		t.Locked = &lock
+		t.Audit = append(t.Audit, TestAuditEntry{Action: AuditLock, By: lock.By, At: lock.At, ContentHash: lock.ContentHash})
+		return nil
+	})
+}
+
+// UnlockTest reopens a locked test for editing. A reason is required and is
+// kept in the audit trail.
+func (a *VocabApp) UnlockTest(testID string, by string, reason string) (SavedTest, error) {
+	if strings.TrimSpace(reason) == "" {
+		return SavedTest{}, fmt.Errorf("확정 해제 사유를 입력하세요")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	return updateTestLock(testID, func(t *SavedTest) error {
+		if t.Locked == nil {
+			return fmt.Errorf("확정되지 않은 시험지입니다")
+		}
+		t.Locked = nil
+		t.Audit = append(t.Audit, TestAuditEntry{
+			Action:      AuditUnlock,
+			By:          auditName(by, settings),
+			At:          time.Now(),
+			Reason:      strings.TrimSpace(reason),
+			ContentHash: contentHash(t.Content),
+		})
+		return nil
+	})
+}
+
+// VerifyTestLock checks that a locked test's stored content is still the
+// content that was locked (and printed).
+func (a *VocabApp) VerifyTestLock(testID string) (LockCheck, error) {
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return LockCheck{}, err
+	}
+	check := LockCheck{ContentHash: contentHash(test.Content), Intact: true}
+	if test.Locked != nil {
+		check.Locked = true
+		check.Intact = check.ContentHash == test.Locked.ContentHash
+	}
+	return check, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --- Word Exclusion List ---
+
+const exclusionsFile = "exclusions.json"
+
+// ExcludedWord is a word that is never tested, whatever list it comes from:
+// too easy for the class, or banned outright.
+type ExcludedWord struct {
+	Word      string    `json:"word"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ExclusionReport is emitted as "generate:excluded" when a run dropped words.
+type ExclusionReport struct {
+	QuestionType string   `json:"questionType"`
+	Excluded     []string `json:"excluded"`
+}
+
+func loadExclusions() ([]ExcludedWord, error) {
+	var words []ExcludedWord
+	return words, loadJSON(exclusionsFile, &words)
+}
+
+// excludeWords drops the entries on the exclusion list, matching headwords
+// without regard to case, and returns the dropped words.
+func excludeWords(parsed []VocabPair, exclusions []ExcludedWord) ([]VocabPair, []string) {
+	if len(exclusions) == 0 {
+		return parsed, nil
+	}
+	banned := map[string]bool{}
+	for _, w := range exclusions {
+		banned[strings.ToLower(w.Word)] = true
+	}
+	kept := make([]VocabPair, 0, len(parsed))
+	var excluded []string
+	for _, e := range parsed {
+		if banned[strings.ToLower(strings.TrimSpace(e.Word))] {
+			excluded = append(excluded, e.Word)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept, excluded
+}
+
+// --- Go functions callable from Javascript ---
+
+// ListExcludedWords returns the exclusion list in alphabetical order.
+func (a *VocabApp) ListExcludedWords() ([]ExcludedWord, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	words, err := loadExclusions()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(words, func(i, j int) bool { return strings.ToLower(words[i].Word) < strings.ToLower(words[j].Word) })
+	return words, nil
+}
+
+// AddExcludedWords puts words on the exclusion list with a shared reason and
+// returns how many were new.
+func (a *VocabApp) AddExcludedWords(words []string, reason string) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	list, err := loadExclusions()
+	if err != nil {
+		return 0, err
+	}
+	known := map[string]bool{}
+	for _, w := range list {
+		known[strings.ToLower(w.Word)] = true
+	}
+	added := 0
+	now := time.Now()
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" || known[strings.ToLower(w)] {
+			continue
+		}
+		known[strings.ToLower(w)] = true
+		list = append(list, ExcludedWord{Word: w, Reason: strings.TrimSpace(reason), CreatedAt: now})
+		added++
+	}
+	if added == 0 {
+		return 0, fmt.Errorf("추가할 새 단어가 없습니다")
+	}
+	return added, saveJSON(exclusionsFile, list)
+}
+
+// RemoveExcludedWord takes a word off the exclusion list.
+func (a *VocabApp) RemoveExcludedWord(word string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	list, err := loadExclusions()
+	if err != nil {
+		return err
+	}
+	kept := list[:0]
+	for _, w := range list {
+		if !strings.EqualFold(w.Word, strings.TrimSpace(word)) {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == len(list) {
+		return fmt.Errorf("제외 목록에 없는 단어입니다: '%s'", word)
+	}
+	return saveJSON(exclusionsFile, kept)
+}
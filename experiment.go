@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// --- A/B Prompt Template Experiments ---
+
+const experimentsFile = "experiments.json"
+
+// TemplateStats accumulates validation outcomes for one template over all
+// experiment runs, so template tweaks can be compared with data.
+type TemplateStats struct {
+	Template              string  `json:"template"`
+	QuestionType          string  `json:"questionType"`
+	Runs                  int     `json:"runs"`
+	APIErrors             int     `json:"apiErrors"`
+	Questions             int     `json:"questions"`
+	FailedQuestions       int     `json:"failedQuestions"`
+	KeyErrorQuestions     int     `json:"keyErrorQuestions"`
+	ValidationFailureRate float64 `json:"validationFailureRate"`
+	AnswerKeyErrorRate    float64 `json:"answerKeyErrorRate"`
+}
+
+func (s *TemplateStats) record(arm ExperimentArm) {
+	s.Runs++
+	if arm.Error != "" {
+		s.APIErrors++
+		return
+	}
+	s.Questions += arm.Validation.QuestionCount
+	s.FailedQuestions += arm.Validation.FailedQuestions()
+	s.KeyErrorQuestions += arm.Validation.KeyErrorQuestions()
+	if s.Questions > 0 {
+		s.ValidationFailureRate = float64(s.FailedQuestions) / float64(s.Questions)
+		s.AnswerKeyErrorRate = float64(s.KeyErrorQuestions) / float64(s.Questions)
+	}
+}
+
+type ExperimentArm struct {
+	Template   string           `json:"template"`
+	Output     string           `json:"output"`
+	Validation ValidationResult `json:"validation"`
+	Error      string           `json:"error,omitempty"`
+}
+
+type ExperimentResult struct {
+	A ExperimentArm `json:"a"`
+	B ExperimentArm `json:"b"`
+}
+
+// --- Go functions callable from Javascript ---
+
+// RunExperiment sends the same (identically shuffled) word list through two
+// prompt templates concurrently, validates both outputs and adds the results
+// to the per-template statistics.
+func (a *VocabApp) RunExperiment(vocabBlock string, modelID string, questionType string, numSentences int, templateA string, templateB string) (ExperimentResult, error) {
+	if a.client == nil {
+		return ExperimentResult{}, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	if templateA == templateB {
+		return ExperimentResult{}, fmt.Errorf("서로 다른 두 템플릿을 선택하세요")
+	}
+
+	parsed := parseVocabBlock(vocabBlock)
+	if len(parsed) == 0 {
+		return ExperimentResult{}, fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+	rand.Shuffle(len(parsed), func(i, j int) { parsed[i], parsed[j] = parsed[j], parsed[i] })
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return ExperimentResult{}, err
+	}
+	// Both arms are checked against the words actually asked for.
+	entries := settings.Generation.questionEntries(parsed, questionType)
+	userPrompt := buildUserPrompt(entries)
+
+	systemA, err := a.systemPromptFor(templateA, questionType, numSentences)
+	if err != nil {
+		return ExperimentResult{}, err
+	}
+	systemB, err := a.systemPromptFor(templateB, questionType, numSentences)
+	if err != nil {
+		return ExperimentResult{}, err
+	}
+
+	run := func(name string, systemPrompt string) ExperimentArm {
+		arm := ExperimentArm{Template: name}
+		output, err := a.callChatGPT(modelID, systemPrompt, userPrompt)
+		if err != nil {
+			arm.Error = err.Error()
+			return arm
+		}
+		arm.Output = output
+		arm.Validation = validateOutput(output, entries, questionType, numSentences)
+		return arm
+	}
+
+	var result ExperimentResult
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); result.A = run(templateA, systemA) }()
+	go func() { defer wg.Done(); result.B = run(templateB, systemB) }()
+	wg.Wait()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var stats []TemplateStats
+	if err := loadJSON(experimentsFile, &stats); err != nil {
+		return result, err
+	}
+	for _, arm := range []ExperimentArm{result.A, result.B} {
+		idx := -1
+		for i := range stats {
+			if stats[i].Template == arm.Template && stats[i].QuestionType == questionType {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			stats = append(stats, TemplateStats{Template: arm.Template, QuestionType: questionType})
+			idx = len(stats) - 1
+		}
+		stats[idx].record(arm)
+	}
+	return result, saveJSON(experimentsFile, stats)
+}
+
+func (a *VocabApp) GetExperimentStats() ([]TemplateStats, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var stats []TemplateStats
+	if err := loadJSON(experimentsFile, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (a *VocabApp) ResetExperimentStats() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return saveJSON(experimentsFile, []TemplateStats{})
+}
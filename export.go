@@ -0,0 +1,422 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Test Export ---
+
+// Answer key formats. Inline and circled keys make a teacher copy: the
+// answers are shown with each question instead of on a separate page.
+const (
+	AnswerKeyList    = "list"    // numbered list on a page after the test
+	AnswerKeyTable   = "table"   // table after the test, five answers per row
+	AnswerKeyInline  = "inline"  // "정답: ③" under each question
+	AnswerKeyCircled = "circled" // the correct choice's marker filled in
+)
+
+// Editions made by ExportEditions from the same test.
+const (
+	EditionStudent = "student" // no answers, key or teacher appendix
+	EditionTeacher = "teacher" // correct choices bold and underlined in place
+)
+
+// filledChoiceMarkers mark the correct choice in circled teacher copies.
+const filledChoiceMarkers = "❶❷❸❹❺"
+
+func validateAnswerKeyFormat(format string) error {
+	switch format {
+	case "", AnswerKeyList, AnswerKeyTable, AnswerKeyInline, AnswerKeyCircled:
+		return nil
+	}
+	return fmt.Errorf("지원하지 않는 정답 형식입니다: '%s'", format)
+}
+
+// answerKeyEntry renders a question's key entry with its teacher note.
+func answerKeyEntry(q Question) string {
+	entry := answerMarker(q.Answer)
+	if q.AnswerText != "" {
+		entry = q.AnswerText
+	}
+	if q.Note != "" {
+		entry += "  [" + q.Note + "]"
+	}
+	return entry
+}
+
+// ExportOptions controls the layout of exported documents.
+type ExportOptions struct {
+	Title  string   `json:"title"`
+	Header []string `json:"header"` // lines printed above the title
+	Font   string   `json:"font"`   // font family, "" for the default
+	Layout string   `json:"layout"` // ExportLayout ID, "" for the standard layout
+	// AnswerKey is one of the AnswerKey* formats, "" for AnswerKeyList.
+	AnswerKey string `json:"answerKey"`
+	// Edition is EditionStudent or EditionTeacher for the split editions of
+	// ExportEditions, "" for a single document with the answer key.
+	Edition string `json:"edition"`
+	// Points are printed after each question title together with the total,
+	// keyed by question number. nil prints no points.
+	Points map[int]float64 `json:"points,omitempty"`
+
+	// FontData is the font file to embed in DOCX exports.
+	FontData []byte `json:"-"`
+
+	// Metadata traces the questions back to their generation run. It is
+	// stored as custom document properties, never printed.
+	Metadata *ExportMetadata `json:"metadata,omitempty"`
+	// WordNotes are printed after the answer key; nil prints none.
+	WordNotes []WordNote `json:"wordNotes,omitempty"`
+	// WordBank is printed in a box above the questions; nil prints none.
+	WordBank []string `json:"wordBank,omitempty"`
+	// BlankStyle and FirstLetterHint restyle the blanks of printed
+	// documents (see styleBlanks). Stored tests always keep blankMarker, so
+	// the styles never reach parsing or validation.
+	BlankStyle      string `json:"blankStyle,omitempty"`
+	FirstLetterHint bool   `json:"firstLetterHint,omitempty"`
+}
+
+// exportOptions builds the options for an export from the settings,
+// rendering the header template with the active class's variables.
+func (a *VocabApp) exportOptions(title string) (ExportOptions, error) {
+	opts := ExportOptions{Title: title}
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return opts, err
+	}
+	if strings.TrimSpace(settings.ExportHeader) != "" {
+		vars := classVars(settings.activeClass())
+		vars["Title"] = title
+		header, err := renderTemplateText(settings.ExportHeader, vars)
+		if err != nil {
+			return opts, fmt.Errorf("머리말 템플릿: %w", err)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(header), "\n") {
+			opts.Header = append(opts.Header, strings.TrimSpace(line))
+		}
+	}
+	opts.Font = settings.ExportFont.Family
+	opts.Layout = settings.ExportLayout
+	opts.AnswerKey = settings.AnswerKeyFormat
+	opts.BlankStyle = settings.Generation.BlankStyle
+	opts.FirstLetterHint = settings.Generation.FirstLetterHint
+	if settings.ExportFont.Embed && settings.ExportFont.Path != "" {
+		data, err := os.ReadFile(settings.ExportFont.Path)
+		if err != nil {
+			return opts, fmt.Errorf("글꼴 파일 읽기 오류: %w", err)
+		}
+		opts.FontData = data
+	}
+	return opts, nil
+}
+
+// testExportOptions adds what is specific to the test being exported: its
+// metadata and, if enabled, the points of each question.
+func (a *VocabApp) testExportOptions(title string, content string) (ExportOptions, error) {
+	opts, err := a.exportOptions(title)
+	if err != nil {
+		return opts, err
+	}
+	opts.Metadata = a.exportMetadata(content)
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return opts, err
+	}
+	if settings.Points.Enabled {
+		opts.Points = a.questionPoints(content, settings.Points)
+	}
+	if settings.WordNotes.TeacherEdition {
+		opts.WordNotes = a.testWordNotes(content)
+	}
+	if settings.WordBank.Enabled {
+		opts.WordBank = a.testWordBank(content, settings.WordBank)
+	}
+	return opts, nil
+}
+
+// newExportDocx starts a document with the export's font and layout.
+func newExportDocx(opts ExportOptions) *docxBuilder {
+	doc := newDocxBuilder()
+	if opts.Font != "" {
+		doc.font = opts.Font
+	}
+	if opts.FontData != nil {
+		doc.EmbedFont(opts.FontData)
+	}
+	if layout, ok := findExportLayout(opts.Layout); ok {
+		layout.apply(doc)
+	}
+	return doc
+}
+
+// renderTestDocx lays out the generated test as a Word document.
+func renderTestDocx(content string, opts ExportOptions) ([]byte, error) {
+	return layoutTestDocx(content, opts).Bytes()
+}
+
+// layoutTestDocx builds the test document. Output that cannot be parsed into
+// questions is written line by line so nothing is lost.
+func layoutTestDocx(content string, opts ExportOptions) *docxBuilder {
+	doc := newExportDocx(opts)
+	for _, line := range opts.Header {
+		doc.Text(line)
+	}
+	if opts.Title != "" {
+		doc.Title(opts.Title)
+	}
+	if m := opts.Metadata; m != nil {
+		doc.SetProperty("VocabRunID", m.RunID)
+		doc.SetProperty("VocabModel", m.Model)
+		doc.SetProperty("VocabQuestionType", m.QuestionType)
+		doc.SetProperty("VocabGeneratedAt", m.GeneratedAt.Format(time.RFC3339))
+		if questions, err := json.Marshal(m.Questions); err == nil {
+			doc.SetProperty("VocabQuestions", string(questions))
+		}
+		if s := m.Stamp; s != nil {
+			doc.SetProperty("VocabProvider", s.Provider)
+			doc.SetProperty("VocabAppVersion", s.AppVersion)
+			doc.SetProperty("VocabPromptVersion", s.PromptVersion+" "+s.PromptHash)
+			if options, err := json.Marshal(s.Options); err == nil {
+				doc.SetProperty("VocabOptions", string(options))
+			}
+		}
+	}
+
+	out := parseOutput(content)
+	styleQuestionBlanks(out.Questions, opts.BlankStyle, opts.FirstLetterHint)
+	if len(out.Questions) == 0 {
+		for _, line := range strings.Split(content, "\n") {
+			doc.Text(strings.TrimRight(line, "\r"))
+		}
+		return doc
+	}
+
+	if opts.Points != nil {
+		doc.Text(fmt.Sprintf("총 %d문항 / 총점 %s점", len(out.Questions), formatPoints(totalPoints(opts.Points))))
+		doc.Blank()
+	}
+	if len(opts.WordBank) > 0 {
+		layoutWordBank(doc, opts.WordBank)
+	}
+	for _, q := range out.Questions {
+		title := fmt.Sprintf("%d. %s", q.Number, q.Title)
+		if p, ok := opts.Points[q.Number]; ok {
+			title += fmt.Sprintf(" [%s점]", formatPoints(p))
+		}
+		doc.Paragraph(docxRun{Text: title, Bold: true})
+		for _, line := range q.Body {
+			doc.Text(line)
+		}
+		markers := []rune(choiceMarkers)
+		for i, c := range q.Choices {
+			if i >= len(markers) {
+				continue
+			}
+			correct := i == q.Answer-1 && opts.Edition != EditionStudent
+			switch {
+			case correct && opts.Edition == EditionTeacher:
+				doc.Paragraph(docxRun{Text: fmt.Sprintf("%c %s", markers[i], c), Bold: true, Underline: true})
+			case correct && opts.AnswerKey == AnswerKeyCircled:
+				doc.Text(fmt.Sprintf("%c %s", []rune(filledChoiceMarkers)[i], c))
+			default:
+				doc.Text(fmt.Sprintf("%c %s", markers[i], c))
+			}
+		}
+		if opts.AnswerKey == AnswerKeyInline && out.HasKey && opts.Edition != EditionStudent {
+			doc.Paragraph(docxRun{Text: "정답: " + answerKeyEntry(q), Bold: true})
+		}
+		doc.Blank()
+	}
+
+	if opts.Edition == EditionStudent {
+		return doc
+	}
+	if out.HasKey {
+		switch opts.AnswerKey {
+		case AnswerKeyInline, AnswerKeyCircled:
+		case AnswerKeyTable:
+			doc.PageBreak()
+			doc.Heading("[정답]")
+			var rows [][]string
+			for i, q := range out.Questions {
+				if i%5 == 0 {
+					rows = append(rows, nil)
+				}
+				rows[len(rows)-1] = append(rows[len(rows)-1], fmt.Sprintf("%d. %s", q.Number, answerKeyEntry(q)))
+			}
+			doc.Table(rows)
+			doc.Blank()
+		default:
+			doc.PageBreak()
+			doc.Heading("[정답]")
+			for _, q := range out.Questions {
+				doc.Text(fmt.Sprintf("%d. %s", q.Number, answerKeyEntry(q)))
+			}
+		}
+	}
+	if len(opts.WordNotes) > 0 {
+		doc.PageBreak()
+		doc.Heading(wordNotesHeader)
+		for _, n := range opts.WordNotes {
+			doc.Paragraph(docxRun{Text: n.Word, Bold: true}, docxRun{Text: ": " + n.Note})
+		}
+	}
+	if out.Appendix != "" {
+		doc.PageBreak()
+		doc.Heading(translationHeader)
+		for _, line := range strings.Split(out.Appendix, "\n") {
+			doc.Text(strings.TrimRight(line, "\r"))
+		}
+	}
+	return doc
+}
+
+// answerMarker renders a 1-based answer as its circled marker, or "?" when
+// the answer key had no entry for the question.
+func answerMarker(answer int) string {
+	markers := []rune(choiceMarkers)
+	if answer < 1 || answer > len(markers) {
+		return "?"
+	}
+	return string(markers[answer-1])
+}
+
+// exportTitle derives a document title from a suggested filename.
+func exportTitle(filename string) string {
+	return strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+}
+
+// renderTestJSON exports the parsed questions together with their metadata.
+func renderTestJSON(content string, opts ExportOptions) ([]byte, error) {
+	out := parseOutput(content)
+	return json.MarshalIndent(struct {
+		Title     string          `json:"title"`
+		Header    []string        `json:"header,omitempty"`
+		Questions []Question      `json:"questions"`
+		Appendix  string          `json:"appendix,omitempty"`
+		Points    map[int]float64 `json:"points,omitempty"`
+		Metadata  *ExportMetadata `json:"metadata,omitempty"`
+		WordBank  []string        `json:"wordBank,omitempty"`
+	}{opts.Title, opts.Header, out.Questions, out.Appendix, opts.Points, opts.Metadata, opts.WordBank}, "", "  ")
+}
+
+// renderExportFile renders the test in one of the supported file formats and
+// returns the bytes together with the file extension and MIME type.
+func renderExportFile(format string, content string, opts ExportOptions) ([]byte, string, string, error) {
+	switch format {
+	case "txt", "":
+		return []byte(styleBlanks(content, opts.BlankStyle, opts.FirstLetterHint)), ".txt", "text/plain; charset=utf-8", nil
+	case "docx":
+		data, err := renderTestDocx(content, opts)
+		return data, ".docx", mimeDocx, err
+	case "json":
+		data, err := renderTestJSON(content, opts)
+		return data, ".json", "application/json", err
+	default:
+		return nil, "", "", fmt.Errorf("지원하지 않는 형식입니다: '%s'", format)
+	}
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExportDocx saves the test as a Word document. answerKey overrides the
+// answer key format from the settings for this export ("" keeps it).
+func (a *VocabApp) ExportDocx(contentToSave string, suggestedFilename string, answerKey string) (string, error) {
+	if err := validateAnswerKeyFormat(answerKey); err != nil {
+		return "", err
+	}
+	name, err := a.planExportName(contentToSave, "", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	filePath, auto, err := a.savePath(&name, ".docx", runtime.SaveDialogOptions{
+		Title: "Word 문서로 저장",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Word 문서 (*.docx)",
+				Pattern:     "*.docx",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := a.testExportOptions(exportTitle(filePath), contentToSave)
+	if err != nil {
+		return "", err
+	}
+	if answerKey != "" {
+		opts.AnswerKey = answerKey
+	}
+	content, err := renderTestDocx(contentToSave, opts)
+	if err != nil {
+		return "", fmt.Errorf("문서 생성 오류: %w", err)
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.exportSaved(name, filePath, auto)
+	return fmt.Sprintf("저장 완료: %s", filepath.Base(filePath)), nil
+}
+
+// ExportEditions saves a student edition without answers and, next to it, a
+// teacher edition ("_교사용") with the correct choices marked in place, both
+// laid out from the same test.
+func (a *VocabApp) ExportEditions(contentToSave string, suggestedFilename string) (string, error) {
+	if len(parseOutput(contentToSave).Questions) == 0 {
+		return "", fmt.Errorf("내보낼 문제를 찾을 수 없습니다")
+	}
+	name, err := a.planExportName(contentToSave, "", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	studentPath, auto, err := a.savePath(&name, ".docx", runtime.SaveDialogOptions{
+		Title: "학생용/교사용 Word 문서로 저장",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Word 문서 (*.docx)",
+				Pattern:     "*.docx",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	teacherPath := strings.TrimSuffix(studentPath, filepath.Ext(studentPath)) + "_교사용.docx"
+
+	opts, err := a.testExportOptions(exportTitle(studentPath), contentToSave)
+	if err != nil {
+		return "", err
+	}
+	// The metadata names the word each question tests, so only the teacher's
+	// copy carries it.
+	metadata := opts.Metadata
+	for _, edition := range []struct{ name, path string }{{EditionStudent, studentPath}, {EditionTeacher, teacherPath}} {
+		opts.Edition = edition.name
+		opts.Metadata = metadata
+		if edition.name == EditionStudent {
+			opts.Metadata = nil
+		}
+		content, err := renderTestDocx(contentToSave, opts)
+		if err != nil {
+			return "", fmt.Errorf("문서 생성 오류: %w", err)
+		}
+		if err := os.WriteFile(edition.path, content, 0644); err != nil {
+			return "", fmt.Errorf("파일 저장 오류: %w", err)
+		}
+	}
+	a.exportSaved(name, studentPath, auto)
+	return fmt.Sprintf("저장 완료: %s, %s", filepath.Base(studentPath), filepath.Base(teacherPath)), nil
+}
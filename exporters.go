@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Exporters & Plugins ---
+
+// pluginsDir holds exporter plugin manifests (*.json) in the data directory.
+const pluginsDir = "plugins"
+
+// pluginTimeout bounds a single run of an exporter plugin.
+const pluginTimeout = 60 * time.Second
+
+// Exporter renders a test into one file format.
+type Exporter interface {
+	Info() ExporterInfo
+	Export(content string, opts ExportOptions) ([]byte, error)
+}
+
+// ExporterInfo describes an exporter for the export menu.
+type ExporterInfo struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"` // with the dot, e.g. ".docx"
+	MIMEType  string `json:"mimeType"`
+	Plugin    bool   `json:"plugin"`
+}
+
+// builtinExporter wraps one of the formats of renderExportFile.
+type builtinExporter struct {
+	info ExporterInfo
+}
+
+func (e builtinExporter) Info() ExporterInfo { return e.info }
+
+func (e builtinExporter) Export(content string, opts ExportOptions) ([]byte, error) {
+	data, _, _, err := renderExportFile(e.info.ID, content, opts)
+	return data, err
+}
+
+var builtinExporters = []Exporter{
+	builtinExporter{ExporterInfo{ID: "txt", Name: "텍스트", Extension: ".txt", MIMEType: "text/plain; charset=utf-8"}},
+	builtinExporter{ExporterInfo{ID: "docx", Name: "Word 문서", Extension: ".docx", MIMEType: mimeDocx}},
+	builtinExporter{ExporterInfo{ID: "json", Name: "JSON", Extension: ".json", MIMEType: "application/json"}},
+}
+
+// pluginManifest describes an external exporter. The command is run with
+// args in the manifest's directory; it gets a pluginRequest as JSON on stdin
+// and writes the finished file to stdout.
+type pluginManifest struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Extension string   `json:"extension"`
+	MIMEType  string   `json:"mimeType"`
+	Command   string   `json:"command"`
+	Args      []string `json:"args"`
+
+	dir  string
+	path string // the manifest file
+}
+
+// trustHash is the hash a plugin is approved with: the manifest followed by
+// the files in the plugins folder that its command and args name, so editing
+// the manifest or its script needs a new approval.
+func (p pluginManifest) trustHash() (string, error) {
+	files := []string{p.path}
+	for _, name := range append([]string{p.Command}, p.Args...) {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(p.dir, name)
+		}
+		if info, err := os.Stat(name); err == nil && info.Mode().IsRegular() {
+			files = append(files, name)
+		}
+	}
+	return hashFiles(files...)
+}
+
+// pluginRequest is what an exporter plugin reads from stdin: the raw test,
+// the same test as the JSON export, and the export options.
+type pluginRequest struct {
+	Content string          `json:"content"`
+	Test    json.RawMessage `json:"test"`
+	Title   string          `json:"title"`
+	Header  []string        `json:"header,omitempty"`
+	Edition string          `json:"edition,omitempty"`
+	Layout  string          `json:"layout,omitempty"`
+}
+
+func (p pluginManifest) Info() ExporterInfo {
+	mime := p.MIMEType
+	if mime == "" {
+		mime = "application/octet-stream"
+	}
+	return ExporterInfo{ID: p.ID, Name: p.Name, Extension: p.Extension, MIMEType: mime, Plugin: true}
+}
+
+func (p pluginManifest) Export(content string, opts ExportOptions) ([]byte, error) {
+	test, err := renderTestJSON(content, opts)
+	if err != nil {
+		return nil, err
+	}
+	input, err := json.Marshal(pluginRequest{
+		Content: content,
+		Test:    test,
+		Title:   opts.Title,
+		Header:  opts.Header,
+		Edition: opts.Edition,
+		Layout:  opts.Layout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	command := p.Command
+	if !filepath.IsAbs(command) && strings.ContainsAny(command, `/\`) {
+		command = filepath.Join(p.dir, command)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command, p.Args...)
+	cmd.Dir = p.dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("'%s' 플러그인 오류: %s", p.Name, truncateRunes(msg, 300))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("'%s' 플러그인이 빈 파일을 반환했습니다", p.Name)
+	}
+	return stdout.Bytes(), nil
+}
+
+func validatePluginManifest(p pluginManifest) error {
+	if p.ID == "" || p.Name == "" || p.Command == "" {
+		return fmt.Errorf("플러그인에는 id, name, command가 필요합니다")
+	}
+	if !strings.HasPrefix(p.Extension, ".") || strings.ContainsAny(p.Extension, `/\ `) {
+		return fmt.Errorf("플러그인 확장자가 올바르지 않습니다: '%s'", p.Extension)
+	}
+	for _, e := range builtinExporters {
+		if e.Info().ID == p.ID {
+			return fmt.Errorf("기본 형식과 같은 플러그인 ID입니다: '%s'", p.ID)
+		}
+	}
+	return nil
+}
+
+// loadPlugins reads the plugin manifests. Broken manifests are reported and
+// skipped so one bad plugin does not hide the others; manifests the user has
+// not approved on this computer (see TrustPlugin) are returned apart and
+// never run.
+func loadPlugins() ([]Exporter, []pluginManifest, []string, error) {
+	base, err := dataDir()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dir := filepath.Join(base, pluginsDir)
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var plugins []Exporter
+	var untrusted []pluginManifest
+	var problems []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+			continue
+		}
+		var p pluginManifest
+		if err := json.Unmarshal(data, &p); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+			continue
+		}
+		if err := validatePluginManifest(p); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+			continue
+		}
+		p.dir, p.path = dir, path
+		hash, err := p.trustHash()
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+			continue
+		}
+		trusted, err := isTrusted(trustPlugin, path, hash)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if !trusted {
+			untrusted = append(untrusted, p)
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, untrusted, problems, nil
+}
+
+// findExporter looks up a built-in exporter or plugin by ID.
+func findExporter(id string) (Exporter, error) {
+	for _, e := range builtinExporters {
+		if e.Info().ID == id {
+			return e, nil
+		}
+	}
+	plugins, untrusted, _, err := loadPlugins()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range plugins {
+		if e.Info().ID == id {
+			return e, nil
+		}
+	}
+	for _, p := range untrusted {
+		if p.ID == id {
+			return nil, fmt.Errorf("'%s' 플러그인을 먼저 승인하세요", p.Name)
+		}
+	}
+	return nil, fmt.Errorf("지원하지 않는 형식입니다: '%s'", id)
+}
+
+// renderExport renders the test with a built-in exporter or a plugin and
+// returns the bytes together with the file extension and MIME type.
+func renderExport(format string, content string, opts ExportOptions) ([]byte, string, string, error) {
+	if format == "" {
+		format = "txt"
+	}
+	e, err := findExporter(format)
+	if err != nil {
+		return nil, "", "", err
+	}
+	data, err := e.Export(content, opts)
+	info := e.Info()
+	return data, info.Extension, info.MIMEType, err
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExporterList is the export formats offered to the user, with the plugins
+// waiting for approval and the manifests that could not be loaded.
+type ExporterList struct {
+	Exporters []ExporterInfo `json:"exporters"`
+	Untrusted []ExporterInfo `json:"untrusted"`
+	Problems  []string       `json:"problems"`
+}
+
+// GetExporters lists the built-in export formats and the installed plugins.
+func (a *VocabApp) GetExporters() (ExporterList, error) {
+	plugins, untrusted, problems, err := loadPlugins()
+	if err != nil {
+		return ExporterList{}, err
+	}
+	list := ExporterList{Untrusted: []ExporterInfo{}, Problems: problems}
+	for _, p := range untrusted {
+		list.Untrusted = append(list.Untrusted, p.Info())
+	}
+	if list.Problems == nil {
+		list.Problems = []string{}
+	}
+	for _, e := range append(append([]Exporter{}, builtinExporters...), plugins...) {
+		list.Exporters = append(list.Exporters, e.Info())
+	}
+	return list, nil
+}
+
+// TrustPlugin approves the plugin with the given ID (see
+// ExporterList.Untrusted) on this computer, as it is now. Changing its
+// manifest or script later needs a new approval.
+func (a *VocabApp) TrustPlugin(id string) error {
+	_, untrusted, _, err := loadPlugins()
+	if err != nil {
+		return err
+	}
+	for _, p := range untrusted {
+		if p.ID != id {
+			continue
+		}
+		hash, err := p.trustHash()
+		if err != nil {
+			return err
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return trustProgram(trustPlugin, p.path, hash)
+	}
+	return fmt.Errorf("승인할 플러그인을 찾을 수 없습니다: '%s'", id)
+}
+
+// OpenPluginsFolder shows the folder exporter plugin manifests go in.
+func (a *VocabApp) OpenPluginsFolder() error {
+	base, err := dataDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(base, pluginsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("플러그인 폴더 생성 오류: %w", err)
+	}
+	return a.OpenInDefaultApp(dir)
+}
+
+// ExportWith saves the test with the given exporter (see GetExporters).
+func (a *VocabApp) ExportWith(exporterID string, contentToSave string, suggestedFilename string) (string, error) {
+	e, err := findExporter(exporterID)
+	if err != nil {
+		return "", err
+	}
+	info := e.Info()
+	name, err := a.planExportName(contentToSave, "", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	filePath, auto, err := a.savePath(&name, info.Extension, runtime.SaveDialogOptions{
+		Title: info.Name + "(으)로 저장",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: fmt.Sprintf("%s (*%s)", info.Name, info.Extension),
+				Pattern:     "*" + info.Extension,
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	opts, err := a.testExportOptions(exportTitle(filePath), contentToSave)
+	if err != nil {
+		return "", err
+	}
+	data, err := e.Export(contentToSave, opts)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.exportSaved(name, filePath, auto)
+	return fmt.Sprintf("저장 완료: %s", filepath.Base(filePath)), nil
+}
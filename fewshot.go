@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Few-Shot Example Sets ---
+
+const fewShotFile = "fewshot.json"
+
+// defaultFewShotBudget is the token budget of injected examples when the
+// settings do not set one.
+const defaultFewShotBudget = 1500
+
+// FewShotSet is a reusable set of example questions for one question type.
+// Each example is one complete question block in the output format.
+type FewShotSet struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	QuestionType string    `json:"questionType"`
+	Tags         []string  `json:"tags"`
+	Examples     []string  `json:"examples"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func loadFewShotSets() ([]FewShotSet, error) {
+	var sets []FewShotSet
+	return sets, loadJSON(fewShotFile, &sets)
+}
+
+// estimateTokens approximates the token count of text: about four characters
+// per token for ASCII, one token per character for Hangul and other scripts.
+func estimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < 128 {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// trimExamples keeps examples in order while they fit in the token budget.
+func trimExamples(examples []string, budget int) []string {
+	var kept []string
+	used := 0
+	for _, ex := range examples {
+		cost := estimateTokens(ex)
+		if used+cost > budget {
+			break
+		}
+		kept = append(kept, ex)
+		used += cost
+	}
+	return kept
+}
+
+// fewShotSection lists the examples for the system prompt.
+func fewShotSection(examples []string) string {
+	if len(examples) == 0 {
+		return ""
+	}
+	lines := []string{
+		"### Example Questions",
+		"The following are well-made questions of this type. Match their quality and format, but write new content for the given vocabulary.",
+	}
+	for i, ex := range examples {
+		lines = append(lines, "", fmt.Sprintf("[Example %d]", i+1), strings.TrimSpace(ex))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fewShotExamples returns the examples of the set selected for the question
+// type, trimmed to the budget. Must be called with a.mu held.
+func fewShotExamples(g GenerationSettings, questionType string) ([]string, error) {
+	id := g.FewShotSets[questionType]
+	if id == "" {
+		return nil, nil
+	}
+	sets, err := loadFewShotSets()
+	if err != nil {
+		return nil, err
+	}
+	budget := g.FewShotBudget
+	if budget == 0 {
+		budget = defaultFewShotBudget
+	}
+	for _, s := range sets {
+		if s.ID == id && s.QuestionType == questionType {
+			return trimExamples(s.Examples, budget), nil
+		}
+	}
+	return nil, nil
+}
+
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	kept := []string{}
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t != "" && !seen[strings.ToLower(t)] {
+			seen[strings.ToLower(t)] = true
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// --- Go functions callable from Javascript ---
+
+// ListFewShotSets returns the example sets of a question type ("" for all),
+// optionally only those carrying tag.
+func (a *VocabApp) ListFewShotSets(questionType string, tag string) ([]FewShotSet, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	sets, err := loadFewShotSets()
+	if err != nil {
+		return nil, err
+	}
+	result := []FewShotSet{}
+	for _, s := range sets {
+		if questionType != "" && s.QuestionType != questionType {
+			continue
+		}
+		if tag != "" {
+			found := false
+			for _, t := range s.Tags {
+				found = found || strings.EqualFold(t, tag)
+			}
+			if !found {
+				continue
+			}
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// SaveFewShotSet creates a set (empty ID) or replaces the one with the same
+// ID.
+func (a *VocabApp) SaveFewShotSet(s FewShotSet) (FewShotSet, error) {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return FewShotSet{}, fmt.Errorf("예시 세트 이름을 입력하세요")
+	}
+	if _, ok := defaultQuestionTitles[s.QuestionType]; !ok {
+		return FewShotSet{}, fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", s.QuestionType)
+	}
+	var examples []string
+	for _, ex := range s.Examples {
+		if ex = strings.TrimSpace(ex); ex != "" {
+			examples = append(examples, ex)
+		}
+	}
+	if len(examples) == 0 {
+		return FewShotSet{}, fmt.Errorf("예시 문제를 하나 이상 입력하세요")
+	}
+	s.Examples = examples
+	s.Tags = normalizeTags(s.Tags)
+	s.UpdatedAt = time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	sets, err := loadFewShotSets()
+	if err != nil {
+		return FewShotSet{}, err
+	}
+	if s.ID == "" {
+		s.ID = newID()
+		s.CreatedAt = s.UpdatedAt
+		sets = append(sets, s)
+		return s, saveJSON(fewShotFile, sets)
+	}
+	for i := range sets {
+		if sets[i].ID == s.ID {
+			s.CreatedAt = sets[i].CreatedAt
+			sets[i] = s
+			return s, saveJSON(fewShotFile, sets)
+		}
+	}
+	return FewShotSet{}, fmt.Errorf("예시 세트를 찾을 수 없습니다")
+}
+
+func (a *VocabApp) DeleteFewShotSet(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	sets, err := loadFewShotSets()
+	if err != nil {
+		return err
+	}
+	kept := sets[:0]
+	for _, s := range sets {
+		if s.ID != id {
+			kept = append(kept, s)
+		}
+	}
+	return saveJSON(fewShotFile, kept)
+}
+
+// SelectFewShotSet chooses the example set Generate injects for a question
+// type; an empty id goes back to zero-shot.
+func (a *VocabApp) SelectFewShotSet(questionType string, id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		delete(settings.Generation.FewShotSets, questionType)
+		return saveJSON(settingsFile, settings)
+	}
+	sets, err := loadFewShotSets()
+	if err != nil {
+		return err
+	}
+	for _, s := range sets {
+		if s.ID == id && s.QuestionType == questionType {
+			if settings.Generation.FewShotSets == nil {
+				settings.Generation.FewShotSets = map[string]string{}
+			}
+			settings.Generation.FewShotSets[questionType] = id
+			return saveJSON(settingsFile, settings)
+		}
+	}
+	return fmt.Errorf("'%s' 유형의 예시 세트를 찾을 수 없습니다", questionType)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Export File Naming ---
+
+// exportVersionsFile remembers the last version number used per file name,
+// so "{class}_{date}_{type}_v{n}" counts up instead of repeating v1.
+const exportVersionsFile = "export-versions.json"
+
+var filenamePlaceholderRe = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// filenamePlaceholders are the names a FilenameTemplate may use.
+var filenamePlaceholders = map[string]bool{"class": true, "date": true, "type": true, "title": true, "n": true}
+
+// invalidFilenameChars are not allowed in file names on Windows.
+var invalidFilenameChars = strings.NewReplacer(`/`, "_", `\`, "_", ":", "_", "*", "_", "?", "_", `"`, "_", "<", "_", ">", "_", "|", "_")
+
+func validateFilenameTemplate(tmpl string) error {
+	for _, m := range filenamePlaceholderRe.FindAllStringSubmatch(tmpl, -1) {
+		if !filenamePlaceholders[m[1]] {
+			return fmt.Errorf("파일 이름 형식에 알 수 없는 항목이 있습니다: {%s}", m[1])
+		}
+	}
+	return nil
+}
+
+// exportName is a planned file name (without extension). Key and Version are
+// set when the template numbers versions; commitExportName records them once
+// the file has actually been written.
+type exportName struct {
+	Title   string
+	Key     string
+	Version int
+}
+
+func renderFilename(tmpl string, vars map[string]string) string {
+	name := filenamePlaceholderRe.ReplaceAllStringFunc(tmpl, func(m string) string {
+		return vars[m[1:len(m)-1]]
+	})
+	return strings.Trim(strings.TrimSpace(invalidFilenameChars.Replace(name)), "._")
+}
+
+// planExportName applies the configured file name template to an export.
+// questionType may be empty, in which case it comes from the generation run
+// behind content. Without a template the suggested name is used as is.
+func (a *VocabApp) planExportName(content string, questionType string, suggestedFilename string) (exportName, error) {
+	title := exportTitle(suggestedFilename)
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return exportName{}, err
+	}
+	tmpl := strings.TrimSpace(settings.FilenameTemplate)
+	if tmpl == "" {
+		return exportName{Title: title}, nil
+	}
+	if questionType == "" {
+		if meta := a.exportMetadata(content); meta != nil {
+			questionType = meta.QuestionType
+		}
+	}
+	vars := map[string]string{
+		"class": settings.activeClass().Name,
+		"date":  time.Now().Format("20060102"),
+		"type":  questionType,
+		"title": title,
+	}
+	if !strings.Contains(tmpl, "{n}") {
+		if name := renderFilename(tmpl, vars); name != "" {
+			title = name
+		}
+		return exportName{Title: title}, nil
+	}
+
+	vars["n"] = "{n}"
+	key := renderFilename(tmpl, vars)
+	a.mu.Lock()
+	versions := map[string]int{}
+	err = loadJSON(exportVersionsFile, &versions)
+	a.mu.Unlock()
+	if err != nil {
+		return exportName{}, err
+	}
+	n := versions[key] + 1
+	return exportName{Title: strings.ReplaceAll(key, "{n}", strconv.Itoa(n)), Key: key, Version: n}, nil
+}
+
+// commitExportName records the version of a file that was saved, so the next
+// export gets the next number.
+func (a *VocabApp) commitExportName(name exportName) {
+	if name.Key == "" {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	versions := map[string]int{}
+	if err := loadJSON(exportVersionsFile, &versions); err != nil {
+		return
+	}
+	if name.Version > versions[name.Key] {
+		versions[name.Key] = name.Version
+		saveJSON(exportVersionsFile, versions)
+	}
+}
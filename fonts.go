@@ -0,0 +1,311 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// --- System Fonts ---
+
+// FontInfo is an installed font family. Hangul is set when the font has
+// glyphs for the Hangul syllables, so Korean text will not fall back to
+// another font.
+type FontInfo struct {
+	Family string `json:"family"`
+	Path   string `json:"path"`
+	Hangul bool   `json:"hangul"`
+	// Embeddable fonts are single TrueType/OpenType files that can be
+	// embedded in DOCX exports (font collections cannot).
+	Embeddable bool `json:"embeddable"`
+}
+
+// ExportFont is the font chosen for exports. Embed copies the font file into
+// DOCX files so they print correctly on school computers without the font.
+type ExportFont struct {
+	Family string `json:"family"`
+	Path   string `json:"path"`
+	Embed  bool   `json:"embed"`
+}
+
+func validateExportFont(f ExportFont) error {
+	if !f.Embed {
+		return nil
+	}
+	if f.Path == "" {
+		return fmt.Errorf("포함할 글꼴 파일이 지정되지 않았습니다")
+	}
+	if strings.EqualFold(filepath.Ext(f.Path), ".ttc") {
+		return fmt.Errorf("글꼴 모음(.ttc)은 문서에 포함할 수 없습니다: %s", f.Family)
+	}
+	if _, err := os.Stat(f.Path); err != nil {
+		return fmt.Errorf("글꼴 파일을 찾을 수 없습니다: %s", f.Path)
+	}
+	return nil
+}
+
+func fontDirs() []string {
+	home, _ := os.UserHomeDir()
+	switch goruntime.GOOS {
+	case "windows":
+		return []string{
+			filepath.Join(os.Getenv("WINDIR"), "Fonts"),
+			filepath.Join(os.Getenv("LOCALAPPDATA"), "Microsoft", "Windows", "Fonts"),
+		}
+	case "darwin":
+		return []string{"/System/Library/Fonts", "/Library/Fonts", filepath.Join(home, "Library", "Fonts")}
+	default:
+		return []string{"/usr/share/fonts", "/usr/local/share/fonts", filepath.Join(home, ".local", "share", "fonts"), filepath.Join(home, ".fonts")}
+	}
+}
+
+// sfntTables maps table tags to their bytes for the font at offset in data.
+func sfntTables(data []byte, offset int) (map[string][]byte, error) {
+	if len(data) < offset+12 {
+		return nil, fmt.Errorf("font too short")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[offset+4:]))
+	tables := map[string][]byte{}
+	for i := 0; i < numTables; i++ {
+		rec := offset + 12 + 16*i
+		if len(data) < rec+16 {
+			return nil, fmt.Errorf("truncated table directory")
+		}
+		start := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if start < 0 || length < 0 || start+length > len(data) {
+			return nil, fmt.Errorf("table out of range")
+		}
+		tables[string(data[rec:rec+4])] = data[start : start+length]
+	}
+	return tables, nil
+}
+
+// fontFamily reads the family name (name ID 1), preferring the Korean name
+// Windows shows (e.g. 함초롬바탕), then the English one.
+func fontFamily(name []byte) string {
+	if len(name) < 6 {
+		return ""
+	}
+	count := int(binary.BigEndian.Uint16(name[2:]))
+	strOffset := int(binary.BigEndian.Uint16(name[4:]))
+	best, bestRank := "", 0
+	for i := 0; i < count; i++ {
+		rec := 6 + 12*i
+		if len(name) < rec+12 {
+			break
+		}
+		platform := binary.BigEndian.Uint16(name[rec:])
+		lang := binary.BigEndian.Uint16(name[rec+4:])
+		nameID := binary.BigEndian.Uint16(name[rec+6:])
+		length := int(binary.BigEndian.Uint16(name[rec+8:]))
+		off := strOffset + int(binary.BigEndian.Uint16(name[rec+10:]))
+		if nameID != 1 || off+length > len(name) {
+			continue
+		}
+		raw := name[off : off+length]
+		var s string
+		rank := 1
+		switch platform {
+		case 0, 3:
+			u := make([]uint16, len(raw)/2)
+			for j := range u {
+				u[j] = binary.BigEndian.Uint16(raw[2*j:])
+			}
+			s = string(utf16.Decode(u))
+			rank = 2
+			if lang == 0x0409 {
+				rank = 3
+			}
+			if lang == 0x0412 {
+				rank = 4
+			}
+		case 1:
+			s = string(raw)
+		default:
+			continue
+		}
+		if rank > bestRank && strings.TrimSpace(s) != "" {
+			best, bestRank = strings.TrimSpace(s), rank
+		}
+	}
+	return best
+}
+
+// cmapHasRune reports whether the font's Unicode cmap maps r to a glyph.
+func cmapHasRune(cmap []byte, r rune) bool {
+	if len(cmap) < 4 {
+		return false
+	}
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < n; i++ {
+		rec := 4 + 8*i
+		if len(cmap) < rec+8 {
+			return false
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		if !(platform == 0 || platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if off+2 > len(cmap) {
+			continue
+		}
+		sub := cmap[off:]
+		switch binary.BigEndian.Uint16(sub) {
+		case 4:
+			if cmapFormat4(sub, r) {
+				return true
+			}
+		case 12:
+			if cmapFormat12(sub, r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cmapFormat4(sub []byte, r rune) bool {
+	if r > 0xFFFF || len(sub) < 14 {
+		return false
+	}
+	segX2 := int(binary.BigEndian.Uint16(sub[6:]))
+	ends, starts, deltas, ranges := 14, 16+segX2, 16+2*segX2, 16+3*segX2
+	if len(sub) < ranges+segX2 {
+		return false
+	}
+	c := uint16(r)
+	for i := 0; i < segX2; i += 2 {
+		end := binary.BigEndian.Uint16(sub[ends+i:])
+		if end < c {
+			continue
+		}
+		start := binary.BigEndian.Uint16(sub[starts+i:])
+		if start > c {
+			return false
+		}
+		rangeOffset := int(binary.BigEndian.Uint16(sub[ranges+i:]))
+		if rangeOffset == 0 {
+			return c+binary.BigEndian.Uint16(sub[deltas+i:]) != 0
+		}
+		at := ranges + i + rangeOffset + 2*int(c-start)
+		return at+2 <= len(sub) && binary.BigEndian.Uint16(sub[at:]) != 0
+	}
+	return false
+}
+
+func cmapFormat12(sub []byte, r rune) bool {
+	if len(sub) < 16 {
+		return false
+	}
+	groups := int(binary.BigEndian.Uint32(sub[12:]))
+	for i := 0; i < groups; i++ {
+		g := 16 + 12*i
+		if len(sub) < g+12 {
+			return false
+		}
+		if uint32(r) >= binary.BigEndian.Uint32(sub[g:]) && uint32(r) <= binary.BigEndian.Uint32(sub[g+4:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// readFontInfo lists the fonts in a .ttf, .otf or .ttc file.
+func readFontInfo(path string) ([]FontInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 {
+		return nil, fmt.Errorf("font too short")
+	}
+	offsets := []int{0}
+	collection := string(data[:4]) == "ttcf"
+	if collection {
+		n := int(binary.BigEndian.Uint32(data[8:]))
+		offsets = nil
+		for i := 0; i < n && 16+4*i <= len(data); i++ {
+			offsets = append(offsets, int(binary.BigEndian.Uint32(data[12+4*i:])))
+		}
+	}
+	var fonts []FontInfo
+	for _, off := range offsets {
+		tables, err := sfntTables(data, off)
+		if err != nil {
+			return fonts, err
+		}
+		family := fontFamily(tables["name"])
+		if family == "" {
+			continue
+		}
+		fonts = append(fonts, FontInfo{
+			Family:     family,
+			Path:       path,
+			Hangul:     cmapHasRune(tables["cmap"], '가') && cmapHasRune(tables["cmap"], '힣'),
+			Embeddable: !collection,
+		})
+	}
+	return fonts, nil
+}
+
+// scanFonts lists the installed font families, one entry per family.
+// Regular-weight files are preferred as the path to embed.
+func scanFonts() []FontInfo {
+	byFamily := map[string]FontInfo{}
+	for _, dir := range fontDirs() {
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".ttf", ".otf", ".ttc":
+			default:
+				return nil
+			}
+			fonts, _ := readFontInfo(path)
+			for _, f := range fonts {
+				prev, seen := byFamily[f.Family]
+				if !seen || !prev.Embeddable && f.Embeddable || isRegularFontFile(path) && !isRegularFontFile(prev.Path) {
+					byFamily[f.Family] = f
+				}
+			}
+			return nil
+		})
+	}
+	fonts := make([]FontInfo, 0, len(byFamily))
+	for _, f := range byFamily {
+		fonts = append(fonts, f)
+	}
+	sort.Slice(fonts, func(i, j int) bool {
+		if fonts[i].Hangul != fonts[j].Hangul {
+			return fonts[i].Hangul
+		}
+		return fonts[i].Family < fonts[j].Family
+	})
+	return fonts
+}
+
+func isRegularFontFile(path string) bool {
+	base := strings.ToLower(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	for _, style := range []string{"bold", "italic", "light", "thin", "black", "medium", "semibold", "extra", "heavy", "oblique"} {
+		if strings.Contains(base, style) {
+			return false
+		}
+	}
+	return true
+}
+
+// --- Go functions callable from Javascript ---
+
+// ListFonts lists the installed fonts, those covering Hangul first.
+func (a *VocabApp) ListFonts() []FontInfo {
+	return scanFonts()
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Forbidden Words ---
+
+const maxForbiddenWords = 200
+
+const forbiddenRepairPrompt = `You are an expert English vocabulary test maker for Korean students.
+Some context sentences of this test use words that must not appear in them. Rewrite each listed sentence as a natural sentence that avoids the listed words (in any form) and still tests the same answer in the same way: keep every blank written as _______ and the same number of blanks, and keep the meaning and difficulty.
+Respond with JSON only: {"questions": [{"question": 1, "sentences": {"2": "rewritten sentence"}}]}. Sentence numbers are 1-based.`
+
+func validateForbiddenWords(words []string) error {
+	if len(words) > maxForbiddenWords {
+		return fmt.Errorf("금지어는 %d개까지 지정할 수 있습니다", maxForbiddenWords)
+	}
+	for _, w := range words {
+		if strings.TrimSpace(w) == "" {
+			return fmt.Errorf("빈 금지어가 있습니다")
+		}
+	}
+	return nil
+}
+
+// forbiddenWordsRule is the style rule listing the words the sentences must
+// not use.
+func forbiddenWordsRule(words []string, avoidTargets bool) string {
+	var parts []string
+	if len(words) > 0 {
+		parts = append(parts, fmt.Sprintf("FORBIDDEN WORDS: Never use these words, in any form, in sentences, definitions or choices other than as an answer: %s.", strings.Join(words, ", ")))
+	}
+	if avoidTargets {
+		parts = append(parts, "OTHER TARGETS: A question's sentences must not contain any other word from the vocabulary list, so no question gives away another's answer.")
+	}
+	return strings.Join(parts, " ")
+}
+
+// questionTargets returns the list words question qi tests: the word group
+// of a passage question (문장 삽입, 순서 배열), otherwise its answer.
+func questionTargets(questionType string, qi int, q Question, entries []VocabPair) []string {
+	var words []string
+	if questionType == "문장 삽입" || questionType == "순서 배열" {
+		for i := qi * passageWordsPerQuestion; i < (qi+1)*passageWordsPerQuestion && i < len(entries); i++ {
+			words = append(words, entries[i].Word)
+		}
+		return words
+	}
+	if answer := blankAnswer(q); answer != "" {
+		words = append(words, answer)
+	}
+	if e, ok := sourceEntry(q, entries); ok {
+		words = append(words, e.Word)
+	}
+	return words
+}
+
+// forbiddenInQuestion returns, per body line, the forbidden words the line
+// uses: the configured words and, with avoidTargets, the other list words.
+// The words the question tests (targets) are always allowed.
+func forbiddenInQuestion(q Question, targets []string, entries []VocabPair, forbidden []string, avoidTargets bool) map[int][]string {
+	words := append([]string{}, forbidden...)
+	if avoidTargets {
+		for _, e := range entries {
+			words = append(words, e.Word)
+		}
+	}
+	tested := func(w string) bool {
+		w = strings.ToLower(w)
+		for _, t := range targets {
+			t = strings.ToLower(t)
+			if w == t || isInflectionOf(w, t) || isInflectionOf(t, w) {
+				return true
+			}
+		}
+		return false
+	}
+	found := map[int][]string{}
+	for i, line := range q.Body {
+		if hasHangul(line) {
+			continue
+		}
+		for _, w := range words {
+			if w = strings.TrimSpace(w); w != "" && !tested(w) && usesWord(line, w) && !containsString(found[i], w) {
+				found[i] = append(found[i], w)
+			}
+		}
+	}
+	return found
+}
+
+// ForbiddenCheckReport is emitted as "generate:forbidden-checked".
+type ForbiddenCheckReport struct {
+	Checked    int               `json:"checked"`    // questions checked
+	Violations int               `json:"violations"` // sentences that used a forbidden word
+	Rewritten  []int             `json:"rewritten"`  // questions with sentences rewritten by the model
+	Unresolved []ValidationIssue `json:"unresolved"`
+}
+
+// checkForbidden finds sentences that use a forbidden word and has the
+// model rewrite them. Rewrites that still use one are rejected.
+func (a *VocabApp) checkForbidden(modelID string, output string, questionType string, entries []VocabPair, forbidden []string, avoidTargets bool) (string, ForbiddenCheckReport, error) {
+	var report ForbiddenCheckReport
+	out := parseOutput(output)
+	report.Checked = len(out.Questions)
+	targets := make([][]string, len(out.Questions))
+	for qi, q := range out.Questions {
+		targets[qi] = questionTargets(questionType, qi, q, entries)
+	}
+	found := map[int]map[int][]string{}
+	var request strings.Builder
+	for qi, q := range out.Questions {
+		lines := forbiddenInQuestion(q, targets[qi], entries, forbidden, avoidTargets)
+		if len(lines) == 0 {
+			continue
+		}
+		found[qi] = lines
+		report.Violations += len(lines)
+		fmt.Fprintf(&request, "Question %d (answer: %s)\n", q.Number, blankAnswer(q))
+		for li, line := range q.Body {
+			if words, ok := lines[li]; ok {
+				fmt.Fprintf(&request, "  %d: %s\n     (avoid: %s)\n", li+1, line, strings.Join(words, ", "))
+			}
+		}
+		request.WriteString("\n")
+	}
+	if len(found) == 0 {
+		return output, report, nil
+	}
+
+	var repair blankRepairResult
+	err := a.callChatGPTJSON(modelID, forbiddenRepairPrompt, request.String(), &repair)
+	if err == nil {
+		index := map[int]int{}
+		for i, q := range out.Questions {
+			index[q.Number] = i
+		}
+		for _, r := range repair.Questions {
+			qi, ok := index[r.Question]
+			if !ok {
+				continue
+			}
+			q := &out.Questions[qi]
+			changed := false
+			for key, sentence := range r.Sentences {
+				n, convErr := strconv.Atoi(key)
+				if convErr != nil || n < 1 || n > len(q.Body) {
+					continue
+				}
+				if _, bad := found[qi][n-1]; !bad || len(blankRe.FindAllString(sentence, -1)) != len(blankRe.FindAllString(q.Body[n-1], -1)) {
+					continue
+				}
+				old := q.Body[n-1]
+				q.Body[n-1] = blankRe.ReplaceAllString(strings.TrimSpace(sentence), blankMarker)
+				if _, still := forbiddenInQuestion(*q, targets[qi], entries, forbidden, avoidTargets)[n-1]; still {
+					q.Body[n-1] = old
+					continue
+				}
+				changed = true
+			}
+			if changed {
+				report.Rewritten = append(report.Rewritten, q.Number)
+			}
+		}
+	}
+
+	for qi, q := range out.Questions {
+		lines := forbiddenInQuestion(q, targets[qi], entries, forbidden, avoidTargets)
+		var numbers []int
+		for li := range lines {
+			numbers = append(numbers, li)
+		}
+		sort.Ints(numbers)
+		for _, li := range numbers {
+			report.Unresolved = append(report.Unresolved, ValidationIssue{
+				Question: q.Number,
+				Message:  fmt.Sprintf("%d번째 예문에 금지어가 있습니다: %s", li+1, strings.Join(lines[li], ", ")),
+			})
+		}
+	}
+	if len(report.Rewritten) == 0 {
+		return output, report, err
+	}
+	return formatOutput(out), report, err
+}
+
+// checkForbiddenAndReport runs checkForbidden for Generate. A failed
+// rewrite is logged and the violations are reported as unresolved.
+func (a *VocabApp) checkForbiddenAndReport(modelID string, output string, questionType string, entries []VocabPair, g GenerationSettings) string {
+	checked, report, err := a.checkForbidden(modelID, output, questionType, entries, g.ForbiddenWords, g.AvoidOtherTargets)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "금지어 검사 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:forbidden-checked", report)
+	return checked
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// --- Google Forms Quiz Export ---
+
+const formsAPI = "https://forms.googleapis.com/v1/forms"
+
+func formsCall(client *http.Client, method string, target string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkGoogleResponse(resp); err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// formItemRequests converts parsed questions into Forms batchUpdate requests:
+// one graded RADIO question per test question with the keyed choice marked
+// correct, worth points[q.Number]. Questions without a usable answer are
+// skipped and reported.
+func formItemRequests(questions []Question, points map[int]int) ([]interface{}, []int) {
+	var requests []interface{}
+	var skipped []int
+	for _, q := range questions {
+		if q.Answer < 1 || q.Answer > len(q.Choices) {
+			skipped = append(skipped, q.Number)
+			continue
+		}
+		var options []map[string]string
+		seen := map[string]bool{}
+		duplicate := false
+		for _, c := range q.Choices {
+			if seen[c] {
+				duplicate = true
+			}
+			seen[c] = true
+			options = append(options, map[string]string{"value": c})
+		}
+		// Forms rejects duplicate option values.
+		if duplicate {
+			skipped = append(skipped, q.Number)
+			continue
+		}
+
+		requests = append(requests, map[string]interface{}{
+			"createItem": map[string]interface{}{
+				"item": map[string]interface{}{
+					"title":       fmt.Sprintf("%d. %s", q.Number, q.Title),
+					"description": strings.Join(q.Body, "\n"),
+					"questionItem": map[string]interface{}{
+						"question": map[string]interface{}{
+							"required": true,
+							"grading": map[string]interface{}{
+								"pointValue": points[q.Number],
+								"correctAnswers": map[string]interface{}{
+									"answers": []map[string]string{{"value": q.Choices[q.Answer-1]}},
+								},
+							},
+							"choiceQuestion": map[string]interface{}{
+								"type":    "RADIO",
+								"options": options,
+							},
+						},
+					},
+				},
+				"location": map[string]int{"index": len(requests)},
+			},
+		})
+	}
+	return requests, skipped
+}
+
+type FormsExportResult struct {
+	FormID       string `json:"formId"`
+	ResponderURL string `json:"responderUrl"`
+	EditURL      string `json:"editUrl"`
+	Skipped      []int  `json:"skipped"`
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExportToGoogleForms creates a self-grading quiz from the structured
+// questions: quiz mode on, each question worth pointsPerQuestion points, or
+// the points from the score weighting settings when pointsPerQuestion is 0.
+func (a *VocabApp) ExportToGoogleForms(contentToExport string, title string, pointsPerQuestion int) (FormsExportResult, error) {
+	var result FormsExportResult
+	out := parseOutput(contentToExport)
+	if len(out.Questions) == 0 {
+		return result, fmt.Errorf("내보낼 문제를 찾을 수 없습니다")
+	}
+	if !out.HasKey {
+		return result, fmt.Errorf("[정답] 섹션이 없어 채점형 퀴즈를 만들 수 없습니다")
+	}
+	points := map[int]int{}
+	if pointsPerQuestion < 1 {
+		a.mu.Lock()
+		settings, err := loadSettings()
+		a.mu.Unlock()
+		if err != nil {
+			return result, err
+		}
+		for n, p := range a.questionPoints(contentToExport, settings.Points) {
+			points[n] = formPoints(p)
+		}
+	} else {
+		for _, q := range out.Questions {
+			points[q.Number] = pointsPerQuestion
+		}
+	}
+	client, err := a.googleHTTPClient()
+	if err != nil {
+		return result, err
+	}
+
+	var form struct {
+		FormID       string `json:"formId"`
+		ResponderURI string `json:"responderUri"`
+	}
+	err = formsCall(client, http.MethodPost, formsAPI, map[string]interface{}{
+		"info": map[string]string{"title": title, "documentTitle": title},
+	}, &form)
+	if err != nil {
+		return result, fmt.Errorf("설문지 생성 오류: %w", err)
+	}
+
+	items, skipped := formItemRequests(out.Questions, points)
+	requests := append([]interface{}{
+		map[string]interface{}{
+			"updateSettings": map[string]interface{}{
+				"settings":   map[string]interface{}{"quizSettings": map[string]bool{"isQuiz": true}},
+				"updateMask": "quizSettings.isQuiz",
+			},
+		},
+	}, items...)
+	err = formsCall(client, http.MethodPost, formsAPI+"/"+url.PathEscape(form.FormID)+":batchUpdate",
+		map[string]interface{}{"requests": requests}, nil)
+	if err != nil {
+		return result, fmt.Errorf("퀴즈 문항 추가 오류: %w", err)
+	}
+
+	result.FormID = form.FormID
+	result.ResponderURL = form.ResponderURI
+	result.EditURL = "https://docs.google.com/forms/d/" + form.FormID + "/edit"
+	result.Skipped = skipped
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// --- Multi-Type Generation ---
+
+// GeneratedSection is the output of one question type in GenerateAll.
+type GeneratedSection struct {
+	QuestionType string `json:"questionType"`
+	Output       string `json:"output"`
+	Error        string `json:"error"`
+}
+
+// --- Go functions callable from Javascript ---
+
+// GenerateAll generates several question types from the same (identically
+// shuffled) list concurrently. Sections come back in the order requested; a
+// failed type carries its error instead of failing the others.
+func (a *VocabApp) GenerateAll(vocabBlock string, modelID string, questionTypes []string, numSentences int) ([]GeneratedSection, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	if len(questionTypes) == 0 {
+		return nil, fmt.Errorf("문제 유형을 하나 이상 선택하세요")
+	}
+	seen := map[string]bool{}
+	for _, qType := range questionTypes {
+		if _, ok := defaultQuestionTitles[qType]; !ok {
+			return nil, fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", qType)
+		}
+		if seen[qType] {
+			return nil, fmt.Errorf("문제 유형이 중복되었습니다: '%s'", qType)
+		}
+		seen[qType] = true
+	}
+
+	parsed, issues := parseVocabLines(vocabBlock)
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+	rand.Shuffle(len(parsed), func(i, j int) { parsed[i], parsed[j] = parsed[j], parsed[i] })
+
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if settings.StrictParse {
+		if err := strictParseError(issues); err != nil {
+			return nil, err
+		}
+	}
+
+	sections := make([]GeneratedSection, len(questionTypes))
+	var wg sync.WaitGroup
+	for i, qType := range questionTypes {
+		wg.Add(1)
+		go func(i int, qType string) {
+			defer wg.Done()
+			section := GeneratedSection{QuestionType: qType}
+			if output, err := a.generate(settings, parsed, modelID, qType, numSentences); err != nil {
+				section.Error = err.Error()
+			} else {
+				section.Output = output
+			}
+			sections[i] = section
+		}(i, qType)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, s := range sections {
+		if s.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", s.QuestionType, s.Error))
+		}
+	}
+	if len(failed) == len(sections) {
+		return sections, fmt.Errorf("모든 유형의 생성에 실패했습니다\n%s", strings.Join(failed, "\n"))
+	}
+	return sections, nil
+}
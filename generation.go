@@ -0,0 +1,272 @@
+package main
+
+import "fmt"
+
+// --- Generation Options ---
+
+// GenerationSettings holds the options that shape every generated test.
+type GenerationSettings struct {
+	// ContextTheme is the ID of a contextThemes entry; empty leaves the topic
+	// of the example sentences to the model.
+	ContextTheme string `json:"contextTheme"`
+	// GradeLevel is the ID of a gradeLevels entry; it sets the audience,
+	// sentence complexity and distractor difficulty together.
+	GradeLevel string `json:"gradeLevel"`
+	// Spelling is SpellingBritish or SpellingAmerican; empty allows either.
+	Spelling string `json:"spelling"`
+	// Names and Places choose the proper nouns of the sentences (see the
+	// Names* and Places* constants); empty leaves them to the model.
+	Names  string `json:"names"`
+	Places string `json:"places"`
+
+	// SenseCoverage decides how polysemous words are turned into 빈칸 추론
+	// questions (see the SenseCoverage constants); SensesCap is N for
+	// SenseCoverageCapped.
+	SenseCoverage string `json:"senseCoverage"`
+	SensesCap     int    `json:"sensesCap"`
+
+	// MaxQuestions caps the number of questions per run (0 = no cap); when
+	// the list is longer, TrimStrategy picks which words to keep.
+	MaxQuestions int    `json:"maxQuestions"`
+	TrimStrategy string `json:"trimStrategy"`
+
+	// VerifyDefinitions runs a second call over 뜻풀이 판단 output that checks
+	// the wrong definitions are really wrong and rewrites ambiguous ones.
+	VerifyDefinitions bool `json:"verifyDefinitions"`
+	// CheckBlanks repairs 빈칸 추론 sentences without exactly one blank and
+	// has the model proofread the sentences with the answer filled in.
+	CheckBlanks bool `json:"checkBlanks"`
+	// AllowInflections lets 빈칸 추론 sentences use inflected forms (ran,
+	// running) while the choices stay in base form.
+	AllowInflections bool `json:"allowInflections"`
+	// TranslateSentences appends Korean translations of the question
+	// sentences as a teacher-only appendix, made in a follow-up call.
+	TranslateSentences bool `json:"translateSentences"`
+	// BlankStyle rewrites the blanks of exported text and Word documents
+	// (see the Blank* constants); empty keeps them as generated.
+	BlankStyle string `json:"blankStyle"`
+	// FirstLetterHint shows the first letter of the answer in every blank
+	// ("a______"), for lower-level classes.
+	FirstLetterHint bool `json:"firstLetterHint"`
+	// ForbiddenWords must not appear in the sentences (proper nouns,
+	// sensitive terms); with AvoidOtherTargets neither may the other words
+	// of the list. Both are asked for in the prompt and checked in Go.
+	ForbiddenWords    []string `json:"forbiddenWords"`
+	AvoidOtherTargets bool     `json:"avoidOtherTargets"`
+	// CheckDuplicates has the model rewrite context sentences that nearly
+	// repeat another sentence of the same test.
+	CheckDuplicates bool `json:"checkDuplicates"`
+	// CheckSenses has the model check that every question on a word with
+	// several senses targets only its intended sense; conflicts are flagged.
+	CheckSenses bool `json:"checkSenses"`
+	// MatchChoicePOS requires all choices to share the answer's part of
+	// speech and form, checked in Go and repaired by the model.
+	MatchChoicePOS bool `json:"matchChoicePOS"`
+	// StyleReferences holds example questions pasted from a past exam per
+	// question type; generated questions imitate their style.
+	StyleReferences map[string]string `json:"styleReferences"`
+	// FewShotSets selects the example set injected per question type (see
+	// SelectFewShotSet); FewShotBudget caps its size in tokens (0 = 1500).
+	FewShotSets   map[string]string `json:"fewShotSets"`
+	FewShotBudget int               `json:"fewShotBudget"`
+	// ReviewModel turns on the draft/review pipeline: the selected model
+	// drafts the test and this (stronger) model rewrites only the questions
+	// that fail validation. Empty sends the draft as it is.
+	ReviewModel string `json:"reviewModel"`
+	// TextbookUnit is the ID of the textbook unit whose passage the
+	// sentences are grounded in (see SelectTextbookUnit); empty = none.
+	TextbookUnit string `json:"textbookUnit"`
+	// Exemplars is the number of similar sentences from approved bank
+	// questions retrieved per word (see IndexSentences); 0 turns retrieval off.
+	Exemplars int `json:"exemplars"`
+	// HideSenseNotes keeps the notes of senses ("은행(銀行)") out of the
+	// printed questions and answer key; the model still sees them as hints.
+	HideSenseNotes bool `json:"hideSenseNotes"`
+	// Candidates is the number of versions sampled per run (0 or 1 = one);
+	// the version of every question passing the most checks is kept.
+	Candidates int `json:"candidates"`
+}
+
+const (
+	SenseCoveragePerSense = "sense" // one question per sense
+	SenseCoveragePerWord  = "word"  // one question per word, senses mixed in its sentences
+	SenseCoverageCapped   = "cap"   // at most SensesCap questions per word
+)
+
+// questionEntries splits the word list into one entry per 빈칸 추론 question
+// according to the sense coverage. Other question types and the default
+// (empty) coverage are left to the prompt.
+func (g GenerationSettings) questionEntries(parsed []VocabPair, questionType string) []VocabPair {
+	if questionType != "빈칸 추론" {
+		return parsed
+	}
+	switch g.SenseCoverage {
+	case SenseCoveragePerSense:
+		return splitSenses(parsed, 0)
+	case SenseCoverageCapped:
+		return splitSenses(parsed, g.SensesCap)
+	}
+	return parsed
+}
+
+// splitSenses turns every word into one entry per sense, or with max > 0 into
+// at most max entries with the senses dealt round-robin, so that every sense
+// still appears in some question.
+func splitSenses(parsed []VocabPair, max int) []VocabPair {
+	var entries []VocabPair
+	for _, pair := range parsed {
+		n := len(pair.Senses)
+		if max > 0 && n > max {
+			n = max
+		}
+		groups := make([]VocabPair, n)
+		for i, sense := range pair.Senses {
+			g := &groups[i%n]
+			g.Word = pair.Word
+			g.Seed = pair.Seed
+			g.Note = pair.Note
+			g.copySense(pair, sense)
+		}
+		entries = append(entries, groups...)
+	}
+	return entries
+}
+
+// ContextTheme is a topic domain for the example sentences.
+type ContextTheme struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	description string // English phrase used in the prompt
+}
+
+var contextThemes = []ContextTheme{
+	{ID: "school", Label: "학교생활", description: "school life (classes, friends, teachers, clubs and school events)"},
+	{ID: "science", Label: "과학", description: "science and technology (experiments, nature, the human body, inventions)"},
+	{ID: "history", Label: "역사", description: "history and culture (historical events, figures, traditions and civilizations)"},
+	{ID: "daily", Label: "일상생활", description: "daily life (family, home, shopping, hobbies and travel)"},
+	{ID: "nonfiction", Label: "수능형 비문학", description: "exam-style nonfiction passages (academic topics in psychology, economics, society and the arts, written in a formal expository tone)"},
+}
+
+func findContextTheme(id string) (ContextTheme, bool) {
+	for _, t := range contextThemes {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return ContextTheme{}, false
+}
+
+// GradeLevel is a target audience for the generated test.
+type GradeLevel struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+
+	audience    string // who the test is for, completing "test maker for ..."
+	sentences   string // sentence complexity guidance
+	distractors string // distractor difficulty guidance
+}
+
+var gradeLevels = []GradeLevel{
+	{ID: "m1", Label: "중1", audience: "Korean first-year middle school students (CEFR A1-A2)",
+		sentences:   "Use short, simple sentences of about 8-12 words with basic grammar (present/past tense, simple clauses) and everyday vocabulary.",
+		distractors: "Use common words the students already know as distractors; they should differ clearly in meaning from the answer."},
+	{ID: "m2", Label: "중2", audience: "Korean second-year middle school students (CEFR A2)",
+		sentences:   "Use sentences of about 10-14 words; simple compound sentences and common phrasal verbs are allowed.",
+		distractors: "Use familiar words of the same part of speech as distractors, avoiding close synonyms."},
+	{ID: "m3", Label: "중3", audience: "Korean third-year middle school students (CEFR A2-B1)",
+		sentences:   "Use sentences of about 12-16 words; relative clauses, to-infinitives and the present perfect are allowed.",
+		distractors: "Use words of the same part of speech with related meanings as distractors."},
+	{ID: "h1", Label: "고1", audience: "Korean first-year high school students (CEFR B1)",
+		sentences:   "Use sentences of about 15-20 words with a mix of simple and complex structures, as in high school textbooks.",
+		distractors: "Use plausible distractors of the same part of speech, including some words with overlapping meanings."},
+	{ID: "h2", Label: "고2", audience: "Korean second-year high school students (CEFR B1-B2)",
+		sentences:   "Use sentences of about 18-25 words with complex structures such as participle clauses and relative clauses.",
+		distractors: "Use near-synonyms and collocation traps as distractors."},
+	{ID: "h3", Label: "고3", audience: "Korean high school seniors preparing for the CSAT (수능, CEFR B2)",
+		sentences:   "Use CSAT-style sentences of about 20-30 words with academic vocabulary and complex structures (inversion, participle clauses, subjunctive).",
+		distractors: "Use sophisticated near-synonyms, collocation traps and words with similar spelling as distractors, as on the CSAT."},
+	{ID: "toefl", Label: "TOEFL", audience: "Korean adults preparing for the TOEFL iBT (CEFR B2-C1)",
+		sentences:   "Use academic sentences of about 20-30 words on university lecture and reading topics.",
+		distractors: "Use academic near-synonyms that only differ in nuance or register as distractors."},
+	{ID: "civil", Label: "공무원", audience: "Korean adults preparing for the civil service English exam (공무원 영어)",
+		sentences:   "Use formal sentences of about 18-28 words in the style of civil service exam passages, including idioms and formal expressions.",
+		distractors: "Use idioms, near-synonyms and formal vocabulary as distractors, as on the civil service exam."},
+}
+
+func findGradeLevel(id string) (GradeLevel, bool) {
+	for _, l := range gradeLevels {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return GradeLevel{}, false
+}
+
+func validateGenerationSettings(g GenerationSettings) error {
+	if _, ok := findContextTheme(g.ContextTheme); g.ContextTheme != "" && !ok {
+		return fmt.Errorf("지원하지 않는 문맥 주제입니다: '%s'", g.ContextTheme)
+	}
+	if _, ok := findGradeLevel(g.GradeLevel); g.GradeLevel != "" && !ok {
+		return fmt.Errorf("지원하지 않는 학년/수준입니다: '%s'", g.GradeLevel)
+	}
+	switch g.SenseCoverage {
+	case "", SenseCoveragePerSense, SenseCoveragePerWord:
+	case SenseCoverageCapped:
+		if g.SensesCap < 1 {
+			return fmt.Errorf("단어당 최대 문항 수는 1 이상이어야 합니다")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 다의어 출제 방식입니다: '%s'", g.SenseCoverage)
+	}
+	if g.MaxQuestions < 0 {
+		return fmt.Errorf("최대 문항 수는 0 이상이어야 합니다")
+	}
+	switch g.TrimStrategy {
+	case "", TrimRandom, TrimWeighted:
+	default:
+		return fmt.Errorf("지원하지 않는 문항 선택 방식입니다: '%s'", g.TrimStrategy)
+	}
+	switch g.Spelling {
+	case "", SpellingBritish, SpellingAmerican:
+	default:
+		return fmt.Errorf("지원하지 않는 철자 방식입니다: '%s'", g.Spelling)
+	}
+	switch g.BlankStyle {
+	case "", BlankUniform, BlankPerLetter:
+	default:
+		return fmt.Errorf("지원하지 않는 빈칸 방식입니다: '%s'", g.BlankStyle)
+	}
+	if g.FewShotBudget < 0 {
+		return fmt.Errorf("예시 토큰 예산은 0 이상이어야 합니다")
+	}
+	if g.Exemplars < 0 || g.Exemplars > maxExemplars {
+		return fmt.Errorf("검색 예문 수는 0에서 %d 사이여야 합니다", maxExemplars)
+	}
+	if g.Candidates < 0 || g.Candidates > maxCandidates {
+		return fmt.Errorf("후보 수는 0에서 %d 사이여야 합니다", maxCandidates)
+	}
+	if g.ReviewModel != "" {
+		if err := validateModelID(g.ReviewModel); err != nil {
+			return fmt.Errorf("검토 모델: %w", err)
+		}
+	}
+	if err := validateNameStyles(g.Names, g.Places); err != nil {
+		return err
+	}
+	if err := validateForbiddenWords(g.ForbiddenWords); err != nil {
+		return err
+	}
+	return validateStyleReferences(g.StyleReferences)
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetContextThemes lists the selectable sentence topics for the settings UI.
+func (a *VocabApp) GetContextThemes() []ContextTheme {
+	return contextThemes
+}
+
+// GetGradeLevels lists the selectable target levels for the settings UI.
+func (a *VocabApp) GetGradeLevels() []GradeLevel {
+	return gradeLevels
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Google OAuth ---
+
+// GoogleSettings holds the OAuth desktop client the user registered in Google
+// Cloud Console and the refresh token obtained by ConnectGoogle.
+type GoogleSettings struct {
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	RefreshToken string `json:"refreshToken"`
+
+	// ExportFolderID is the Drive folder ExportToDrive uploads into.
+	ExportFolderID string `json:"exportFolderId"`
+	// ShareWithLink makes exported files readable by anyone with the link.
+	// Off by default, so tests stay private to the teacher's account.
+	ShareWithLink bool `json:"shareWithLink"`
+}
+
+const (
+	googleAuthURL  = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+)
+
+// googleScopes only covers files and forms this app creates, not the user's
+// whole Drive.
+var googleScopes = []string{
+	"https://www.googleapis.com/auth/drive.file",
+	"https://www.googleapis.com/auth/forms.body",
+}
+
+func randomURLSafe(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// googleTransport adds a fresh access token, refreshed from the stored
+// refresh token, to every request.
+type googleTransport struct {
+	settings GoogleSettings
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (t *googleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.accessToken()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (t *googleTransport) accessToken() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && time.Until(t.expires) > time.Minute {
+		return t.token, nil
+	}
+	tok, err := requestGoogleToken(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {t.settings.RefreshToken},
+		"client_id":     {t.settings.ClientID},
+		"client_secret": {t.settings.ClientSecret},
+	})
+	if err != nil {
+		return "", err
+	}
+	t.token = tok.AccessToken
+	t.expires = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	return t.token, nil
+}
+
+type googleToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+	ErrorDesc    string `json:"error_description"`
+}
+
+func requestGoogleToken(form url.Values) (googleToken, error) {
+	var tok googleToken
+	resp, err := http.PostForm(googleTokenURL, form)
+	if err != nil {
+		return tok, fmt.Errorf("Google 토큰 요청 오류: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return tok, fmt.Errorf("Google 토큰 응답 오류: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || tok.AccessToken == "" {
+		return tok, fmt.Errorf("Google 인증 실패: %s %s", tok.Error, tok.ErrorDesc)
+	}
+	return tok, nil
+}
+
+// googleHTTPClient returns an authorized client, or an error asking the user
+// to connect their Google account first.
+func (a *VocabApp) googleHTTPClient() (*http.Client, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if settings.Google.RefreshToken == "" {
+		return nil, fmt.Errorf("Google 계정이 연결되지 않았습니다. 설정에서 Google 연결을 먼저 진행하세요.")
+	}
+	return &http.Client{
+		Timeout:   120 * time.Second,
+		Transport: &googleTransport{settings: settings.Google},
+	}, nil
+}
+
+// checkGoogleResponse turns a non-2xx Google API response into an error.
+func checkGoogleResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	return fmt.Errorf("Google API 오류 (%d): %s", resp.StatusCode, body.Error.Message)
+}
+
+// --- Go functions callable from Javascript ---
+
+// ConnectGoogle runs the installed-app OAuth flow: it opens the consent page
+// in the browser, waits for the redirect on a loopback port and stores the
+// refresh token in the settings.
+func (a *VocabApp) ConnectGoogle() (string, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if settings.Google.ClientID == "" {
+		return "", fmt.Errorf("Google OAuth 클라이언트 ID를 먼저 설정하세요")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("로컬 인증 서버 시작 오류: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+	state := randomURLSafe(16)
+	verifier := randomURLSafe(48)
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL := googleAuthURL + "?" + url.Values{
+		"client_id":             {settings.Google.ClientID},
+		"redirect_uri":          {redirectURI},
+		"response_type":         {"code"},
+		"scope":                 {strings.Join(googleScopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+		"access_type":           {"offline"},
+		"prompt":                {"consent"},
+	}.Encode()
+
+	codeCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+		if e := q.Get("error"); e != "" {
+			fmt.Fprintln(w, "인증이 취소되었습니다. 이 창을 닫아도 됩니다.")
+			errCh <- fmt.Errorf("Google 인증 거부: %s", e)
+			return
+		}
+		fmt.Fprintln(w, "인증이 완료되었습니다. 이 창을 닫고 앱으로 돌아가세요.")
+		codeCh <- q.Get("code")
+	})}
+	go server.Serve(listener)
+	defer server.Shutdown(context.Background())
+
+	runtime.BrowserOpenURL(a.ctx, authURL)
+
+	var code string
+	select {
+	case code = <-codeCh:
+	case err := <-errCh:
+		return "", err
+	case <-time.After(5 * time.Minute):
+		return "", fmt.Errorf("Google 인증 시간이 초과되었습니다")
+	}
+
+	tok, err := requestGoogleToken(url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {settings.Google.ClientID},
+		"client_secret": {settings.Google.ClientSecret},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return "", err
+	}
+	if tok.RefreshToken == "" {
+		return "", fmt.Errorf("Google이 갱신 토큰을 반환하지 않았습니다. 다시 시도하세요.")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err = loadSettings()
+	if err != nil {
+		return "", err
+	}
+	settings.Google.RefreshToken = tok.RefreshToken
+	if err := saveJSON(settingsFile, settings); err != nil {
+		return "", err
+	}
+	return "Google 계정 연결 완료", nil
+}
+
+func (a *VocabApp) DisconnectGoogle() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	settings.Google.RefreshToken = ""
+	return saveJSON(settingsFile, settings)
+}
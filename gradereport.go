@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Grade Reports ---
+
+// latestResults keeps the most recent result of every student, sorted by
+// student name.
+func latestResults(results []GradeResult) []GradeResult {
+	latest := map[string]GradeResult{}
+	for _, r := range results {
+		if prev, ok := latest[r.Student]; !ok || !r.GradedAt.Before(prev.GradedAt) {
+			latest[r.Student] = r
+		}
+	}
+	kept := make([]GradeResult, 0, len(latest))
+	for _, r := range latest {
+		kept = append(kept, r)
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Student < kept[j].Student })
+	return kept
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// gradeReportRows lays out the student × question score matrix with the total
+// per student, followed by the difficulty and discrimination rows.
+func gradeReportRows(results []GradeResult, questions []Question) [][]xlsxCell {
+	header := []xlsxCell{xlsxText("학생")}
+	for _, q := range questions {
+		header = append(header, xlsxText(fmt.Sprintf("%d번", q.Number)))
+	}
+	header = append(header, xlsxText("총점"), xlsxText("만점"))
+	rows := [][]xlsxCell{header}
+
+	for _, r := range results {
+		row := []xlsxCell{xlsxText(r.Student)}
+		for _, q := range questions {
+			score := 0.0
+			for _, item := range r.Items {
+				if item.Question == q.Number {
+					score = item.Score
+				}
+			}
+			row = append(row, xlsxNumber(round2(score)))
+		}
+		row = append(row, xlsxNumber(round2(r.Score)), xlsxNumber(round2(r.Total)))
+		rows = append(rows, row)
+	}
+
+	difficulty := []xlsxCell{xlsxText("난이도 (정답률)")}
+	discrimination := []xlsxCell{xlsxText("변별도")}
+	biserial := []xlsxCell{xlsxText("점이연 상관")}
+	for _, s := range analyzeItems(results, questions) {
+		difficulty = append(difficulty, xlsxNumber(round2(s.Difficulty)))
+		discrimination = append(discrimination, xlsxNumber(round2(s.Discrimination)))
+		biserial = append(biserial, xlsxNumber(round2(s.PointBiserial)))
+	}
+	return append(rows, []xlsxCell{}, difficulty, discrimination, biserial)
+}
+
+// csvText keeps spreadsheet apps from reading a text cell as a formula:
+// student names and answers are typed by students, and a cell such as
+// "=HYPERLINK(...)" would otherwise run when the file is opened.
+func csvText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// buildCSV writes the rows as UTF-8 CSV with a byte order mark, so Excel
+// opens the Korean text correctly.
+func buildCSV(rows [][]xlsxCell) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell.Numeric {
+				record[i] = strconv.FormatFloat(cell.Number, 'f', -1, 64)
+			} else {
+				record[i] = csvText(cell.Text)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExportGradeResults saves the grades of a saved test as a spreadsheet
+// ("csv" or "xlsx"), using each student's latest result.
+func (a *VocabApp) ExportGradeResults(testID string, format string) (string, error) {
+	if format != "csv" && format != "xlsx" {
+		return "", fmt.Errorf("지원하지 않는 형식입니다: '%s'", format)
+	}
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return "", err
+	}
+	all, err := a.ListGradeResults(testID)
+	if err != nil {
+		return "", err
+	}
+	results := latestResults(all)
+	if len(results) == 0 {
+		return "", fmt.Errorf("채점 결과가 없습니다")
+	}
+	rows := gradeReportRows(results, parseOutput(test.Content).Questions)
+	var data []byte
+	if format == "csv" {
+		data, err = buildCSV(rows)
+	} else {
+		data, err = buildXlsx("성적", rows)
+	}
+	if err != nil {
+		return "", fmt.Errorf("스프레드시트 생성 오류: %w", err)
+	}
+
+	name, err := a.planExportName(test.Content, test.QuestionType, test.Name)
+	if err != nil {
+		return "", err
+	}
+	filter := runtime.FileFilter{DisplayName: "CSV 파일 (*.csv)", Pattern: "*.csv"}
+	if format == "xlsx" {
+		filter = runtime.FileFilter{DisplayName: "Excel 파일 (*.xlsx)", Pattern: "*.xlsx"}
+	}
+	filePath, auto, err := a.savePath(&name, "_성적."+format, runtime.SaveDialogOptions{
+		Title:   "채점 결과 저장",
+		Filters: []runtime.FileFilter{filter},
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.exportSaved(name, filePath, auto)
+	return fmt.Sprintf("저장 완료: %s", filepath.Base(filePath)), nil
+}
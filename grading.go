@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Auto-Grading ---
+
+const gradesFile = "grades.json"
+
+// GradingRules controls how written answers of production questions are
+// graded. Multiple-choice answers are always right or wrong.
+type GradingRules struct {
+	// AcceptSpellingVariants gives full credit for the British or American
+	// spelling of the answer (colour/color).
+	AcceptSpellingVariants bool `json:"acceptSpellingVariants"`
+	// InflectionCredit is the credit (0-1) for another form of the right
+	// word, e.g. "abandon" when "abandoned" was needed.
+	InflectionCredit float64 `json:"inflectionCredit"`
+	// TypoCredit is the credit (0-1) for a single-letter spelling mistake in
+	// words of four letters or more.
+	TypoCredit    float64 `json:"typoCredit"`
+	CaseSensitive bool    `json:"caseSensitive"`
+}
+
+func validateGradingRules(r GradingRules) error {
+	if r.InflectionCredit < 0 || r.InflectionCredit > 1 || r.TypoCredit < 0 || r.TypoCredit > 1 {
+		return fmt.Errorf("부분 점수 비율은 0에서 1 사이여야 합니다")
+	}
+	return nil
+}
+
+// GradedItem is the grade of one question of a submission.
+type GradedItem struct {
+	Question int     `json:"question"`
+	Response string  `json:"response"`
+	Credit   float64 `json:"credit"` // 0-1
+	Points   float64 `json:"points"`
+	Score    float64 `json:"score"`
+	Reason   string  `json:"reason"`
+}
+
+// GradeResult is one student's graded submission for a saved test.
+type GradeResult struct {
+	ID       string       `json:"id"`
+	TestID   string       `json:"testId"`
+	TestName string       `json:"testName"`
+	Student  string       `json:"student"`
+	Class    string       `json:"class"`
+	Items    []GradedItem `json:"items"`
+	Score    float64      `json:"score"`
+	Total    float64      `json:"total"`
+	GradedAt time.Time    `json:"gradedAt"`
+}
+
+func loadGrades() ([]GradeResult, error) {
+	var grades []GradeResult
+	return grades, loadJSON(gradesFile, &grades)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// spellingVariant reports whether a and b are the British and American
+// spellings of the same word.
+func spellingVariant(a, b string) bool {
+	return spellingPairs[a] == b || spellingPairs[b] == a
+}
+
+// chosenIndex reads a multiple-choice response given as a circled marker, a
+// digit or the text of the choice. It returns 0 if it cannot tell.
+func chosenIndex(q Question, response string) int {
+	if r := []rune(response); len(r) == 1 && choiceIndex(r[0]) > 0 {
+		return choiceIndex(r[0])
+	}
+	if n, err := strconv.Atoi(response); err == nil && n >= 1 && n <= len(q.Choices) {
+		return n
+	}
+	for i, c := range q.Choices {
+		if strings.EqualFold(c, response) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// gradeResponse returns the credit (0-1) a response earns and why.
+// accepted lists extra answers the teacher accepts for the question.
+func gradeResponse(q Question, response string, accepted []string, rules GradingRules) (float64, string) {
+	response = strings.Join(strings.Fields(response), " ")
+	if response == "" {
+		return 0, "무응답"
+	}
+	if q.AnswerText == "" {
+		if q.Answer == 0 {
+			return 0, "정답 없음"
+		}
+		if chosenIndex(q, response) == q.Answer {
+			return 1, "정답"
+		}
+		return 0, "오답"
+	}
+
+	norm := func(s string) string {
+		s = strings.Join(strings.Fields(s), " ")
+		if !rules.CaseSensitive {
+			s = strings.ToLower(s)
+		}
+		return s
+	}
+	got := norm(response)
+	answers := append([]string{q.AnswerText}, accepted...)
+	for _, a := range answers {
+		if got == norm(a) {
+			return 1, "정답"
+		}
+	}
+	credit, reason := 0.0, "오답"
+	for _, a := range answers {
+		want := norm(a)
+		lgot, lwant := strings.ToLower(got), strings.ToLower(want)
+		switch {
+		case rules.AcceptSpellingVariants && spellingVariant(lgot, lwant):
+			return 1, "철자 변이 인정"
+		case rules.InflectionCredit > credit && (isInflectionOf(lgot, lwant) || isInflectionOf(lwant, lgot)):
+			credit, reason = rules.InflectionCredit, "형태 오류 (부분 점수)"
+		case rules.TypoCredit > credit && len([]rune(lwant)) >= 4 && editDistance(lgot, lwant) == 1:
+			credit, reason = rules.TypoCredit, "철자 오류 (부분 점수)"
+		}
+	}
+	return credit, reason
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetAcceptedAnswers sets extra accepted answers for the production questions
+// of a saved test, keyed by question number.
+func (a *VocabApp) SetAcceptedAnswers(testID string, accepted map[int][]string) (SavedTest, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests, err := loadTests()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	for i := range tests {
+		if tests[i].ID == testID && tests[i].DeletedAt == nil {
+			if err := errIfLocked(tests[i]); err != nil {
+				return SavedTest{}, err
+			}
+			tests[i].Accepted = accepted
+			return tests[i], saveJSON(testsFile, tests)
+		}
+	}
+	return SavedTest{}, fmt.Errorf("시험지를 찾을 수 없습니다")
+}
+
+// GradeSubmission grades one student's answers to a saved test, keyed by
+// question number, and stores the result. Points come from the score
+// weighting settings; the item statistics of the test are refreshed.
+func (a *VocabApp) GradeSubmission(testID string, student string, responses map[int]string) (GradeResult, error) {
+	student = strings.TrimSpace(student)
+	if student == "" {
+		return GradeResult{}, fmt.Errorf("학생 이름을 입력하세요")
+	}
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return GradeResult{}, err
+	}
+	out := parseOutput(test.Content)
+	if !out.HasKey {
+		return GradeResult{}, fmt.Errorf("[정답] 섹션이 없어 채점할 수 없습니다")
+	}
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return GradeResult{}, err
+	}
+	points := a.questionPoints(test.Content, settings.Points)
+
+	result := GradeResult{
+		ID:       newID(),
+		TestID:   test.ID,
+		TestName: test.Name,
+		Student:  student,
+		Class:    settings.ActiveClass,
+		GradedAt: time.Now(),
+	}
+	for _, q := range out.Questions {
+		item := GradedItem{Question: q.Number, Response: responses[q.Number], Points: points[q.Number]}
+		item.Credit, item.Reason = gradeResponse(q, item.Response, test.Accepted[q.Number], settings.Grading)
+		item.Score = item.Credit * item.Points
+		result.Items = append(result.Items, item)
+		result.Score += item.Score
+		result.Total += item.Points
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	grades, err := loadGrades()
+	if err != nil {
+		return GradeResult{}, err
+	}
+	grades = append(grades, result)
+	if err := saveJSON(gradesFile, grades); err != nil {
+		return GradeResult{}, err
+	}
+	if _, err := analyzeTest(test, grades); err != nil {
+		runtime.LogErrorf(a.ctx, "문항 분석 오류: %v", err)
+	}
+	return result, nil
+}
+
+// ListGradeResults returns the stored results of a saved test, or of all
+// tests when testID is empty.
+func (a *VocabApp) ListGradeResults(testID string) ([]GradeResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	grades, err := loadGrades()
+	if err != nil {
+		return nil, err
+	}
+	results := []GradeResult{}
+	for _, g := range grades {
+		if testID == "" || g.TestID == testID {
+			results = append(results, g)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Grammar Check ---
+
+const defaultGrammarServer = "https://api.languagetool.org"
+
+// GrammarSettings configure the LanguageTool server the English sentences
+// of generated tests are checked with. A self-hosted server has no rate
+// limit; the public one allows a few checks a minute.
+type GrammarSettings struct {
+	Enabled bool   `json:"enabled"`
+	Server  string `json:"server"`   // "" for the public LanguageTool API
+	Variant string `json:"variant"`  // LanguageTool language code, "" for en-US
+	APIKey  string `json:"apiKey"`   // LanguageTool Premium key, optional
+	User    string `json:"username"` // account the key belongs to
+	// AutoRepair applies the first suggestion of grammar errors; other
+	// matches (spelling, style) are only flagged.
+	AutoRepair bool `json:"autoRepair"`
+}
+
+func validateGrammarSettings(s GrammarSettings) error {
+	if s.Server != "" {
+		u, err := url.Parse(s.Server)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("문법 검사 서버 주소가 올바르지 않습니다: '%s'", s.Server)
+		}
+	}
+	if (s.APIKey == "") != (s.User == "") {
+		return fmt.Errorf("LanguageTool 키를 사용하려면 계정 이름도 입력하세요")
+	}
+	return nil
+}
+
+type grammarMatch struct {
+	Message      string `json:"message"`
+	Offset       int    `json:"offset"`
+	Length       int    `json:"length"`
+	Replacements []struct {
+		Value string `json:"value"`
+	} `json:"replacements"`
+	Rule struct {
+		ID        string `json:"id"`
+		IssueType string `json:"issueType"`
+	} `json:"rule"`
+}
+
+// languageToolCheck sends text to the LanguageTool server.
+func languageToolCheck(s GrammarSettings, text string) ([]grammarMatch, error) {
+	server := strings.TrimRight(s.Server, "/")
+	if server == "" {
+		server = defaultGrammarServer
+	}
+	variant := s.Variant
+	if variant == "" {
+		variant = "en-US"
+	}
+	form := url.Values{"text": {text}, "language": {variant}}
+	if s.APIKey != "" {
+		form.Set("apiKey", s.APIKey)
+		form.Set("username", s.User)
+	}
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).PostForm(server+"/v2/check", form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("문법 검사 서버 응답 오류: %s", resp.Status)
+	}
+	var body struct {
+		Matches []grammarMatch `json:"matches"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body.Matches, nil
+}
+
+// filledSentence is a body line with its blanks filled with the answer, so
+// the checker sees a complete sentence.
+type filledSentence struct {
+	question int // index into the parsed questions
+	line     int // index into the question body
+	text     string
+	blanks   [][2]int // byte spans of the filled-in answers in text
+	original []string // the blank text each span replaced
+}
+
+// fillBlanks fills the blanks of line with answer.
+func fillBlanks(line string, answer string) filledSentence {
+	var f filledSentence
+	last := 0
+	var b strings.Builder
+	for _, m := range blankRe.FindAllStringIndex(line, -1) {
+		b.WriteString(line[last:m[0]])
+		f.blanks = append(f.blanks, [2]int{b.Len(), b.Len() + len(answer)})
+		f.original = append(f.original, line[m[0]:m[1]])
+		b.WriteString(answer)
+		last = m[1]
+	}
+	b.WriteString(line[last:])
+	f.text = b.String()
+	return f
+}
+
+// unfill puts the blanks back.
+func (f filledSentence) unfill() string {
+	text := f.text
+	for i := len(f.blanks) - 1; i >= 0; i-- {
+		text = text[:f.blanks[i][0]] + f.original[i] + text[f.blanks[i][1]:]
+	}
+	return text
+}
+
+// touchesBlank reports whether the span [start, end) overlaps a filled
+// answer, which the checker must not change.
+func (f filledSentence) touchesBlank(start, end int) bool {
+	for _, s := range f.blanks {
+		if start < s[1] && end > s[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// replace substitutes [start, end) and shifts the blank spans after it.
+func (f *filledSentence) replace(start, end int, value string) {
+	f.text = f.text[:start] + value + f.text[end:]
+	delta := len(value) - (end - start)
+	for i := range f.blanks {
+		if f.blanks[i][0] >= end {
+			f.blanks[i][0] += delta
+			f.blanks[i][1] += delta
+		}
+	}
+}
+
+func hasHangul(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return unicode.Is(unicode.Hangul, r) }) >= 0
+}
+
+// GrammarCheckReport is emitted as "generate:grammar-checked".
+type GrammarCheckReport struct {
+	Checked  int               `json:"checked"`  // sentences sent to the checker
+	Repaired []ValidationIssue `json:"repaired"` // errors fixed automatically
+	Flagged  []ValidationIssue `json:"flagged"`  // errors left for the teacher
+}
+
+// checkGrammar checks the English body lines of every question in one
+// request, filling blanks with the answer first. Matches on the answer
+// itself are ignored: the word and its form are checked elsewhere.
+func checkGrammar(s GrammarSettings, output string) (string, GrammarCheckReport, error) {
+	report := GrammarCheckReport{Repaired: []ValidationIssue{}, Flagged: []ValidationIssue{}}
+	out := parseOutput(output)
+	var sentences []filledSentence
+	for qi, q := range out.Questions {
+		answer := blankAnswer(q)
+		if len(q.Forms) > 0 {
+			answer = q.Forms[0]
+		}
+		if answer == "" {
+			answer = "something"
+		}
+		for li, line := range q.Body {
+			if strings.TrimSpace(line) == "" || hasHangul(line) {
+				continue
+			}
+			f := fillBlanks(line, answer)
+			f.question, f.line = qi, li
+			sentences = append(sentences, f)
+		}
+	}
+	report.Checked = len(sentences)
+	if len(sentences) == 0 {
+		return output, report, nil
+	}
+
+	// Sentences are sent as paragraphs of one text; starts maps the
+	// offsets of the matches back.
+	var text strings.Builder
+	starts := make([]int, len(sentences))
+	for i, f := range sentences {
+		if i > 0 {
+			text.WriteString("\n\n")
+		}
+		starts[i] = text.Len()
+		text.WriteString(f.text)
+	}
+	matches, err := languageToolCheck(s, text.String())
+	if err != nil {
+		return output, report, err
+	}
+	// LanguageTool counts offsets in UTF-16 code units.
+	var byteOffset []int
+	for i, r := range text.String() {
+		byteOffset = append(byteOffset, i)
+		if r > 0xFFFF {
+			byteOffset = append(byteOffset, i)
+		}
+	}
+	byteOffset = append(byteOffset, text.Len())
+	for i := range matches {
+		m := &matches[i]
+		if m.Offset < 0 || m.Offset+m.Length > len(byteOffset)-1 {
+			m.Offset, m.Length = -1, 0
+			continue
+		}
+		m.Offset, m.Length = byteOffset[m.Offset], byteOffset[m.Offset+m.Length]-byteOffset[m.Offset]
+	}
+	// Later matches first, so earlier offsets stay valid while repairing.
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Offset > matches[j].Offset })
+
+	changed := false
+	for _, m := range matches {
+		i := sort.Search(len(starts), func(i int) bool { return starts[i] > m.Offset }) - 1
+		if i < 0 {
+			continue
+		}
+		f := &sentences[i]
+		start, end := m.Offset-starts[i], m.Offset-starts[i]+m.Length
+		if end > len(f.text) || f.touchesBlank(start, end) {
+			continue
+		}
+		q := out.Questions[f.question]
+		issue := ValidationIssue{Question: q.Number, Message: fmt.Sprintf("%s: '%s'", m.Message, f.text[start:end])}
+		if s.AutoRepair && m.Rule.IssueType == "grammar" && len(m.Replacements) > 0 {
+			issue.Message += fmt.Sprintf(" → '%s'", m.Replacements[0].Value)
+			f.replace(start, end, m.Replacements[0].Value)
+			report.Repaired = append(report.Repaired, issue)
+			changed = true
+			continue
+		}
+		report.Flagged = append(report.Flagged, issue)
+	}
+	byQuestion := func(issues []ValidationIssue) {
+		sort.SliceStable(issues, func(i, j int) bool { return issues[i].Question < issues[j].Question })
+	}
+	byQuestion(report.Repaired)
+	byQuestion(report.Flagged)
+	if !changed {
+		return output, report, nil
+	}
+	for _, f := range sentences {
+		out.Questions[f.question].Body[f.line] = f.unfill()
+	}
+	return formatOutput(out), report, nil
+}
+
+// checkGrammarAndReport runs checkGrammar for Generate. A failed check is
+// logged and the unchecked output kept.
+func (a *VocabApp) checkGrammarAndReport(s GrammarSettings, output string) string {
+	checked, report, err := checkGrammar(s, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "문법 검사 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:grammar-checked", report)
+	return checked
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Handwritten Answer Sheets (experimental) ---
+
+// maxSheetImageBytes keeps single photos within what vision models accept.
+const maxSheetImageBytes = 20 << 20
+
+const handwritingPrompt = `You read photographed, handwritten answer sheets of an English vocabulary test taken by Korean students.
+For every question number in the list, transcribe exactly what the student wrote, including spelling mistakes; do not correct anything. For multiple-choice questions, give the circled or written choice number (1-5). Use "" when the answer is blank or unreadable.
+Also give your confidence in each reading from 0 to 1, and the student's name if it is written on the sheet.
+Respond with JSON only: {"student": "name or empty", "answers": [{"question": 1, "text": "what was written", "confidence": 0.9}]}`
+
+type handwritingResult struct {
+	Student string `json:"student"`
+	Answers []struct {
+		Question   int     `json:"question"`
+		Text       string  `json:"text"`
+		Confidence float64 `json:"confidence"`
+	} `json:"answers"`
+}
+
+// HandwritingItem is the suggested grade of one question. The teacher checks
+// the readings and confirms them with GradeSubmission.
+type HandwritingItem struct {
+	GradedItem
+	Confidence float64 `json:"confidence"`
+}
+
+// HandwritingGrade is a suggested, not yet stored, grade of a sheet.
+type HandwritingGrade struct {
+	TestID  string            `json:"testId"`
+	Student string            `json:"student"`
+	Items   []HandwritingItem `json:"items"`
+	Score   float64           `json:"score"`
+	Total   float64           `json:"total"`
+}
+
+// sheetImagePart loads a photo as an inline image for the vision model.
+func sheetImagePart(path string) (openai.ChatMessagePart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return openai.ChatMessagePart{}, fmt.Errorf("사진 읽기 오류: %w", err)
+	}
+	if len(data) > maxSheetImageBytes {
+		return openai.ChatMessagePart{}, fmt.Errorf("사진이 너무 큽니다 (20MB 이하): %s", filepath.Base(path))
+	}
+	mimeType := mime.TypeByExtension(strings.ToLower(filepath.Ext(path)))
+	if !strings.HasPrefix(mimeType, "image/") {
+		return openai.ChatMessagePart{}, fmt.Errorf("이미지 파일이 아닙니다: %s", filepath.Base(path))
+	}
+	return openai.ChatMessagePart{
+		Type: openai.ChatMessagePartTypeImageURL,
+		ImageURL: &openai.ChatMessageImageURL{
+			URL:    "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data),
+			Detail: openai.ImageURLDetailHigh,
+		},
+	}, nil
+}
+
+// describeSheetQuestions lists the questions so the model knows which
+// numbers to look for and what kind of answer each one takes.
+func describeSheetQuestions(questions []Question) string {
+	var b strings.Builder
+	b.WriteString("Questions on the sheet:\n")
+	for _, q := range questions {
+		kind := "multiple choice (1-5)"
+		if len(q.Choices) == 0 {
+			kind = "written word"
+		}
+		fmt.Fprintf(&b, "%d: %s\n", q.Number, kind)
+	}
+	return b.String()
+}
+
+// --- Go functions callable from Javascript ---
+
+// SelectAnswerSheets lets the user pick photos of answer sheets.
+func (a *VocabApp) SelectAnswerSheets() ([]string, error) {
+	return runtime.OpenMultipleFilesDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "답안지 사진 선택",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "이미지 (*.jpg, *.png, *.webp)",
+				Pattern:     "*.jpg;*.jpeg;*.png;*.webp",
+			},
+		},
+	})
+}
+
+// GradeHandwriting reads one student's handwritten answer sheet (one or more
+// photos) with a vision model and grades the readings with the same rules as
+// GradeSubmission. Nothing is stored: the teacher corrects the readings and
+// confirms them with GradeSubmission.
+func (a *VocabApp) GradeHandwriting(testID string, modelID string, imagePaths []string) (HandwritingGrade, error) {
+	if a.client == nil {
+		return HandwritingGrade{}, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	if len(imagePaths) == 0 {
+		return HandwritingGrade{}, fmt.Errorf("답안지 사진을 선택하세요")
+	}
+	if !a.capabilities(modelID).Vision {
+		return HandwritingGrade{}, fmt.Errorf("이 모델은 이미지 입력을 지원하지 않습니다: '%s'", modelID)
+	}
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return HandwritingGrade{}, err
+	}
+	out := parseOutput(test.Content)
+	if !out.HasKey {
+		return HandwritingGrade{}, fmt.Errorf("[정답] 섹션이 없어 채점할 수 없습니다")
+	}
+
+	parts := []openai.ChatMessagePart{{Type: openai.ChatMessagePartTypeText, Text: describeSheetQuestions(out.Questions)}}
+	for _, path := range imagePaths {
+		part, err := sheetImagePart(path)
+		if err != nil {
+			return HandwritingGrade{}, err
+		}
+		parts = append(parts, part)
+	}
+	var read handwritingResult
+	err = a.callChatGPTJSONMessages(modelID, []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: handwritingPrompt},
+		{Role: openai.ChatMessageRoleUser, MultiContent: parts},
+	}, &read)
+	if err != nil {
+		return HandwritingGrade{}, err
+	}
+
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return HandwritingGrade{}, err
+	}
+	points := a.questionPoints(test.Content, settings.Points)
+	answers := map[int]int{}
+	for i, ans := range read.Answers {
+		answers[ans.Question] = i
+	}
+	grade := HandwritingGrade{TestID: test.ID, Student: strings.TrimSpace(read.Student)}
+	for _, q := range out.Questions {
+		item := HandwritingItem{GradedItem: GradedItem{Question: q.Number, Points: points[q.Number]}}
+		if i, ok := answers[q.Number]; ok {
+			item.Response = strings.TrimSpace(read.Answers[i].Text)
+			item.Confidence = read.Answers[i].Confidence
+		}
+		item.Credit, item.Reason = gradeResponse(q, item.Response, test.Accepted[q.Number], settings.Grading)
+		item.Score = item.Credit * item.Points
+		grade.Items = append(grade.Items, item)
+		grade.Score += item.Score
+		grade.Total += item.Points
+	}
+	return grade, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Inflected Forms ---
+
+// irregularForms lists the irregular past / past participle forms (and a few
+// irregular plurals) by base form.
+var irregularForms = map[string][]string{
+	"be": {"am", "is", "are", "was", "were", "been", "being"}, "have": {"has", "had"}, "do": {"does", "did", "done"},
+	"go": {"goes", "went", "gone"}, "arise": {"arose", "arisen"}, "awake": {"awoke", "awoken"},
+	"bear": {"bore", "borne", "born"}, "beat": {"beaten"}, "become": {"became"}, "begin": {"began", "begun"},
+	"bend": {"bent"}, "bet": {"bet"}, "bind": {"bound"}, "bite": {"bit", "bitten"}, "bleed": {"bled"},
+	"blow": {"blew", "blown"}, "break": {"broke", "broken"}, "breed": {"bred"}, "bring": {"brought"},
+	"build": {"built"}, "burn": {"burnt"}, "burst": {"burst"}, "buy": {"bought"}, "cast": {"cast"},
+	"catch": {"caught"}, "choose": {"chose", "chosen"}, "cling": {"clung"}, "come": {"came"}, "cost": {"cost"},
+	"creep": {"crept"}, "cut": {"cut"}, "deal": {"dealt"}, "dig": {"dug"}, "draw": {"drew", "drawn"},
+	"dream": {"dreamt"}, "drink": {"drank", "drunk"}, "drive": {"drove", "driven"}, "eat": {"ate", "eaten"},
+	"fall": {"fell", "fallen"}, "feed": {"fed"}, "feel": {"felt"}, "fight": {"fought"}, "find": {"found"},
+	"flee": {"fled"}, "fly": {"flew", "flown"}, "forbid": {"forbade", "forbidden"}, "forget": {"forgot", "forgotten"},
+	"forgive": {"forgave", "forgiven"}, "freeze": {"froze", "frozen"}, "get": {"got", "gotten"},
+	"give": {"gave", "given"}, "grind": {"ground"}, "grow": {"grew", "grown"}, "hang": {"hung"},
+	"hear": {"heard"}, "hide": {"hid", "hidden"}, "hit": {"hit"}, "hold": {"held"}, "hurt": {"hurt"},
+	"keep": {"kept"}, "kneel": {"knelt"}, "know": {"knew", "known"}, "lay": {"laid"}, "lead": {"led"},
+	"lean": {"leant"}, "leap": {"leapt"}, "learn": {"learnt"}, "leave": {"left"}, "lend": {"lent"},
+	"let": {"let"}, "lie": {"lay", "lain", "lying"}, "light": {"lit"}, "lose": {"lost"}, "make": {"made"},
+	"mean": {"meant"}, "meet": {"met"}, "mislead": {"misled"}, "overcome": {"overcame"}, "pay": {"paid"},
+	"put": {"put"}, "quit": {"quit"}, "read": {"read"}, "ride": {"rode", "ridden"}, "ring": {"rang", "rung"},
+	"rise": {"rose", "risen"}, "run": {"ran"}, "say": {"said"}, "see": {"saw", "seen"}, "seek": {"sought"},
+	"sell": {"sold"}, "send": {"sent"}, "set": {"set"}, "shake": {"shook", "shaken"}, "shed": {"shed"},
+	"shine": {"shone"}, "shoot": {"shot"}, "show": {"shown"}, "shrink": {"shrank", "shrunk"}, "shut": {"shut"},
+	"sing": {"sang", "sung"}, "sink": {"sank", "sunk"}, "sit": {"sat"}, "sleep": {"slept"}, "slide": {"slid"},
+	"speak": {"spoke", "spoken"}, "speed": {"sped"}, "spend": {"spent"}, "spin": {"spun"}, "split": {"split"},
+	"spread": {"spread"}, "spring": {"sprang", "sprung"}, "stand": {"stood"}, "steal": {"stole", "stolen"},
+	"stick": {"stuck"}, "sting": {"stung"}, "strike": {"struck", "stricken"}, "strive": {"strove", "striven"},
+	"swear": {"swore", "sworn"}, "sweep": {"swept"}, "swim": {"swam", "swum"}, "swing": {"swung"},
+	"take": {"took", "taken"}, "teach": {"taught"}, "tear": {"tore", "torn"}, "tell": {"told"},
+	"think": {"thought"}, "throw": {"threw", "thrown"}, "undergo": {"underwent", "undergone"},
+	"understand": {"understood"}, "undertake": {"undertook", "undertaken"}, "uphold": {"upheld"},
+	"wake": {"woke", "woken"}, "wear": {"wore", "worn"}, "weave": {"wove", "woven"}, "weep": {"wept"},
+	"win": {"won"}, "wind": {"wound"}, "withdraw": {"withdrew", "withdrawn"}, "withstand": {"withstood"},
+	"write": {"wrote", "written"},
+	"child": {"children"}, "man": {"men"}, "woman": {"women"}, "foot": {"feet"}, "tooth": {"teeth"},
+	"mouse": {"mice"}, "person": {"people"}, "phenomenon": {"phenomena"}, "criterion": {"criteria"},
+	"analysis": {"analyses"}, "crisis": {"crises"}, "thesis": {"theses"}, "hypothesis": {"hypotheses"},
+	"good": {"better", "best"}, "bad": {"worse", "worst"}, "far": {"farther", "further", "farthest", "furthest"},
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// regularForms builds the regular -s/-ed/-ing/-er/-est/-ly forms of a word,
+// including the usual spelling changes (e-drop, y→i, doubled consonant).
+func regularForms(base string) []string {
+	forms := []string{base + "s", base + "es", base + "ed", base + "ing", base + "er", base + "est", base + "ly"}
+	n := len(base)
+	if n < 2 {
+		return forms
+	}
+	last := base[n-1]
+	switch {
+	case last == 'e':
+		stem := base[:n-1]
+		forms = append(forms, base+"d", stem+"ing", base+"r", base+"st")
+		if strings.HasSuffix(base, "ie") {
+			forms = append(forms, base[:n-2]+"ying")
+		}
+	case last == 'y' && !isVowel(base[n-2]):
+		stem := base[:n-1]
+		forms = append(forms, stem+"ies", stem+"ied", stem+"ier", stem+"iest", stem+"ily")
+	case !isVowel(last) && isVowel(base[n-2]) && (n < 3 || !isVowel(base[n-3])) && strings.IndexByte("wxy", last) < 0:
+		double := base + string(last)
+		forms = append(forms, double+"ed", double+"ing", double+"er", double+"est")
+	}
+	if strings.HasSuffix(base, "ic") {
+		forms = append(forms, base+"ked", base+"king")
+	}
+	return forms
+}
+
+// isInflectionOf reports whether form is the base word itself or one of its
+// inflected forms. Multi-word entries (phrasal verbs) are checked word by
+// word, so "gave up" is a form of "give up".
+func isInflectionOf(form string, base string) bool {
+	form, base = strings.ToLower(strings.TrimSpace(form)), strings.ToLower(strings.TrimSpace(base))
+	fw, bw := strings.Fields(form), strings.Fields(base)
+	if len(fw) != len(bw) || len(fw) == 0 {
+		return false
+	}
+	for i := range fw {
+		if fw[i] == bw[i] {
+			continue
+		}
+		if i > 0 {
+			// Only the head word of a phrase inflects.
+			return false
+		}
+		if !isSingleInflection(fw[i], bw[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSingleInflection(form string, base string) bool {
+	for _, f := range irregularForms[base] {
+		if f == form {
+			return true
+		}
+	}
+	for _, f := range regularForms(base) {
+		if f == form {
+			return true
+		}
+	}
+	return false
+}
+
+// checkInflections reports key entries whose listed forms are not
+// inflections of the answer, or questions that were missing the list.
+func checkInflections(output string) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, q := range parseOutput(output).Questions {
+		if q.Answer < 1 || q.Answer > len(q.Choices) {
+			continue
+		}
+		answer := q.Choices[q.Answer-1]
+		for _, form := range q.Forms {
+			if !isInflectionOf(form, answer) {
+				issues = append(issues, ValidationIssue{Question: q.Number, Message: fmt.Sprintf("예문의 '%s'은(는) 정답 '%s'의 변화형이 아닙니다", form, answer)})
+			}
+		}
+	}
+	return issues
+}
+
+// reportInflections emits "generate:inflections-checked" with the problems
+// checkInflections found.
+func (a *VocabApp) reportInflections(output string) {
+	issues := checkInflections(output)
+	if len(issues) > 0 {
+		runtime.LogErrorf(a.ctx, "활용형 검사: 문제 %d건", len(issues))
+	}
+	runtime.EventsEmit(a.ctx, "generate:inflections-checked", issues)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Multiple Instances ---
+
+// Several windows of the app (one process each) may share the data
+// directory: one edits a list while another generates. Every store update is
+// a read-modify-write, so storeLock extends a.mu across processes with an OS
+// file lock, and generationQueue lets one instance generate at a time.
+
+const (
+	storeLockFile = ".store.lock"
+	queueLockFile = ".queue.lock"
+)
+
+// errLockBusy is returned by lockFile without wait when another process
+// holds the lock.
+var errLockBusy = errors.New("lock busy")
+
+func openLockFile(name string) (*os.File, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_RDWR, 0644)
+}
+
+// storeLock guards the JSON stores within the process and, through a lock
+// file, against other instances. If the lock file cannot be opened it falls
+// back to guarding this process only.
+type storeLock struct {
+	mu   sync.Mutex
+	file *os.File
+	held bool
+}
+
+func (l *storeLock) Lock() {
+	l.mu.Lock()
+	if l.file == nil {
+		f, err := openLockFile(storeLockFile)
+		if err != nil {
+			return
+		}
+		l.file = f
+	}
+	l.held = lockFile(l.file, true) == nil
+}
+
+func (l *storeLock) Unlock() {
+	if l.held {
+		unlockFile(l.file)
+		l.held = false
+	}
+	l.mu.Unlock()
+}
+
+// generationQueue holds the queue lock file while any generation of this
+// instance runs. Generations within one instance still run in parallel;
+// other instances wait for their turn.
+type generationQueue struct {
+	mu      sync.Mutex
+	holders int
+	file    *os.File
+}
+
+// enterQueue waits until this instance may generate. Emits "generate:queued"
+// when another instance is generating.
+func (a *VocabApp) enterQueue() {
+	q := &a.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.holders++
+	if q.holders > 1 {
+		return
+	}
+	if q.file == nil {
+		f, err := openLockFile(queueLockFile)
+		if err != nil {
+			runtime.LogErrorf(a.ctx, "생성 대기열 잠금 파일 오류: %v", err)
+			return
+		}
+		q.file = f
+	}
+	if err := lockFile(q.file, false); err == errLockBusy {
+		runtime.EventsEmit(a.ctx, "generate:queued", nil)
+		err = lockFile(q.file, true)
+		if err != nil {
+			runtime.LogErrorf(a.ctx, "생성 대기열 잠금 오류: %v", err)
+		}
+	}
+}
+
+// leaveQueue lets other instances generate once the last generation of this
+// instance has finished.
+func (a *VocabApp) leaveQueue() {
+	q := &a.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.holders--
+	if q.holders == 0 && q.file != nil {
+		unlockFile(q.file)
+	}
+}
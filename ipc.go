@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Automation IPC ---
+
+const defaultIPCPort = 8767
+
+// ipcProtocolVersion is the version of the automation socket protocol,
+// described in the README ("자동화 소켓 프로토콜"). It changes whenever a
+// message or method changes incompatibly; adding a method or an optional
+// field does not change it.
+const ipcProtocolVersion = 1
+
+// ipcMethods are the methods of ipcProtocolVersion.
+var ipcMethods = []string{"hello", "parse", "generate", "validate", "export"}
+
+// IPCSettings configures the automation socket: newline-delimited JSON
+// requests over a localhost TCP connection, answered with a stream of
+// progress messages and a final result. It uses the REST API token.
+type IPCSettings struct {
+	Enabled bool `json:"enabled"`
+	Port    int  `json:"port"` // default 8767
+}
+
+func (s IPCSettings) port() int {
+	if s.Port == 0 {
+		return defaultIPCPort
+	}
+	return s.Port
+}
+
+func validateIPCSettings(s IPCSettings, api APISettings) error {
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("자동화 포트는 1에서 65535 사이여야 합니다")
+	}
+	if s.Enabled && len(api.Token) < 16 {
+		return fmt.Errorf("자동화 소켓을 사용하려면 API 토큰을 먼저 만드세요")
+	}
+	return nil
+}
+
+// ipcRequest is one line sent by a client. Method is one of ipcMethods;
+// Params holds the matching REST API request. Version is the protocol the
+// client speaks, 0 for the current one.
+type ipcRequest struct {
+	Version int             `json:"v,omitempty"`
+	ID      int             `json:"id"`
+	Token   string          `json:"token"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// ipcMessage is one line sent back: progress updates while a generation
+// runs, then either Result or Error.
+type ipcMessage struct {
+	Version  int         `json:"v"`
+	ID       int         `json:"id"`
+	Progress *ETAUpdate  `json:"progress,omitempty"`
+	Result   interface{} `json:"result,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// ipcHello is the result of "hello", for clients to check the protocol
+// before sending other requests.
+type ipcHello struct {
+	Protocol   int      `json:"protocol"`
+	AppVersion string   `json:"appVersion"`
+	Methods    []string `json:"methods"`
+}
+
+type ipcValidateRequest struct {
+	Output       string `json:"output"`
+	Vocab        string `json:"vocab"`
+	QuestionType string `json:"questionType"`
+	NumSentences int    `json:"numSentences"`
+}
+
+type ipcExportResult struct {
+	Filename string `json:"filename"`
+	MIMEType string `json:"mimeType"`
+	Data     []byte `json:"data"` // base64 in JSON
+}
+
+// ipcServer is the running automation socket.
+type ipcServer struct {
+	mu       sync.Mutex
+	listener net.Listener
+}
+
+// ipcConn serializes the messages of concurrent requests on one connection.
+type ipcConn struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (c *ipcConn) send(m ipcMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m.Version = ipcProtocolVersion
+	c.enc.Encode(m)
+}
+
+// callIPC runs one request; progress is called with the ETA updates of a
+// generation.
+func (a *VocabApp) callIPC(req ipcRequest, progress func(ETAUpdate)) (interface{}, error) {
+	decode := func(v interface{}) error {
+		if err := json.Unmarshal(req.Params, v); err != nil {
+			return fmt.Errorf("잘못된 요청입니다: %w", err)
+		}
+		return nil
+	}
+	switch req.Method {
+	case "hello":
+		return ipcHello{Protocol: ipcProtocolVersion, AppVersion: appVersion, Methods: ipcMethods}, nil
+	case "parse":
+		var p apiParseRequest
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		entries := []apiEntry{}
+		for _, e := range parseVocabBlock(p.Vocab) {
+			entries = append(entries, apiEntry{Word: e.Word, Senses: e.Senses})
+		}
+		return entries, nil
+	case "generate":
+		var g apiGenerateRequest
+		if err := decode(&g); err != nil {
+			return nil, err
+		}
+		// Progress comes from the "generate:eta" events of runs with the
+		// same model and question type.
+		off := runtime.EventsOn(a.ctx, "generate:eta", func(data ...interface{}) {
+			if len(data) == 1 {
+				if u, ok := data[0].(ETAUpdate); ok && u.Model == g.Model && u.QuestionType == g.QuestionType {
+					progress(u)
+				}
+			}
+		})
+		if off != nil {
+			defer off()
+		}
+		return a.Generate(g.Vocab, g.Model, g.QuestionType, g.NumSentences)
+	case "validate":
+		var v ipcValidateRequest
+		if err := decode(&v); err != nil {
+			return nil, err
+		}
+		return validateOutput(v.Output, parseVocabBlock(v.Vocab), v.QuestionType, v.NumSentences), nil
+	case "export":
+		var e apiExportRequest
+		if err := decode(&e); err != nil {
+			return nil, err
+		}
+		title := strings.TrimSpace(e.Title)
+		if title == "" {
+			title = "vocab-test"
+		}
+		opts, err := a.testExportOptions(title, e.Content)
+		if err != nil {
+			return nil, err
+		}
+		data, ext, mimeType, err := renderExport(e.Format, e.Content, opts)
+		if err != nil {
+			return nil, err
+		}
+		return ipcExportResult{Filename: title + ext, MIMEType: mimeType, Data: data}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 요청입니다: '%s'", req.Method)
+	}
+}
+
+// serveIPC reads requests from one connection until it closes; requests run
+// concurrently and their messages carry the request ID.
+func (a *VocabApp) serveIPC(conn net.Conn, token string) {
+	defer conn.Close()
+	c := &ipcConn{enc: json.NewEncoder(conn)}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 10<<20)
+	var wg sync.WaitGroup
+	for scanner.Scan() {
+		var req ipcRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			c.send(ipcMessage{Error: "invalid request: " + err.Error()})
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(req.Token), []byte(token)) != 1 {
+			c.send(ipcMessage{ID: req.ID, Error: "invalid token"})
+			continue
+		}
+		if req.Version != 0 && req.Version != ipcProtocolVersion {
+			c.send(ipcMessage{ID: req.ID, Error: fmt.Sprintf("unsupported protocol version %d (server speaks %d)", req.Version, ipcProtocolVersion)})
+			continue
+		}
+		wg.Add(1)
+		go func(req ipcRequest) {
+			defer wg.Done()
+			result, err := a.callIPC(req, func(u ETAUpdate) { c.send(ipcMessage{ID: req.ID, Progress: &u}) })
+			if err != nil {
+				c.send(ipcMessage{ID: req.ID, Error: err.Error()})
+				return
+			}
+			c.send(ipcMessage{ID: req.ID, Result: result})
+		}(req)
+	}
+	wg.Wait()
+}
+
+// --- Go functions callable from Javascript ---
+
+// StartIPCServer opens the automation socket on localhost and returns its
+// address.
+func (a *VocabApp) StartIPCServer() (string, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if len(settings.API.Token) < 16 {
+		return "", fmt.Errorf("API 토큰을 먼저 만드세요")
+	}
+	addr := fmt.Sprintf("127.0.0.1:%d", settings.IPC.port())
+	a.ipc.mu.Lock()
+	defer a.ipc.mu.Unlock()
+	if a.ipc.listener != nil {
+		return addr, nil
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("자동화 소켓 시작 오류: %w", err)
+	}
+	a.ipc.listener = listener
+	token := settings.API.Token
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go a.serveIPC(conn, token)
+		}
+	}()
+	return addr, nil
+}
+
+// StopIPCServer closes the automation socket. Connections already open
+// finish their requests.
+func (a *VocabApp) StopIPCServer() error {
+	a.ipc.mu.Lock()
+	defer a.ipc.mu.Unlock()
+	if a.ipc.listener == nil {
+		return nil
+	}
+	err := a.ipc.listener.Close()
+	a.ipc.listener = nil
+	return err
+}
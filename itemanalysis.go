@@ -0,0 +1,174 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// --- Item Analysis ---
+
+// discriminationGroup is the share of students in the upper and lower groups
+// of the discrimination index.
+const discriminationGroup = 0.27
+
+// ItemStat is the classical analysis of one question over a set of results.
+type ItemStat struct {
+	Question  int `json:"question"`
+	Responses int `json:"responses"`
+	// Difficulty is the mean credit (the p-value): 1 means everyone got it.
+	Difficulty float64 `json:"difficulty"`
+	// Discrimination is the upper-lower index: the mean credit of the top 27%
+	// of students by total score minus that of the bottom 27%.
+	Discrimination float64 `json:"discrimination"`
+	// PointBiserial correlates the credit with the rest of the total score.
+	PointBiserial float64 `json:"pointBiserial"`
+	// Choices counts how often each choice was picked, in choice order;
+	// Omitted counts blank or unreadable responses.
+	Choices    []int     `json:"choices,omitempty"`
+	Omitted    int       `json:"omitted"`
+	AnalyzedAt time.Time `json:"analyzedAt"`
+}
+
+func itemResult(r GradeResult, question int) GradedItem {
+	for _, item := range r.Items {
+		if item.Question == question {
+			return item
+		}
+	}
+	return GradedItem{Question: question}
+}
+
+// correlation is the Pearson correlation of x and y, 0 when either does not
+// vary.
+func correlation(x, y []float64) float64 {
+	n := float64(len(x))
+	if n < 2 {
+		return 0
+	}
+	var mx, my float64
+	for i := range x {
+		mx += x[i]
+		my += y[i]
+	}
+	mx, my = mx/n, my/n
+	var sxy, sxx, syy float64
+	for i := range x {
+		sxy += (x[i] - mx) * (y[i] - my)
+		sxx += (x[i] - mx) * (x[i] - mx)
+		syy += (y[i] - my) * (y[i] - my)
+	}
+	if sxx == 0 || syy == 0 {
+		return 0
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+// analyzeItems computes the item statistics of every question from one
+// result per student.
+func analyzeItems(results []GradeResult, questions []Question) []ItemStat {
+	ranked := append([]GradeResult(nil), results...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	group := int(math.Round(float64(len(ranked)) * discriminationGroup))
+	if group < 1 {
+		group = 1
+	}
+	meanCredit := func(rs []GradeResult, question int) float64 {
+		if len(rs) == 0 {
+			return 0
+		}
+		sum := 0.0
+		for _, r := range rs {
+			sum += itemResult(r, question).Credit
+		}
+		return sum / float64(len(rs))
+	}
+
+	now := time.Now()
+	stats := make([]ItemStat, 0, len(questions))
+	for _, q := range questions {
+		s := ItemStat{Question: q.Number, Responses: len(results), Difficulty: meanCredit(results, q.Number), AnalyzedAt: now}
+		if len(ranked) >= 2 {
+			s.Discrimination = meanCredit(ranked[:group], q.Number) - meanCredit(ranked[len(ranked)-group:], q.Number)
+		}
+		credits := make([]float64, len(results))
+		rest := make([]float64, len(results))
+		if len(q.Choices) > 0 {
+			s.Choices = make([]int, len(q.Choices))
+		}
+		for i, r := range results {
+			item := itemResult(r, q.Number)
+			credits[i] = item.Credit
+			rest[i] = r.Score - item.Score
+			if c := chosenIndex(q, item.Response); c > 0 && c <= len(s.Choices) {
+				s.Choices[c-1]++
+			} else if len(q.Choices) > 0 {
+				s.Omitted++
+			}
+		}
+		s.PointBiserial = correlation(credits, rest)
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// storeItemStats attaches the statistics to the bank questions that came from
+// the test and flags questions whose statistics look wrong. Must be called
+// with a.mu held.
+func storeItemStats(testID string, stats []ItemStat) error {
+	bank, err := loadBank()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for i := range bank {
+		if bank[i].TestID != testID {
+			continue
+		}
+		for j := range stats {
+			if stats[j].Question == bank[i].Question.Number {
+				bank[i].Stats = &stats[j]
+				if reason := statsReviewReason(stats[j]); reason != "" {
+					bank[i].flag(reason)
+				}
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return saveJSON(bankFile, bank)
+}
+
+// analyzeTest recomputes the item statistics of a test from the latest result
+// of every student and stores them in the bank. Must be called with a.mu
+// held.
+func analyzeTest(test SavedTest, grades []GradeResult) ([]ItemStat, error) {
+	var results []GradeResult
+	for _, g := range grades {
+		if g.TestID == test.ID {
+			results = append(results, g)
+		}
+	}
+	stats := analyzeItems(latestResults(results), parseOutput(test.Content).Questions)
+	return stats, storeItemStats(test.ID, stats)
+}
+
+// --- Go functions callable from Javascript ---
+
+// AnalyzeItems returns the item statistics of a graded test and refreshes
+// them on its bank questions.
+func (a *VocabApp) AnalyzeItems(testID string) ([]ItemStat, error) {
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	grades, err := loadGrades()
+	if err != nil {
+		return nil, err
+	}
+	return analyzeTest(test, grades)
+}
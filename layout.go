@@ -0,0 +1,60 @@
+package main
+
+import "fmt"
+
+// --- Export Layouts ---
+
+// ExportLayout is a document layout preset for exports.
+type ExportLayout struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+
+	halfPts int    // body font size in half-points, 0 for the default
+	spacing int    // line spacing in 240ths of a line, 0 for the default
+	font    string // forced font family, "" to keep the chosen font
+}
+
+var exportLayouts = []ExportLayout{
+	{ID: "standard", Label: "기본"},
+	// Large print for students with low vision or dyslexia: 16pt sans-serif,
+	// 1.5 line spacing, one question element per line.
+	{ID: "large-print", Label: "큰 글씨 (읽기 지원)", halfPts: 32, spacing: 360, font: "맑은 고딕"},
+}
+
+func findExportLayout(id string) (ExportLayout, bool) {
+	for _, l := range exportLayouts {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return ExportLayout{}, false
+}
+
+func validateExportLayout(id string) error {
+	if _, ok := findExportLayout(id); id != "" && !ok {
+		return fmt.Errorf("지원하지 않는 문서 레이아웃입니다: '%s'", id)
+	}
+	return nil
+}
+
+// apply sets the preset's font and spacing on a document.
+func (l ExportLayout) apply(doc *docxBuilder) {
+	if l.halfPts > 0 {
+		doc.halfPts = l.halfPts
+	}
+	if l.spacing > 0 {
+		doc.spacing = l.spacing
+	}
+	if l.font != "" && l.font != doc.font {
+		doc.font = l.font
+		// the embedded font is no longer used
+		doc.fontData = nil
+	}
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetExportLayouts lists the selectable export layouts for the settings UI.
+func (a *VocabApp) GetExportLayouts() []ExportLayout {
+	return exportLayouts
+}
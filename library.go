@@ -0,0 +1,184 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Saved Word Lists & Tests ---
+
+const (
+	wordListsFile = "wordlists.json"
+	testsFile     = "tests.json"
+)
+
+type SavedWordList struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Entries   []VocabPair `json:"entries"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+	DeletedAt *time.Time  `json:"deletedAt,omitempty"`
+}
+
+// SavedTest is one generated test as it was produced (or last edited).
+type SavedTest struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	QuestionType string     `json:"questionType"`
+	Model        string     `json:"model"`
+	Content      string     `json:"content"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
+	// Points overrides the points of single questions, keyed by number.
+	Points map[int]float64 `json:"points,omitempty"`
+	// Accepted lists extra accepted answers for production questions.
+	Accepted map[int][]string `json:"accepted,omitempty"`
+	// Locked is set while the test is finalized (see LockTest); Audit lists
+	// every lock and unlock.
+	Locked *TestLock        `json:"locked,omitempty"`
+	Audit  []TestAuditEntry `json:"audit,omitempty"`
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func loadWordLists() ([]SavedWordList, error) {
+	var lists []SavedWordList
+	return lists, loadJSON(wordListsFile, &lists)
+}
+
+func loadTests() ([]SavedTest, error) {
+	var tests []SavedTest
+	return tests, loadJSON(testsFile, &tests)
+}
+
+// --- Go functions callable from Javascript ---
+
+// SaveWordList stores the working list under name, replacing a live list
+// with the same name.
+func (a *VocabApp) SaveWordList(name string) (SavedWordList, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return SavedWordList{}, fmt.Errorf("단어장 이름을 입력하세요")
+	}
+	entries := a.GetWorkingList().Entries
+	if len(entries) == 0 {
+		return SavedWordList{}, fmt.Errorf("저장할 단어가 없습니다")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	lists, err := loadWordLists()
+	if err != nil {
+		return SavedWordList{}, err
+	}
+	now := time.Now()
+	for i := range lists {
+		if lists[i].Name == name && lists[i].DeletedAt == nil {
+			lists[i].Entries = entries
+			lists[i].UpdatedAt = now
+			return lists[i], saveJSON(wordListsFile, lists)
+		}
+	}
+	list := SavedWordList{ID: newID(), Name: name, Entries: entries, CreatedAt: now, UpdatedAt: now}
+	lists = append(lists, list)
+	return list, saveJSON(wordListsFile, lists)
+}
+
+// ListWordLists returns the saved lists that are not in the trash.
+func (a *VocabApp) ListWordLists() ([]SavedWordList, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	lists, err := loadWordLists()
+	if err != nil {
+		return nil, err
+	}
+	live := []SavedWordList{}
+	for _, l := range lists {
+		if l.DeletedAt == nil {
+			live = append(live, l)
+		}
+	}
+	return live, nil
+}
+
+// OpenWordList loads a saved list into the working list.
+func (a *VocabApp) OpenWordList(id string) (ListState, error) {
+	a.mu.Lock()
+	lists, err := loadWordLists()
+	a.mu.Unlock()
+	if err != nil {
+		return ListState{}, err
+	}
+	for _, l := range lists {
+		if l.ID == id && l.DeletedAt == nil {
+			return a.SetWorkingList(formatVocabBlock(l.Entries)), nil
+		}
+	}
+	return ListState{}, fmt.Errorf("단어장을 찾을 수 없습니다")
+}
+
+func (a *VocabApp) SaveTest(name string, questionType string, modelID string, content string) (SavedTest, error) {
+	if strings.TrimSpace(content) == "" {
+		return SavedTest{}, fmt.Errorf("저장할 내용이 없습니다")
+	}
+	test := SavedTest{
+		ID:           newID(),
+		Name:         strings.TrimSpace(name),
+		QuestionType: questionType,
+		Model:        modelID,
+		Content:      content,
+		CreatedAt:    time.Now(),
+	}
+	if test.Name == "" {
+		test.Name = fmt.Sprintf("%s %s", questionType, test.CreatedAt.Format("2006-01-02 15:04"))
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests, err := loadTests()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	tests = append(tests, test)
+	return test, saveJSON(testsFile, tests)
+}
+
+// ListTests returns saved tests that are not in the trash, newest first.
+func (a *VocabApp) ListTests() ([]SavedTest, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests, err := loadTests()
+	if err != nil {
+		return nil, err
+	}
+	live := []SavedTest{}
+	for i := len(tests) - 1; i >= 0; i-- {
+		if tests[i].DeletedAt == nil {
+			live = append(live, tests[i])
+		}
+	}
+	return live, nil
+}
+
+func (a *VocabApp) GetTest(id string) (SavedTest, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests, err := loadTests()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	for _, t := range tests {
+		if t.ID == id && t.DeletedAt == nil {
+			return t, nil
+		}
+	}
+	return SavedTest{}, fmt.Errorf("시험지를 찾을 수 없습니다")
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Local Inference (llama.cpp / llamafile) ---
+
+// ProviderLocal runs models on this computer with an external llama.cpp
+// server (llama-server) or a llamafile, which both speak the OpenAI API.
+// Nothing leaves the machine, at the price of weaker questions.
+const ProviderLocal = "local"
+
+// localModelsDir holds downloaded GGUF model files in the data directory.
+const localModelsDir = "models"
+
+const (
+	defaultLocalPort    = 8080
+	defaultLocalContext = 8192
+	// localStartTimeout is how long a freshly started server may take to
+	// load its model before StartLocalServer gives up.
+	localStartTimeout = 3 * time.Minute
+)
+
+// LocalSettings configures a ProviderLocal profile. ServerPath is the
+// llama-server or llamafile executable; Model is a file in the models folder
+// (empty for a llamafile with built-in weights).
+type LocalSettings struct {
+	ServerPath  string `json:"serverPath"`
+	Model       string `json:"model"`
+	Port        int    `json:"port"`
+	ContextSize int    `json:"contextSize"`
+	// GPULayers is the number of layers offloaded to the GPU (0 = CPU only).
+	GPULayers int `json:"gpuLayers"`
+}
+
+func (l LocalSettings) port() int {
+	if l.Port == 0 {
+		return defaultLocalPort
+	}
+	return l.Port
+}
+
+func (l LocalSettings) baseURL() string {
+	return fmt.Sprintf("http://127.0.0.1:%d/v1", l.port())
+}
+
+func validateLocalSettings(name string, l *LocalSettings) error {
+	if l == nil || strings.TrimSpace(l.ServerPath) == "" {
+		return fmt.Errorf("%s: llama.cpp 서버 또는 llamafile 실행 파일을 지정하세요", name)
+	}
+	if l.Port < 0 || l.Port > 65535 {
+		return fmt.Errorf("%s: 잘못된 포트 번호입니다: %d", name, l.Port)
+	}
+	if l.ContextSize < 0 || l.GPULayers < 0 {
+		return fmt.Errorf("%s: 컨텍스트 크기와 GPU 레이어 수는 0 이상이어야 합니다", name)
+	}
+	if l.Model != "" && filepath.Base(l.Model) != l.Model {
+		return fmt.Errorf("%s: 모델은 모델 폴더의 파일 이름으로 지정하세요", name)
+	}
+	return nil
+}
+
+// localModelPath resolves a model file name inside the models folder.
+func localModelPath(name string) (string, error) {
+	base, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	if name == "" || filepath.Base(name) != name {
+		return "", fmt.Errorf("잘못된 모델 파일 이름입니다: '%s'", name)
+	}
+	return filepath.Join(base, localModelsDir, name), nil
+}
+
+// localProcess is the llama.cpp server started by the app, and the model
+// download in progress.
+type localProcess struct {
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	download context.CancelFunc
+}
+
+// trustedServerPath resolves the server executable of l and checks that the
+// user approved it, as it is now, with TrustLocalServer. Unapproved servers
+// are never run, so a synced or restored setting cannot start a program.
+func trustedServerPath(l LocalSettings) (string, error) {
+	serverPath, err := filepath.Abs(l.ServerPath)
+	if err != nil {
+		return "", err
+	}
+	hash, err := hashFiles(serverPath)
+	if err != nil {
+		return "", fmt.Errorf("로컬 모델 서버 실행 파일을 읽을 수 없습니다: %w", err)
+	}
+	trusted, err := isTrusted(trustLocalServer, serverPath, hash)
+	if err != nil {
+		return "", err
+	}
+	if !trusted {
+		return "", errServerNotTrusted
+	}
+	return serverPath, nil
+}
+
+var errServerNotTrusted = fmt.Errorf("로컬 모델 서버 실행 파일을 먼저 승인하세요")
+
+// serverArgs builds the command line of the server.
+func serverArgs(l LocalSettings, modelPath string) []string {
+	var args []string
+	if strings.Contains(strings.ToLower(filepath.Base(l.ServerPath)), "llamafile") {
+		args = append(args, "--server", "--nobrowser")
+	}
+	if modelPath != "" {
+		args = append(args, "-m", modelPath)
+	}
+	ctxSize := l.ContextSize
+	if ctxSize == 0 {
+		ctxSize = defaultLocalContext
+	}
+	args = append(args, "--host", "127.0.0.1", "--port", strconv.Itoa(l.port()), "-c", strconv.Itoa(ctxSize))
+	if l.GPULayers > 0 {
+		args = append(args, "-ngl", strconv.Itoa(l.GPULayers))
+	}
+	return args
+}
+
+// waitForServer polls the server's health endpoint until it has loaded the
+// model.
+func waitForServer(l LocalSettings, exited <-chan error) error {
+	healthURL := fmt.Sprintf("http://127.0.0.1:%d/health", l.port())
+	deadline := time.Now().Add(localStartTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("로컬 모델 서버가 종료되었습니다: %v", err)
+		case <-time.After(time.Second):
+		}
+		resp, err := http.Get(healthURL)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return nil
+		}
+	}
+	return fmt.Errorf("로컬 모델 서버가 %v 안에 준비되지 않았습니다", localStartTimeout)
+}
+
+// LocalModel is a downloaded model file.
+type LocalModel struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// DownloadProgress is emitted as "local:download" while a model downloads.
+type DownloadProgress struct {
+	Name     string `json:"name"`
+	Received int64  `json:"received"`
+	Total    int64  `json:"total"` // -1 when the server did not say
+}
+
+// progressWriter counts written bytes and emits throttled progress events.
+type progressWriter struct {
+	a        *VocabApp
+	progress DownloadProgress
+	last     time.Time
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.progress.Received += int64(len(p))
+	if time.Since(w.last) > 500*time.Millisecond {
+		w.last = time.Now()
+		runtime.EventsEmit(w.a.ctx, "local:download", w.progress)
+	}
+	return len(p), nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// StartLocalServer starts the llama.cpp server of the active local profile
+// and waits until the model is loaded. A running server is left alone.
+func (a *VocabApp) StartLocalServer() error {
+	settings, err := a.GetSettings()
+	if err != nil {
+		return err
+	}
+	p, ok := settings.activeProfile()
+	if !ok || p.Provider != ProviderLocal {
+		return fmt.Errorf("활성 프로필이 로컬 모델 프로필이 아닙니다")
+	}
+	if err := validateLocalSettings(p.Name, p.Local); err != nil {
+		return err
+	}
+	modelPath := ""
+	if p.Local.Model != "" {
+		if modelPath, err = localModelPath(p.Local.Model); err != nil {
+			return err
+		}
+		if _, err := os.Stat(modelPath); err != nil {
+			return fmt.Errorf("모델 파일을 찾을 수 없습니다: %s", p.Local.Model)
+		}
+	}
+
+	serverPath, err := trustedServerPath(*p.Local)
+	if err != nil {
+		return err
+	}
+
+	a.local.mu.Lock()
+	if a.local.cmd != nil {
+		a.local.mu.Unlock()
+		return nil
+	}
+	cmd := exec.Command(serverPath, serverArgs(*p.Local, modelPath)...)
+	if err := cmd.Start(); err != nil {
+		a.local.mu.Unlock()
+		return fmt.Errorf("로컬 모델 서버 실행 오류: %w", err)
+	}
+	a.local.cmd = cmd
+	a.local.mu.Unlock()
+
+	exited := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		a.local.mu.Lock()
+		if a.local.cmd == cmd {
+			a.local.cmd = nil
+		}
+		a.local.mu.Unlock()
+		exited <- err
+	}()
+	if err := waitForServer(*p.Local, exited); err != nil {
+		a.StopLocalServer()
+		return err
+	}
+	return nil
+}
+
+// TrustLocalServer approves the server executable of the named local
+// profile on this computer, as it is now. StartLocalServer refuses to run an
+// executable that was not approved or has changed since.
+func (a *VocabApp) TrustLocalServer(profileName string) error {
+	settings, err := a.GetSettings()
+	if err != nil {
+		return err
+	}
+	for _, p := range settings.Profiles {
+		if p.Name != profileName || p.Provider != ProviderLocal {
+			continue
+		}
+		if err := validateLocalSettings(p.Name, p.Local); err != nil {
+			return err
+		}
+		serverPath, err := filepath.Abs(p.Local.ServerPath)
+		if err != nil {
+			return err
+		}
+		hash, err := hashFiles(serverPath)
+		if err != nil {
+			return fmt.Errorf("로컬 모델 서버 실행 파일을 읽을 수 없습니다: %w", err)
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return trustProgram(trustLocalServer, serverPath, hash)
+	}
+	return fmt.Errorf("로컬 모델 프로필을 찾을 수 없습니다: '%s'", profileName)
+}
+
+// StopLocalServer stops the server started by StartLocalServer.
+func (a *VocabApp) StopLocalServer() error {
+	a.local.mu.Lock()
+	defer a.local.mu.Unlock()
+	if a.local.cmd == nil {
+		return nil
+	}
+	err := a.local.cmd.Process.Kill()
+	a.local.cmd = nil
+	return err
+}
+
+// ListLocalModels lists the model files in the models folder.
+func (a *VocabApp) ListLocalModels() ([]LocalModel, error) {
+	base, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(base, localModelsDir))
+	if os.IsNotExist(err) {
+		return []LocalModel{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	models := []LocalModel{}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".part") {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			models = append(models, LocalModel{Name: e.Name(), Size: info.Size()})
+		}
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	return models, nil
+}
+
+// DownloadLocalModel downloads a GGUF file (e.g. from Hugging Face) into the
+// models folder, emitting "local:download" progress events. checksum is the
+// file's SHA-256 as published next to it; a file that does not match is
+// discarded. CancelLocalModelDownload stops the download.
+func (a *VocabApp) DownloadLocalModel(modelURL string, checksum string) (LocalModel, error) {
+	u, err := url.Parse(strings.TrimSpace(modelURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return LocalModel{}, fmt.Errorf("잘못된 다운로드 주소입니다")
+	}
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if _, err := hex.DecodeString(checksum); err != nil || len(checksum) != sha256.Size*2 {
+		return LocalModel{}, fmt.Errorf("모델 파일의 SHA-256 체크섬을 입력하세요")
+	}
+	name := path.Base(u.Path)
+	target, err := localModelPath(name)
+	if err != nil {
+		return LocalModel{}, err
+	}
+	if _, err := os.Stat(target); err == nil {
+		return LocalModel{}, fmt.Errorf("이미 있는 모델입니다: %s", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return LocalModel{}, fmt.Errorf("모델 폴더 생성 오류: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.local.mu.Lock()
+	if a.local.download != nil {
+		a.local.mu.Unlock()
+		return LocalModel{}, fmt.Errorf("다른 모델을 다운로드하고 있습니다")
+	}
+	a.local.download = cancel
+	a.local.mu.Unlock()
+	defer func() {
+		a.local.mu.Lock()
+		a.local.download = nil
+		a.local.mu.Unlock()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return LocalModel{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return LocalModel{}, fmt.Errorf("모델 다운로드가 취소되었습니다")
+		}
+		return LocalModel{}, fmt.Errorf("모델 다운로드 오류: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return LocalModel{}, fmt.Errorf("모델 다운로드 오류: %s", resp.Status)
+	}
+	part := target + ".part"
+	f, err := os.Create(part)
+	if err != nil {
+		return LocalModel{}, fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	hash := sha256.New()
+	progress := &progressWriter{a: a, progress: DownloadProgress{Name: name, Total: resp.ContentLength}}
+	size, err := io.Copy(io.MultiWriter(f, hash, progress), resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(part)
+		if ctx.Err() != nil {
+			return LocalModel{}, fmt.Errorf("모델 다운로드가 취소되었습니다")
+		}
+		return LocalModel{}, fmt.Errorf("모델 다운로드 오류: %w", err)
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != checksum {
+		os.Remove(part)
+		return LocalModel{}, fmt.Errorf("다운로드한 모델의 체크섬이 맞지 않습니다: %s", name)
+	}
+	if err := os.Rename(part, target); err != nil {
+		return LocalModel{}, fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	runtime.EventsEmit(a.ctx, "local:download", DownloadProgress{Name: name, Received: size, Total: size})
+	return LocalModel{Name: name, Size: size}, nil
+}
+
+// CancelLocalModelDownload stops the download of DownloadLocalModel, which
+// then discards the partial file.
+func (a *VocabApp) CancelLocalModelDownload() {
+	a.local.mu.Lock()
+	defer a.local.mu.Unlock()
+	if a.local.download != nil {
+		a.local.download()
+	}
+}
+
+// DeleteLocalModel removes a downloaded model file.
+func (a *VocabApp) DeleteLocalModel(name string) error {
+	target, err := localModelPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil {
+		return fmt.Errorf("모델 삭제 오류: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Generation Runs & Export Metadata ---
+
+const (
+	runsFile = "runs.json"
+	maxRuns  = 200
+
+	// promptTemplateVersion is bumped whenever a change to buildSystemPrompt
+	// changes the questions it produces.
+	promptTemplateVersion = 1
+)
+
+// appVersion is set at build time with -ldflags "-X main.appVersion=1.2.0".
+var appVersion = "dev"
+
+// RunOptions are the settings a run was generated with.
+type RunOptions struct {
+	NumSentences int                `json:"numSentences"`
+	Generation   GenerationSettings `json:"generation"`
+	Normalize    NormalizeSettings  `json:"normalize"`
+}
+
+// RunStamp records the software, connection and settings behind a run, so
+// that a test that looks different from last week's can be explained.
+type RunStamp struct {
+	Provider   string `json:"provider"`
+	Profile    string `json:"profile,omitempty"`
+	AppVersion string `json:"appVersion"`
+	// PromptVersion is "builtin-<promptTemplateVersion>" or "custom" for a
+	// per-model override; PromptHash tells edited overrides apart.
+	PromptVersion string     `json:"promptVersion"`
+	PromptHash    string     `json:"promptHash"`
+	Options       RunOptions `json:"options"`
+}
+
+// runStamp builds the stamp of a run about to be generated from systemPrompt.
+func (a *VocabApp) runStamp(settings Settings, modelID string, questionType string, numSentences int, systemPrompt string) *RunStamp {
+	provider := a.provider
+	if provider == "" {
+		provider = ProviderOpenAI
+	}
+	promptVersion := fmt.Sprintf("builtin-%d", promptTemplateVersion)
+	if settings.hasPromptOverride(modelID, questionType) {
+		promptVersion = "custom"
+	}
+	sum := sha256.Sum256([]byte(systemPrompt))
+	return &RunStamp{
+		Provider:      provider,
+		Profile:       settings.ActiveProfile,
+		AppVersion:    appVersion,
+		PromptVersion: promptVersion,
+		PromptHash:    hex.EncodeToString(sum[:])[:12],
+		Options: RunOptions{
+			NumSentences: numSentences,
+			Generation:   settings.Generation,
+			Normalize:    settings.Normalize,
+		},
+	}
+}
+
+// GenerationRun records what a generation was produced from, so exported
+// questions can be traced back to their word list entries.
+type GenerationRun struct {
+	ID           string      `json:"id"`
+	Model        string      `json:"model"`
+	QuestionType string      `json:"questionType"`
+	Entries      []VocabPair `json:"entries"`
+	Output       string      `json:"output"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	// Alternatives holds the candidate versions not picked per question
+	// number, for runs that sampled several candidates.
+	Alternatives map[int][]Question `json:"alternatives,omitempty"`
+	// Confidence is the probability of every keyed answer, for models that
+	// return token log probabilities.
+	Confidence map[int]float64 `json:"confidence,omitempty"`
+	// Excluded lists the words the exclusion list dropped from the run.
+	Excluded []string `json:"excluded,omitempty"`
+	// Stamp is nil for runs recorded before stamps were added.
+	Stamp *RunStamp `json:"stamp,omitempty"`
+}
+
+// QuestionMeta ties one question to the entry it tests.
+type QuestionMeta struct {
+	Number int    `json:"number"`
+	Word   string `json:"word"`
+	Sense  string `json:"sense"`
+}
+
+// ExportMetadata is embedded in exported files but not shown to students.
+type ExportMetadata struct {
+	RunID        string         `json:"runId"`
+	Model        string         `json:"model"`
+	QuestionType string         `json:"questionType"`
+	GeneratedAt  time.Time      `json:"generatedAt"`
+	Questions    []QuestionMeta `json:"questions"`
+	Stamp        *RunStamp      `json:"stamp,omitempty"`
+}
+
+func loadRuns() ([]GenerationRun, error) {
+	var runs []GenerationRun
+	return runs, loadJSON(runsFile, &runs)
+}
+
+// recordRun stores a finished generation, keeping the most recent maxRuns.
+func (a *VocabApp) recordRun(run GenerationRun) (string, error) {
+	run.ID = newID()
+	run.CreatedAt = time.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	runs, err := loadRuns()
+	if err != nil {
+		return "", err
+	}
+	runs = append(runs, run)
+	if len(runs) > maxRuns {
+		runs = runs[len(runs)-maxRuns:]
+	}
+	return run.ID, saveJSON(runsFile, runs)
+}
+
+// findRun returns the run that produced content. Edited output no longer
+// matches exactly, so it falls back to the newest run that contains the
+// first question's first body line.
+func findRun(runs []GenerationRun, content string) (GenerationRun, bool) {
+	content = strings.TrimSpace(content)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if strings.TrimSpace(runs[i].Output) == content {
+			return runs[i], true
+		}
+	}
+	out := parseOutput(content)
+	if len(out.Questions) == 0 || len(out.Questions[0].Body) == 0 {
+		return GenerationRun{}, false
+	}
+	probe := strings.TrimSpace(out.Questions[0].Body[0])
+	for i := len(runs) - 1; i >= 0; i-- {
+		if probe != "" && strings.Contains(runs[i].Output, probe) {
+			return runs[i], true
+		}
+	}
+	return GenerationRun{}, false
+}
+
+// sourceEntry finds the entry a question tests: the keyed choice when it is a
+// list word (or a form of one), otherwise the first list word in the question.
+func sourceEntry(q Question, entries []VocabPair) (VocabPair, bool) {
+	if q.Answer >= 1 && q.Answer <= len(q.Choices) {
+		keyed := strings.ToLower(strings.TrimSpace(q.Choices[q.Answer-1]))
+		for _, e := range entries {
+			if word := strings.ToLower(e.Word); keyed == word || isInflectionOf(keyed, word) {
+				return e, true
+			}
+		}
+	}
+	text := strings.Join(append(append([]string{q.Title}, q.Body...), q.Choices...), "\n")
+	for _, e := range entries {
+		if wordPattern(e.Word).MatchString(text) {
+			return e, true
+		}
+	}
+	return VocabPair{}, false
+}
+
+// exportMetadata looks up the generation run behind content. Content that
+// did not come from a recorded run gets no metadata.
+func (a *VocabApp) exportMetadata(content string) *ExportMetadata {
+	a.mu.Lock()
+	runs, err := loadRuns()
+	a.mu.Unlock()
+	if err != nil {
+		return nil
+	}
+	run, ok := findRun(runs, content)
+	if !ok {
+		return nil
+	}
+	meta := &ExportMetadata{
+		RunID:        run.ID,
+		Model:        run.Model,
+		QuestionType: run.QuestionType,
+		GeneratedAt:  run.CreatedAt,
+		Stamp:        run.Stamp,
+	}
+	for _, q := range parseOutput(content).Questions {
+		m := QuestionMeta{Number: q.Number}
+		if e, ok := sourceEntry(q, run.Entries); ok {
+			m.Word = e.Word
+			m.Sense = strings.Join(e.Senses, ", ")
+		}
+		meta.Questions = append(meta.Questions, m)
+	}
+	return meta
+}
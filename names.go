@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Names & Places ---
+
+// Name styles of GenerationSettings.Names.
+const (
+	NamesKorean  = "korean"
+	NamesWestern = "western"
+	NamesMixed   = "mixed"
+)
+
+// Place styles of GenerationSettings.Places.
+const (
+	PlacesKorean        = "korean"
+	PlacesInternational = "international"
+)
+
+func validateNameStyles(names string, places string) error {
+	switch names {
+	case "", NamesKorean, NamesWestern, NamesMixed:
+	default:
+		return fmt.Errorf("지원하지 않는 인명 방식입니다: '%s'", names)
+	}
+	switch places {
+	case "", PlacesKorean, PlacesInternational:
+	default:
+		return fmt.Errorf("지원하지 않는 지명 방식입니다: '%s'", places)
+	}
+	return nil
+}
+
+// namesRule is the prompt rule for the names and places used in sentences,
+// matching the conventions of the class's textbooks.
+func namesRule(names string, places string) string {
+	var rule string
+	switch names {
+	case NamesKorean:
+		rule = "When a sentence needs a person's name, use Korean given names romanized as in Korean English textbooks (e.g., Minji, Jiho, Seoyeon, Junho)."
+	case NamesWestern:
+		rule = "When a sentence needs a person's name, use common English given names (e.g., Tom, Emma, David, Sarah)."
+	case NamesMixed:
+		rule = "When a sentence needs a person's name, mix Korean given names romanized as in Korean English textbooks (e.g., Minji, Jiho) with common English given names (e.g., Tom, Emma)."
+	}
+	switch places {
+	case PlacesKorean:
+		rule += " When a sentence mentions a city, region or landmark, use places in Korea (e.g., Seoul, Busan, Jeju Island, Gyeongju)."
+	case PlacesInternational:
+		rule += " When a sentence mentions a city, region or landmark, use well-known places outside Korea (e.g., London, New York, Sydney)."
+	}
+	if rule == "" {
+		return ""
+	}
+	return "NAMES: " + strings.TrimSpace(rule)
+}
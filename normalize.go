@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Entry Normalization ---
+
+// NormalizeSettings picks the clean-up steps run over parsed word lists
+// before generation. All steps are off by default.
+type NormalizeSettings struct {
+	// TrimPunctuation drops trailing punctuation ("abandon." = "버리다;").
+	TrimPunctuation bool `json:"trimPunctuation"`
+	// HalfWidth turns full-width letters, digits and symbols (ａｂｃ, （）)
+	// into their half-width forms.
+	HalfWidth bool `json:"halfWidth"`
+	// LowercaseWords lowercases headwords, keeping proper nouns and acronyms.
+	LowercaseWords bool `json:"lowercaseWords"`
+	// DedupeSenses collapses senses that are the same after the other steps.
+	DedupeSenses bool `json:"dedupeSenses"`
+}
+
+func (n NormalizeSettings) enabled() bool {
+	return n.TrimPunctuation || n.HalfWidth || n.LowercaseWords || n.DedupeSenses
+}
+
+// NormalizeChange is one entry the normalization changed, as "word = 뜻"
+// lines before and after.
+type NormalizeChange struct {
+	Index  int    `json:"index"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// NormalizeReport is emitted as "generate:normalized" when a run's list was
+// changed, and returned by NormalizeWorkingList.
+type NormalizeReport struct {
+	Changes []NormalizeChange `json:"changes"`
+}
+
+const trailingPunctuation = ".,;:!?。、·…"
+
+// properNouns are capitalized words LowercaseWords leaves alone; acronyms
+// are recognized by being all capitals.
+var properNouns = map[string]bool{
+	"Monday": true, "Tuesday": true, "Wednesday": true, "Thursday": true, "Friday": true, "Saturday": true, "Sunday": true,
+	"January": true, "February": true, "March": true, "April": true, "May": true, "June": true, "July": true,
+	"August": true, "September": true, "October": true, "November": true, "December": true,
+	"English": true, "Korean": true, "Korea": true, "American": true, "America": true, "British": true, "Britain": true,
+	"Chinese": true, "China": true, "Japanese": true, "Japan": true, "French": true, "France": true, "German": true,
+	"Germany": true, "Spanish": true, "Spain": true, "European": true, "Europe": true, "Asian": true, "Asia": true,
+	"African": true, "Africa": true, "Christmas": true, "Easter": true, "Internet": true, "Bible": true, "God": true,
+}
+
+// halfWidth maps full-width ASCII variants and the ideographic space to
+// their half-width forms.
+func halfWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u3000':
+			return ' '
+		case r >= '\uff01' && r <= '\uff5e':
+			return r - 0xfee0
+		}
+		return r
+	}, s)
+}
+
+// lowercaseWord lowercases every word of a headword that is not a proper
+// noun or an acronym.
+func lowercaseWord(word string) string {
+	fields := strings.Fields(word)
+	for i, f := range fields {
+		if properNouns[f] || (len(f) > 1 && strings.ToUpper(f) == f && strings.IndexFunc(f, unicode.IsLetter) >= 0) {
+			continue
+		}
+		fields[i] = strings.ToLower(f)
+	}
+	return strings.Join(fields, " ")
+}
+
+// normalizeEntries runs the enabled steps over every entry and reports the
+// entries that changed.
+func normalizeEntries(entries []VocabPair, n NormalizeSettings) ([]VocabPair, NormalizeReport) {
+	report := NormalizeReport{Changes: []NormalizeChange{}}
+	if !n.enabled() {
+		return entries, report
+	}
+	clean := func(s string) string {
+		if n.HalfWidth {
+			s = halfWidth(s)
+		}
+		if n.TrimPunctuation {
+			s = strings.TrimRight(s, trailingPunctuation+" ")
+		}
+		return strings.TrimSpace(s)
+	}
+	normalized := make([]VocabPair, 0, len(entries))
+	for i, e := range entries {
+		pair := VocabPair{Word: clean(e.Word), Seed: e.Seed, Note: e.Note}
+		if n.LowercaseWords {
+			pair.Word = lowercaseWord(pair.Word)
+		}
+		seen := map[string]bool{}
+		for _, s := range e.Senses {
+			sense := clean(s)
+			if sense == "" || (n.DedupeSenses && seen[strings.ToLower(sense)]) {
+				continue
+			}
+			seen[strings.ToLower(sense)] = true
+			pair.Senses = append(pair.Senses, sense)
+			if flag := e.SenseFlags[s]; flag != "" && pair.SenseFlags[sense] == "" {
+				pair.setSenseFlag(sense, flag)
+			}
+			if note := e.SenseNotes[s]; note != "" && pair.SenseNotes[sense] == "" {
+				pair.setSenseNote(sense, clean(note))
+			}
+		}
+		if pair.Word == "" || len(pair.Senses) == 0 {
+			pair = e
+		}
+		before, after := formatVocabBlock([]VocabPair{e}), formatVocabBlock([]VocabPair{pair})
+		if before != after {
+			report.Changes = append(report.Changes, NormalizeChange{Index: i, Before: before, After: after})
+		}
+		normalized = append(normalized, pair)
+	}
+	return normalized, report
+}
+
+// --- Go functions callable from Javascript ---
+
+// NormalizeWorkingList runs the normalization steps of the settings over the
+// working list as one undoable edit.
+func (a *VocabApp) NormalizeWorkingList() (ListState, NormalizeReport, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return a.GetWorkingList(), NormalizeReport{}, err
+	}
+	if !settings.Normalize.enabled() {
+		return a.GetWorkingList(), NormalizeReport{}, fmt.Errorf("설정에서 정리 항목을 하나 이상 선택하세요")
+	}
+	var report NormalizeReport
+	st, err := a.list.apply("목록 정리", func(entries []VocabPair) ([]VocabPair, error) {
+		var normalized []VocabPair
+		normalized, report = normalizeEntries(entries, settings.Normalize)
+		return normalized, nil
+	})
+	return st, report, err
+}
+
+// reportNormalized emits the changes normalization made to a run's list.
+func (a *VocabApp) reportNormalized(report NormalizeReport) {
+	if len(report.Changes) > 0 {
+		runtime.EventsEmit(a.ctx, "generate:normalized", report)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Teacher Notes ---
+
+// WordNoteSettings decides where the teacher notes of words (usage
+// warnings, exam tips; see SetWordNote) are printed. They never reach the
+// model or the student edition.
+type WordNoteSettings struct {
+	StudySheet     bool `json:"studySheet"`
+	TeacherEdition bool `json:"teacherEdition"`
+}
+
+// wordNotesHeader heads the notes appended to the teacher edition.
+const wordNotesHeader = "[단어 메모]"
+
+// WordNote is the note of one word as printed in exports.
+type WordNote struct {
+	Word string `json:"word"`
+	Note string `json:"note"`
+}
+
+// testWordNotes returns the notes of the words tested by content, in
+// question order. Notes are added after generation too, so each word's note
+// is looked up in the working list first, then in the saved word lists and
+// only then in the entries of the test's generation run.
+func (a *VocabApp) testWordNotes(content string) []WordNote {
+	a.mu.Lock()
+	runs, err := loadRuns()
+	var lists []SavedWordList
+	if err == nil {
+		lists, err = loadWordLists()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return nil
+	}
+	run, _ := findRun(runs, content)
+	saved := map[string]string{}
+	for _, l := range lists {
+		if l.DeletedAt != nil {
+			continue
+		}
+		for _, e := range l.Entries {
+			if key := strings.ToLower(e.Word); e.Note != "" && saved[key] == "" {
+				saved[key] = e.Note
+			}
+		}
+	}
+
+	var notes []WordNote
+	seen := map[string]bool{}
+	for _, q := range parseOutput(content).Questions {
+		e, ok := sourceEntry(q, run.Entries)
+		if !ok {
+			e = VocabPair{Word: blankAnswer(q)}
+		}
+		key := strings.ToLower(e.Word)
+		if key == "" || seen[key] {
+			continue
+		}
+		note := a.workingListNote(e.Word)
+		if note == "" {
+			note = saved[key]
+		}
+		if note == "" {
+			note = e.Note
+		}
+		if note == "" {
+			continue
+		}
+		seen[key] = true
+		notes = append(notes, WordNote{Word: e.Word, Note: note})
+	}
+	return notes
+}
+
+// workingListNote returns the note of word in the working list.
+func (a *VocabApp) workingListNote(word string) string {
+	a.list.mu.Lock()
+	defer a.list.mu.Unlock()
+	if i := findEntry(a.list.entries, word); i >= 0 {
+		return a.list.entries[i].Note
+	}
+	return ""
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetWordNote attaches a teacher note to the word at index; an empty note
+// removes it.
+func (a *VocabApp) SetWordNote(index int, note string) (ListState, error) {
+	return a.list.apply("메모 지정", func(entries []VocabPair) ([]VocabPair, error) {
+		if index < 0 || index >= len(entries) {
+			return nil, fmt.Errorf("잘못된 항목 번호입니다: %d", index)
+		}
+		entries[index].Note = strings.TrimSpace(note)
+		return entries, nil
+	})
+}
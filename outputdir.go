@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Output Directory & Auto-Save ---
+
+// ExportSaved is emitted as "export:saved" after every file export, so the UI
+// can offer to open the file or, for auto-saved files, the folder.
+type ExportSaved struct {
+	Path   string `json:"path"`
+	Folder string `json:"folder"`
+	Auto   bool   `json:"auto"`
+}
+
+// autoSavePath returns a free path for name in dir. Versioned names count up;
+// other names get " (2)", " (3)", ... rather than overwriting a file.
+func autoSavePath(dir string, name *exportName, suffix string) string {
+	for i := 2; ; i++ {
+		path := filepath.Join(dir, name.Title+suffix)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		if name.Key != "" {
+			name.Version++
+			name.Title = strings.ReplaceAll(name.Key, "{n}", strconv.Itoa(name.Version))
+		} else {
+			name.Title = strings.TrimSuffix(name.Title, " ("+strconv.Itoa(i-1)+")") + " (" + strconv.Itoa(i) + ")"
+		}
+	}
+}
+
+// savePath picks where an export goes: straight into the output directory
+// when auto-save is on, otherwise wherever the user chooses in the dialog.
+// suffix is appended to the planned name, e.g. ".docx".
+func (a *VocabApp) savePath(name *exportName, suffix string, dialog runtime.SaveDialogOptions) (string, bool, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", false, err
+	}
+	if settings.AutoSave && settings.OutputDir != "" {
+		if err := os.MkdirAll(settings.OutputDir, 0755); err != nil {
+			return "", false, fmt.Errorf("저장 폴더 생성 오류: %w", err)
+		}
+		return autoSavePath(settings.OutputDir, name, suffix), true, nil
+	}
+	dialog.DefaultFilename = name.Title + suffix
+	if settings.OutputDir != "" {
+		dialog.DefaultDirectory = settings.OutputDir
+	}
+	filePath, err := runtime.SaveFileDialog(a.ctx, dialog)
+	if err != nil {
+		return "", false, err
+	}
+	if filePath == "" {
+		return "", false, fmt.Errorf("저장 경로가 선택되지 않았습니다")
+	}
+	return filePath, false, nil
+}
+
+// exportSaved records the version of a saved export, tells the UI and the
+// webhook where the file went and opens it if the settings ask for it.
+func (a *VocabApp) exportSaved(name exportName, path string, auto bool) {
+	a.commitExportName(name)
+	runtime.EventsEmit(a.ctx, "export:saved", ExportSaved{Path: path, Folder: filepath.Dir(path), Auto: auto})
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err == nil {
+		a.fireWebhook(settings.Webhook, WebhookPayload{Event: WebhookExported, Path: path})
+	}
+	if err == nil && settings.OpenAfterExport {
+		if err := a.OpenInDefaultApp(path); err != nil {
+			runtime.LogErrorf(a.ctx, "%v", err)
+		}
+	}
+}
+
+// startDetached runs a launcher command without waiting for the program it
+// opens to exit.
+func startDetached(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// OpenContainingFolder shows path in the system file manager.
+func (a *VocabApp) OpenContainingFolder(path string) error {
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "windows":
+		cmd = exec.Command("explorer", "/select,", path)
+	case "darwin":
+		cmd = exec.Command("open", "-R", path)
+	default:
+		cmd = exec.Command("xdg-open", filepath.Dir(path))
+	}
+	if err := startDetached(cmd); err != nil {
+		return fmt.Errorf("폴더 열기 오류: %w", err)
+	}
+	return nil
+}
+
+// OpenInDefaultApp opens an exported file in the application registered for
+// its type, e.g. Word or 한글 for .docx.
+func (a *VocabApp) OpenInDefaultApp(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("파일을 찾을 수 없습니다: %s", path)
+	}
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	case "darwin":
+		cmd = exec.Command("open", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	if err := startDetached(cmd); err != nil {
+		return fmt.Errorf("파일 열기 오류: %w", err)
+	}
+	return nil
+}
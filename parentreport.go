@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Parent Reports ---
+
+// Limits that keep a parent report on one page.
+const (
+	parentReportWords   = 40 // words listed per section
+	parentReportQuizzes = 5  // most recent quizzes listed
+)
+
+// QuizScore is one graded quiz of a student.
+type QuizScore struct {
+	TestName string    `json:"testName"`
+	Score    float64   `json:"score"`
+	Total    float64   `json:"total"`
+	GradedAt time.Time `json:"gradedAt"`
+}
+
+// ParentReport summarizes a student's vocabulary for their parents: the
+// words mastered (latest attempt with full credit), the words to review and
+// the most recent quiz scores.
+type ParentReport struct {
+	Student  string      `json:"student"`
+	Class    string      `json:"class"`
+	Mastered []string    `json:"mastered"`
+	Review   []string    `json:"review"`
+	Quizzes  []QuizScore `json:"quizzes"`
+}
+
+// parentReport builds the report of student in class from all attempts and
+// grades. Students of the same name in other classes are left out.
+func parentReport(student string, class string, attempts []wordAttempt, grades []GradeResult) ParentReport {
+	report := ParentReport{Student: student, Class: class, Mastered: []string{}, Review: []string{}, Quizzes: []QuizScore{}}
+	latest := map[string]wordAttempt{}
+	for _, at := range attempts {
+		if at.Student != student || at.Class != class {
+			continue
+		}
+		word := strings.ToLower(at.Entry.Word)
+		if prev, ok := latest[word]; !ok || !at.GradedAt.Before(prev.GradedAt) {
+			latest[word] = at
+		}
+	}
+	for _, at := range latest {
+		if at.Credit >= masteryCredit {
+			report.Mastered = append(report.Mastered, at.Entry.Word)
+		} else {
+			report.Review = append(report.Review, at.Entry.Word)
+		}
+	}
+	sort.Strings(report.Mastered)
+	sort.Strings(report.Review)
+
+	// A regraded test counts once, with its latest result.
+	byTest := map[string]GradeResult{}
+	for _, g := range grades {
+		if prev, ok := byTest[g.TestID]; g.Student == student && g.Class == class && (!ok || !g.GradedAt.Before(prev.GradedAt)) {
+			byTest[g.TestID] = g
+		}
+	}
+	for _, g := range byTest {
+		report.Quizzes = append(report.Quizzes, QuizScore{TestName: g.TestName, Score: g.Score, Total: g.Total, GradedAt: g.GradedAt})
+	}
+	sort.Slice(report.Quizzes, func(i, j int) bool { return report.Quizzes[i].GradedAt.After(report.Quizzes[j].GradedAt) })
+	if len(report.Quizzes) > parentReportQuizzes {
+		report.Quizzes = report.Quizzes[:parentReportQuizzes]
+	}
+	return report
+}
+
+// wordSummary joins words for a report line, naming how many were left out.
+func wordSummary(words []string) string {
+	if len(words) == 0 {
+		return "없음"
+	}
+	if len(words) > parentReportWords {
+		return strings.Join(words[:parentReportWords], ", ") + fmt.Sprintf(" 외 %d개", len(words)-parentReportWords)
+	}
+	return strings.Join(words, ", ")
+}
+
+// renderParentReport lays out a report as a one-page PDF. The word and quiz
+// limits keep it well within the page; the builder drops whatever would
+// still spill over.
+func renderParentReport(r ParentReport, vars map[string]interface{}) []byte {
+	doc := newPDFBuilder(1)
+	doc.Title("영어 어휘 학습 리포트")
+	info := fmt.Sprintf("학생: %s", r.Student)
+	if r.Class != "" {
+		info += fmt.Sprintf("   반: %s", r.Class)
+	}
+	if school, _ := vars["School"].(string); school != "" {
+		info += "   학교: " + school
+	}
+	doc.Text(info)
+	if teacher, _ := vars["TeacherName"].(string); teacher != "" {
+		doc.Text("담당 교사: " + teacher)
+	}
+	doc.Text("작성일: " + time.Now().Format("2006. 1. 2."))
+	doc.Blank()
+
+	tracked := len(r.Mastered) + len(r.Review)
+	doc.Heading("학습 현황")
+	doc.Text(fmt.Sprintf("평가한 단어 %d개 중 %d개를 익혔습니다 (%.0f%%).", tracked, len(r.Mastered), percent(float64(len(r.Mastered)), float64(tracked))))
+
+	doc.Heading(fmt.Sprintf("익힌 단어 (%d개)", len(r.Mastered)))
+	doc.Text(wordSummary(r.Mastered))
+	doc.Heading(fmt.Sprintf("복습이 필요한 단어 (%d개)", len(r.Review)))
+	doc.Text(wordSummary(r.Review))
+
+	doc.Heading("최근 퀴즈 점수")
+	if len(r.Quizzes) == 0 {
+		doc.Text("없음")
+	}
+	for _, q := range r.Quizzes {
+		doc.Item(fmt.Sprintf("%s  %s  %s / %s점", q.GradedAt.Format("2006-01-02"), q.TestName, formatPoints(q.Score), formatPoints(q.Total)))
+	}
+	return doc.Bytes()
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetParentReport returns the parent report of one student of class.
+func (a *VocabApp) GetParentReport(student string, class string) (ParentReport, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	attempts, err := loadWordAttempts()
+	if err != nil {
+		return ParentReport{}, err
+	}
+	grades, err := loadGrades()
+	if err != nil {
+		return ParentReport{}, err
+	}
+	return parentReport(student, class, attempts, grades), nil
+}
+
+// ExportParentReports saves a one-page "<student>_학습리포트.pdf" for every
+// graded student of class, in the output directory or a folder chosen by
+// the user when none is configured.
+func (a *VocabApp) ExportParentReports(class string) (string, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	var attempts []wordAttempt
+	var grades []GradeResult
+	if err == nil {
+		attempts, err = loadWordAttempts()
+	}
+	if err == nil {
+		grades, err = loadGrades()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	var students []string
+	seen := map[string]bool{}
+	for _, g := range grades {
+		if g.Class == class && !seen[g.Student] {
+			seen[g.Student] = true
+			students = append(students, g.Student)
+		}
+	}
+	if len(students) == 0 {
+		return "", fmt.Errorf("채점 결과가 없습니다")
+	}
+	sort.Strings(students)
+
+	dir := settings.OutputDir
+	if dir == "" {
+		dir, err = runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{Title: "학습 리포트 저장 폴더 선택"})
+		if err != nil {
+			return "", err
+		}
+		if dir == "" {
+			return "", fmt.Errorf("저장 경로가 선택되지 않았습니다")
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("저장 폴더 생성 오류: %w", err)
+	}
+	vars := map[string]interface{}{}
+	if c := settings.classNamed(class); c != nil {
+		vars = classVars(*c)
+	}
+	for _, student := range students {
+		data := renderParentReport(parentReport(student, class, attempts, grades), vars)
+		name := exportName{Title: invalidFilenameChars.Replace(student) + "_학습리포트"}
+		path := autoSavePath(dir, &name, ".pdf")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return "", fmt.Errorf("파일 저장 오류: %w", err)
+		}
+	}
+	return fmt.Sprintf("학습 리포트 %d개 저장 완료: %s", len(students), filepath.Base(dir)), nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// --- Line Formats ---
+
+// listMarkerRe matches the numbering or bullet in front of lines copied from
+// textbooks and PDFs: "1.", "1)", "(1)", "①", "•", "-", "*" and the like.
+var listMarkerRe = regexp.MustCompile(`^(?:\d{1,3}[.)]|\(\d{1,3}\)|[①-⑳]|[•·▪◦‣●○■□\-*])\s*`)
+
+// dashSeparators are accepted between word and senses when a line has no
+// '='. A plain hyphen only counts with spaces around it, so hyphenated words
+// stay whole.
+var dashSeparators = []string{"–", "—", " - "}
+
+// splitVocabLine splits a line into the headword, the raw senses and the
+// optional seed sentence after a second '=', after stripping a list marker.
+func splitVocabLine(line string) (word string, senses string, seed string, ok bool) {
+	line = strings.TrimSpace(listMarkerRe.ReplaceAllString(line, ""))
+	if word, senses, ok := strings.Cut(line, "="); ok {
+		senses, seed, _ := strings.Cut(senses, "=")
+		return strings.TrimSpace(word), strings.TrimSpace(senses), strings.TrimSpace(seed), true
+	}
+	for _, sep := range dashSeparators {
+		if word, senses, ok := strings.Cut(line, sep); ok {
+			return strings.TrimSpace(word), strings.TrimSpace(senses), "", true
+		}
+	}
+	return "", "", "", false
+}
+
+// --- Swapped Columns ---
+
+// scriptCounts counts the Hangul and Latin letters of s.
+func scriptCounts(s string) (hangul int, latin int) {
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	return hangul, latin
+}
+
+// swappedColumns reports a line written the wrong way round: a Korean
+// "word" with English "senses".
+func swappedColumns(word string, senses string) bool {
+	wordHangul, wordLatin := scriptCounts(word)
+	senseHangul, senseLatin := scriptCounts(senses)
+	return wordHangul > 0 && wordLatin == 0 && senseLatin > 0 && senseHangul == 0
+}
+
+// ColumnFix is one line parsed with its columns swapped back.
+type ColumnFix struct {
+	Line   int    `json:"line"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ColumnRepairReport previews the swapped-column repair of a vocab block.
+// FileWide is set when most lines were swapped, which usually means the
+// columns of the whole file were exported the other way round.
+type ColumnRepairReport struct {
+	Fixes    []ColumnFix `json:"fixes"`
+	FileWide bool        `json:"fileWide"`
+	Repaired string      `json:"repaired"`
+}
+
+// --- Strict Parsing ---
+
+// ParseIssue is a non-blank line of a vocab block that parsing skipped.
+type ParseIssue struct {
+	Line   int    `json:"line"` // 1-based
+	Text   string `json:"text"`
+	Reason string `json:"reason"`
+}
+
+// ParseReport is the result of CheckVocabBlock.
+type ParseReport struct {
+	Entries int          `json:"entries"`
+	Issues  []ParseIssue `json:"issues"`
+}
+
+// maxReportedIssues caps the lines listed in a strict-parse error; the
+// full list comes from CheckVocabBlock.
+const maxReportedIssues = 5
+
+// strictParseError fails a generation whose input had skipped lines, in
+// strict-parse mode.
+func strictParseError(issues []ParseIssue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	var lines []string
+	for i, issue := range issues {
+		if i == maxReportedIssues {
+			lines = append(lines, fmt.Sprintf("외 %d줄", len(issues)-maxReportedIssues))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%d번째 줄: %s", issue.Line, issue.Reason))
+	}
+	return fmt.Errorf("형식이 잘못된 줄이 %d개 있습니다 (%s)", len(issues), strings.Join(lines, ", "))
+}
+
+// --- Go functions callable from Javascript ---
+
+// CheckVocabBlock parses a vocab block and lists every line that would be
+// skipped, so the file can be fixed before generating.
+func (a *VocabApp) CheckVocabBlock(vocabBlock string) ParseReport {
+	pairs, issues := parseVocabLines(vocabBlock)
+	if issues == nil {
+		issues = []ParseIssue{}
+	}
+	return ParseReport{Entries: len(pairs), Issues: issues}
+}
+
+// PreviewColumnRepair lists the lines parsing will read with swapped columns
+// ("버리다 = abandon") and returns the repaired block, so the teacher can
+// check the repair before generating or save the fixed list.
+func (a *VocabApp) PreviewColumnRepair(vocabBlock string) ColumnRepairReport {
+	report := ColumnRepairReport{Fixes: []ColumnFix{}}
+	lines := 0
+	for i, raw := range strings.Split(vocabBlock, "\n") {
+		word, senses, _, ok := splitVocabLine(strings.TrimSpace(raw))
+		if !ok {
+			continue
+		}
+		lines++
+		if swappedColumns(word, senses) {
+			report.Fixes = append(report.Fixes, ColumnFix{Line: i + 1, Before: strings.TrimSpace(raw), After: formatVocabBlock(parseVocabBlock(raw))})
+		}
+	}
+	report.FileWide = lines > 0 && len(report.Fixes)*2 > lines
+	report.Repaired = formatVocabBlock(parseVocabBlock(vocabBlock))
+	return report
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// --- Minimal PDF Writer ---
+
+// A4 in points, with 2cm margins like the DOCX exports.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 57
+)
+
+// pdfFont is one of the Korean fonts every PDF reader provides (Adobe's
+// Korean font pack, or its substitutes in browsers and 한글), so the file
+// needs no embedded font and stays a few kilobytes.
+const pdfFont = "HYGoThic-Medium"
+
+// pdfBuilder lays out lines of text top to bottom, starting a new page when
+// one is full. Like docxBuilder it only implements what the reports need.
+type pdfBuilder struct {
+	pages    []*bytes.Buffer
+	y        float64 // baseline of the next line on the current page
+	maxPages int     // 0 for no limit
+}
+
+// newPDFBuilder starts a document of at most maxPages pages (0 for no
+// limit); the lines that would not fit are left out.
+func newPDFBuilder(maxPages int) *pdfBuilder {
+	b := &pdfBuilder{maxPages: maxPages}
+	b.newPage()
+	return b
+}
+
+func (b *pdfBuilder) newPage() {
+	b.pages = append(b.pages, &bytes.Buffer{})
+	b.y = pdfPageHeight - pdfMargin
+}
+
+// pdfTextWidth estimates the width of text at size: Latin characters are
+// half-width in the font, everything else full-width.
+func pdfTextWidth(text string, size float64) float64 {
+	units := 0
+	for _, r := range text {
+		if r < 0x80 {
+			units += 500
+		} else {
+			units += 1000
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// pdfWrap breaks text into lines that fit width, preferring spaces.
+func pdfWrap(text string, size float64, width float64) []string {
+	var lines []string
+	for pdfTextWidth(text, size) > width {
+		runes := []rune(text)
+		cut := 0
+		for i := 1; i <= len(runes) && pdfTextWidth(string(runes[:i]), size) <= width; i++ {
+			cut = i
+		}
+		if cut == 0 {
+			cut = 1
+		}
+		prefix := string(runes[:cut])
+		if sp := strings.LastIndex(prefix, " "); sp > 0 && cut < len(runes) {
+			cut = utf8.RuneCountInString(prefix[:sp])
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		text = strings.TrimLeft(string(runes[cut:]), " ")
+	}
+	return append(lines, text)
+}
+
+// pdfString encodes text for the UniKS-UCS2-H encoding, dropping characters
+// outside the Basic Multilingual Plane.
+func pdfString(text string) string {
+	var b strings.Builder
+	b.WriteString("<")
+	for _, r := range text {
+		if r > 0xFFFF {
+			continue
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+func (b *pdfBuilder) line(text string, size float64, x float64, after float64) {
+	for _, l := range pdfWrap(text, size, pdfPageWidth-pdfMargin-x) {
+		if b.y-size < pdfMargin {
+			if b.maxPages > 0 && len(b.pages) >= b.maxPages {
+				return
+			}
+			b.newPage()
+		}
+		b.y -= size
+		fmt.Fprintf(b.pages[len(b.pages)-1], "BT /F1 %.1f Tf %.1f %.1f Td %s Tj ET\n", size, x, b.y, pdfString(l))
+		b.y -= size * 0.4
+	}
+	b.y -= after
+}
+
+func (b *pdfBuilder) Title(text string) {
+	size := 18.0
+	x := (pdfPageWidth - pdfTextWidth(text, size)) / 2
+	if x < pdfMargin {
+		x = pdfMargin
+	}
+	b.line(text, size, x, 10)
+}
+
+func (b *pdfBuilder) Heading(text string) {
+	b.y -= 6
+	b.line(text, 13, pdfMargin, 4)
+}
+
+func (b *pdfBuilder) Text(text string) {
+	b.line(text, 10.5, pdfMargin, 2)
+}
+
+// Item is an indented line of a list.
+func (b *pdfBuilder) Item(text string) {
+	b.line(text, 10.5, pdfMargin+12, 2)
+}
+
+func (b *pdfBuilder) Blank() {
+	b.y -= 10.5
+}
+
+// Bytes writes the document with a cross-reference table.
+func (b *pdfBuilder) Bytes() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // pages, filled in below
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniKS-UCS2-H /DescendantFonts [4 0 R] >>", pdfFont),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Korea1) /Supplement 1 >> /FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>", pdfFont),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 6 /FontBBox [-6 -145 1003 880] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>", pdfFont),
+	}
+	var kids []string
+	for _, content := range b.pages {
+		page := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, page+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(b.pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
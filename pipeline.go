@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Draft/Review Pipeline ---
+
+const reviewPrompt = `You are a senior English test editor reviewing vocabulary questions drafted by a junior writer for Korean students.
+Each question below failed an automatic check; the problems are listed under it. Rewrite only these questions so that they follow the drafting instructions at the end and fix every listed problem. Keep the question numbers, the title and the tested word.
+Respond with JSON only: {"questions": [{"question": 1, "body": ["sentence", "..."], "choices": ["a", "b", "c", "d", "e"], "answer": 3, "answerText": ""}]}. "answer" is the 1-based index of the correct choice; production questions have no choices and give the written answer in "answerText" instead.`
+
+// ReviewReport is emitted as "generate:reviewed" after the review model went
+// over the draft.
+type ReviewReport struct {
+	DraftModel  string            `json:"draftModel"`
+	ReviewModel string            `json:"reviewModel"`
+	Questions   int               `json:"questions"`
+	Flagged     []int             `json:"flagged"`
+	Repaired    []int             `json:"repaired"`
+	Unresolved  []ValidationIssue `json:"unresolved"`
+}
+
+type reviewResult struct {
+	Questions []struct {
+		Question   int      `json:"question"`
+		Body       []string `json:"body"`
+		Choices    []string `json:"choices"`
+		Answer     int      `json:"answer"`
+		AnswerText string   `json:"answerText"`
+	} `json:"questions"`
+}
+
+// flaggedIssues groups the validation problems of a draft by question.
+// Problems not tied to a question cannot be repaired one by one and are
+// left out.
+func flaggedIssues(result ValidationResult) map[int][]string {
+	flagged := map[int][]string{}
+	for _, issue := range append(result.Failures, result.AnswerKeyErrors...) {
+		if issue.Question > 0 {
+			flagged[issue.Question] = append(flagged[issue.Question], issue.Message)
+		}
+	}
+	return flagged
+}
+
+// reviewDraft sends only the questions of a draft that fail validation to the
+// review model and swaps in its rewrites, then validates the result again.
+func (a *VocabApp) reviewDraft(reviewModel string, systemPrompt string, output string, entries []VocabPair, questionType string, numSentences int) (string, ReviewReport, error) {
+	report := ReviewReport{ReviewModel: reviewModel}
+	out := parseOutput(output)
+	report.Questions = len(out.Questions)
+	flagged := flaggedIssues(validateOutput(output, entries, questionType, numSentences))
+	if len(flagged) == 0 {
+		return output, report, nil
+	}
+
+	var request strings.Builder
+	var picked ParsedOutput
+	picked.HasKey = true
+	for _, q := range out.Questions {
+		if issues, ok := flagged[q.Number]; ok {
+			report.Flagged = append(report.Flagged, q.Number)
+			picked.Questions = append(picked.Questions, q)
+			fmt.Fprintf(&request, "Question %d problems:\n- %s\n", q.Number, strings.Join(issues, "\n- "))
+		}
+	}
+	request.WriteString("\n" + formatOutput(picked))
+
+	var review reviewResult
+	prompt := reviewPrompt + "\n\nDrafting instructions:\n" + systemPrompt
+	if err := a.callChatGPTJSON(reviewModel, prompt, request.String(), &review); err != nil {
+		return output, report, err
+	}
+	index := map[int]int{}
+	for i, q := range out.Questions {
+		index[q.Number] = i
+	}
+	for _, r := range review.Questions {
+		i, ok := index[r.Question]
+		if _, wanted := flagged[r.Question]; !ok || !wanted || len(r.Body) == 0 {
+			continue
+		}
+		q := &out.Questions[i]
+		q.Body = r.Body
+		q.Choices = r.Choices
+		q.Answer = r.Answer
+		q.AnswerText = strings.TrimSpace(r.AnswerText)
+		report.Repaired = append(report.Repaired, q.Number)
+	}
+	if len(report.Repaired) == 0 {
+		return output, report, nil
+	}
+	out.HasKey = true
+	reviewed := formatOutput(out)
+	for number, issues := range flaggedIssues(validateOutput(reviewed, entries, questionType, numSentences)) {
+		for _, message := range issues {
+			report.Unresolved = append(report.Unresolved, ValidationIssue{Question: number, Message: message})
+		}
+	}
+	return reviewed, report, nil
+}
+
+// reviewDraftAndReport runs reviewDraft for Generate. If the review call
+// fails the draft is kept as it is.
+func (a *VocabApp) reviewDraftAndReport(draftModel string, reviewModel string, systemPrompt string, output string, entries []VocabPair, questionType string, numSentences int) string {
+	reviewed, report, err := a.reviewDraft(reviewModel, systemPrompt, output, entries, questionType, numSentences)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "검토 모델 오류: %v", err)
+	}
+	report.DraftModel = draftModel
+	runtime.EventsEmit(a.ctx, "generate:reviewed", report)
+	return reviewed
+}
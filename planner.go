@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Semester Planner ---
+
+// PlanWeek is the words one week of a semester plan introduces and reviews.
+type PlanWeek struct {
+	Week   int      `json:"week"`
+	New    []string `json:"new"`
+	Review []string `json:"review"`
+}
+
+// WordCoverage lists the weeks a word appears in.
+type WordCoverage struct {
+	Word  string `json:"word"`
+	Weeks []int  `json:"weeks"`
+}
+
+// SemesterPlanRequest describes a term: the master list is split over Weeks
+// and every word comes back ReviewAfter weeks after it was introduced (e.g.
+// [1, 3] for reviews one and three weeks later), with at most MaxReview
+// review words per week (0 = no limit).
+type SemesterPlanRequest struct {
+	Name        string       `json:"name"`
+	WordListID  string       `json:"wordListId"`
+	Weeks       int          `json:"weeks"`
+	ReviewAfter []int        `json:"reviewAfter"`
+	MaxReview   int          `json:"maxReview"`
+	Parts       []SeriesPart `json:"parts"`
+	Model       string       `json:"model"`
+	Format      string       `json:"format"`
+	OutputDir   string       `json:"outputDir"`
+}
+
+// SemesterPlan is the week-by-week plan with the coverage of every word.
+// Dropped counts the reviews left out by MaxReview.
+type SemesterPlan struct {
+	Weeks    []PlanWeek     `json:"weeks"`
+	Coverage []WordCoverage `json:"coverage"`
+	Dropped  int            `json:"dropped"`
+}
+
+// planSemester splits the words evenly over the weeks in list order and
+// schedules the reviews. Reviews of earlier-introduced words win when a week
+// is over MaxReview.
+func planSemester(words []string, weeks int, reviewAfter []int, maxReview int) SemesterPlan {
+	plan := SemesterPlan{Weeks: make([]PlanWeek, weeks)}
+	introduced := map[string]int{}
+	for w := range plan.Weeks {
+		plan.Weeks[w] = PlanWeek{Week: w + 1, New: []string{}, Review: []string{}}
+		from, to := w*len(words)/weeks, (w+1)*len(words)/weeks
+		for _, word := range words[from:to] {
+			plan.Weeks[w].New = append(plan.Weeks[w].New, word)
+			introduced[word] = w + 1
+		}
+	}
+	for _, word := range words {
+		for _, after := range reviewAfter {
+			week := introduced[word] + after
+			if after < 1 || week > weeks {
+				continue
+			}
+			pw := &plan.Weeks[week-1]
+			if maxReview > 0 && len(pw.Review) >= maxReview {
+				plan.Dropped++
+				continue
+			}
+			pw.Review = append(pw.Review, word)
+		}
+	}
+
+	seen := map[string][]int{}
+	for _, pw := range plan.Weeks {
+		for _, word := range append(append([]string{}, pw.New...), pw.Review...) {
+			seen[word] = append(seen[word], pw.Week)
+		}
+	}
+	for _, word := range words {
+		plan.Coverage = append(plan.Coverage, WordCoverage{Word: word, Weeks: seen[word]})
+	}
+	return plan
+}
+
+// semesterPlan loads the list of the request and plans it.
+func (a *VocabApp) semesterPlan(req SemesterPlanRequest) (SemesterPlan, error) {
+	if req.Weeks < 1 {
+		return SemesterPlan{}, fmt.Errorf("주차 수는 1 이상이어야 합니다")
+	}
+	if req.MaxReview < 0 {
+		return SemesterPlan{}, fmt.Errorf("주당 복습 단어 수는 0 이상이어야 합니다")
+	}
+	a.mu.Lock()
+	lists, err := loadWordLists()
+	a.mu.Unlock()
+	if err != nil {
+		return SemesterPlan{}, err
+	}
+	var words []string
+	seen := map[string]bool{}
+	for _, l := range lists {
+		if l.ID != req.WordListID || l.DeletedAt != nil {
+			continue
+		}
+		for _, e := range l.Entries {
+			if key := strings.ToLower(e.Word); !seen[key] {
+				seen[key] = true
+				words = append(words, e.Word)
+			}
+		}
+	}
+	if len(words) == 0 {
+		return SemesterPlan{}, fmt.Errorf("단어장을 찾을 수 없습니다")
+	}
+	if req.Weeks > len(words) {
+		return SemesterPlan{}, fmt.Errorf("주차 수(%d)가 단어 수(%d)보다 많습니다", req.Weeks, len(words))
+	}
+	return planSemester(words, req.Weeks, req.ReviewAfter, req.MaxReview), nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// PreviewSemesterPlan shows how the list would be spread over the term
+// without creating anything.
+func (a *VocabApp) PreviewSemesterPlan(req SemesterPlanRequest) (SemesterPlan, error) {
+	return a.semesterPlan(req)
+}
+
+// CreateSemesterPlan plans the term and creates a series that follows the
+// plan, so each week's quiz is one GenerateNextInSeries call.
+func (a *VocabApp) CreateSemesterPlan(req SemesterPlanRequest) (Series, error) {
+	plan, err := a.semesterPlan(req)
+	if err != nil {
+		return Series{}, err
+	}
+	perWeek := 0
+	for _, pw := range plan.Weeks {
+		if len(pw.New) > perWeek {
+			perWeek = len(pw.New)
+		}
+	}
+	return a.SaveSeries(Series{
+		Name:         req.Name,
+		WordListID:   req.WordListID,
+		Rotation:     RotationSequential,
+		WordsPerWeek: perWeek,
+		Parts:        req.Parts,
+		Model:        req.Model,
+		Format:       req.Format,
+		OutputDir:    req.OutputDir,
+		Plan:         plan.Weeks,
+	})
+}
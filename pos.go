@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Choice Part-of-Speech Matching ---
+
+// A tiny suffix tagger: it only answers when a word's ending makes the part
+// of speech clear, so an empty result means "unknown", never "mismatch".
+// Endings shared by many nouns and adjectives (-ant, -ent: student,
+// important) are left out.
+const (
+	posNoun = "noun"
+	posVerb = "verb"
+	posAdj  = "adjective"
+	posAdv  = "adverb"
+)
+
+var posSuffixes = []struct {
+	suffix string
+	pos    string
+}{
+	{"tion", posNoun}, {"sion", posNoun}, {"ment", posNoun}, {"ness", posNoun}, {"ity", posNoun},
+	{"ance", posNoun}, {"ence", posNoun}, {"ship", posNoun}, {"hood", posNoun}, {"ism", posNoun},
+	{"ist", posNoun}, {"dom", posNoun}, {"ure", posNoun},
+	{"ous", posAdj}, {"ful", posAdj}, {"ive", posAdj}, {"able", posAdj}, {"ible", posAdj},
+	{"less", posAdj}, {"ical", posAdj}, {"ic", posAdj}, {"ary", posAdj},
+	{"ize", posVerb}, {"ise", posVerb}, {"ify", posVerb}, {"ate", posVerb}, {"en", posVerb},
+}
+
+// closedClassPOS covers common words whose ending says nothing about them.
+var closedClassPOS = map[string]string{
+	"family": posNoun, "early": posAdj, "only": posAdj, "friendly": posAdj, "lovely": posAdj, "likely": posAdj,
+	"lonely": posAdj, "elderly": posAdj, "costly": posAdj, "silly": posAdj, "ugly": posAdj, "holy": posAdj,
+	"supply": posVerb, "apply": posVerb, "reply": posVerb, "rely": posVerb, "comply": posVerb, "imply": posVerb,
+	"climate": posNoun, "candidate": posNoun, "estate": posNoun, "senate": posNoun, "chocolate": posNoun,
+	"private": posAdj, "accurate": posAdj, "adequate": posAdj, "desperate": posAdj, "moderate": posAdj,
+	"delicate": posAdj, "ultimate": posAdj, "appropriate": posAdj, "legitimate": posAdj, "intimate": posAdj,
+	"children": posNoun, "garden": posNoun, "kitchen": posNoun, "chicken": posNoun, "citizen": posNoun,
+	"golden": posAdj, "wooden": posAdj, "hidden": posAdj, "sudden": posAdj, "open": posAdj,
+	"library": posNoun, "salary": posNoun, "dictionary": posNoun, "summary": posNoun, "anniversary": posNoun,
+	"music": posNoun, "topic": posNoun, "logic": posNoun, "traffic": posNoun, "public": posAdj,
+	"promise": posVerb, "exercise": posNoun, "expertise": posNoun, "premise": posNoun, "precise": posAdj,
+	"future": posNoun, "sure": posAdj, "secure": posAdj, "mature": posAdj, "obscure": posAdj, "pure": posAdj,
+	"endure": posVerb, "ensure": posVerb, "measure": posVerb, "capture": posVerb, "assure": posVerb,
+	"cure": posVerb, "procure": posVerb, "expose": posVerb,
+}
+
+// uninflectedEndings are words that end in -ed or -ing without being a
+// past or -ing form, so their ending says nothing about their form.
+var uninflectedEndings = map[string]bool{
+	"exceed": true, "proceed": true, "succeed": true, "indeed": true, "hundred": true, "kindred": true,
+	"sacred": true, "wicked": true, "rugged": true, "ragged": true, "crooked": true, "beloved": true,
+	"morning": true, "evening": true, "during": true, "nothing": true, "something": true, "anything": true,
+	"everything": true, "ceiling": true, "spring": true, "string": true, "sibling": true, "pudding": true,
+	"offspring": true, "lightning": true, "duckling": true, "darling": true, "awning": true,
+}
+
+// inflectionPattern is the regular ending a single word carries, if any.
+func inflectionPattern(word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if uninflectedEndings[word] {
+		return ""
+	}
+	switch {
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		return "-ing"
+	case strings.HasSuffix(word, "ed") && len(word) > 5:
+		return "-ed"
+	}
+	return ""
+}
+
+// guessPOS returns the part of speech a word's form shows, or "".
+func guessPOS(word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if strings.Contains(word, " ") || len(word) < 4 {
+		return ""
+	}
+	if pos, ok := closedClassPOS[word]; ok {
+		return pos
+	}
+	if strings.HasSuffix(word, "ly") {
+		return posAdv
+	}
+	for _, s := range posSuffixes {
+		if strings.HasSuffix(word, s.suffix) && len(word) > len(s.suffix)+2 {
+			return s.pos
+		}
+	}
+	return ""
+}
+
+// choicePOSMismatches returns the 1-based indexes of the choices whose part
+// of speech or inflection visibly differs from the keyed answer's.
+func choicePOSMismatches(q Question) []int {
+	if q.Answer < 1 || q.Answer > len(q.Choices) {
+		return nil
+	}
+	answer := q.Choices[q.Answer-1]
+	answerPOS, answerInfl := guessPOS(answer), inflectionPattern(answer)
+	var bad []int
+	for i, c := range q.Choices {
+		if i == q.Answer-1 {
+			continue
+		}
+		pos := guessPOS(c)
+		if (answerPOS != "" && pos != "" && pos != answerPOS) || inflectionPattern(c) != answerInfl {
+			bad = append(bad, i+1)
+		}
+	}
+	return bad
+}
+
+// wordChoiceTypes are the question types whose choices are single words, so
+// part of speech matching applies.
+var wordChoiceTypes = map[string]bool{"빈칸 추론": true, "영영풀이": true, "의미 다른 것": true, "유추": true}
+
+func choicePOSRule(enabled bool) string {
+	if !enabled {
+		return ""
+	}
+	return "CHOICES: All five choices must have the same part of speech and the same form (e.g., all base-form verbs, all plural nouns, all -ing forms) as the correct answer, so that no choice can be ruled out on grammar alone."
+}
+
+const posRepairPrompt = `You are an expert English vocabulary test maker for Korean students.
+In these multiple-choice questions some distractors have a different part of speech or form than the keyed answer, so students can rule them out on grammar alone. Replace ONLY the listed choices with plausible but incorrect words of exactly the same part of speech and form as the keyed answer. Do not change the keyed answer or reuse another choice.
+Respond with JSON only: {"questions": [{"question": 1, "choices": {"2": "replacement", "4": "replacement"}}]}.`
+
+// POSCheckReport is emitted as "generate:pos-checked".
+type POSCheckReport struct {
+	Flagged    int               `json:"flagged"`
+	Repaired   []int             `json:"repaired"`
+	Unresolved []ValidationIssue `json:"unresolved"`
+}
+
+// matchChoicePOS finds distractors whose form gives them away and has the
+// model replace them, then re-checks the result in Go.
+func (a *VocabApp) matchChoicePOS(modelID string, output string) (string, POSCheckReport, error) {
+	var report POSCheckReport
+	out := parseOutput(output)
+	mismatches := map[int][]int{}
+	var flagged []Question
+	var request strings.Builder
+	for _, q := range out.Questions {
+		if bad := choicePOSMismatches(q); len(bad) > 0 {
+			mismatches[q.Number] = bad
+			flagged = append(flagged, q)
+			fmt.Fprintf(&request, "Replace choices %v of question %d.\n", bad, q.Number)
+		}
+	}
+	report.Flagged = len(flagged)
+	if len(flagged) == 0 {
+		return output, report, nil
+	}
+	request.WriteString("\n" + describeQuestions(flagged))
+
+	var repair choiceRepairResult
+	if err := a.callChatGPTJSON(modelID, posRepairPrompt, request.String(), &repair); err != nil {
+		return output, report, err
+	}
+	index := map[int]int{}
+	for i, q := range out.Questions {
+		index[q.Number] = i
+	}
+	for _, r := range repair.Questions {
+		i, ok := index[r.Question]
+		if !ok {
+			continue
+		}
+		q := &out.Questions[i]
+		changed := false
+		for _, c := range mismatches[q.Number] {
+			if text := strings.TrimSpace(r.Choices[strconv.Itoa(c)]); text != "" {
+				q.Choices[c-1] = text
+				changed = true
+			}
+		}
+		if changed {
+			report.Repaired = append(report.Repaired, q.Number)
+		}
+	}
+	for _, q := range out.Questions {
+		if _, ok := mismatches[q.Number]; !ok {
+			continue
+		}
+		for _, c := range choicePOSMismatches(q) {
+			report.Unresolved = append(report.Unresolved, ValidationIssue{Question: q.Number, Message: fmt.Sprintf("선택지 '%s'의 품사/형태가 정답과 다릅니다", q.Choices[c-1])})
+		}
+	}
+	if len(report.Repaired) == 0 {
+		return output, report, nil
+	}
+	return formatOutput(out), report, nil
+}
+
+func (a *VocabApp) matchChoicePOSAndReport(modelID string, output string) string {
+	checked, report, err := a.matchChoicePOS(modelID, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "선택지 품사 검사 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:pos-checked", report)
+	return checked
+}
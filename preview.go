@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// --- Export Preview ---
+
+// Page geometry of the exported documents in twips, matching
+// docxDocumentFooter (A4, 2cm margins).
+const (
+	previewPageWidth  = 11906
+	previewPageHeight = 16838
+	previewMargin     = 1134
+)
+
+// TestPreview is an export rendered as HTML, one element per page.
+type TestPreview struct {
+	Format string   `json:"format"`
+	Pages  []string `json:"pages"`
+}
+
+// paragraphHeight estimates the height of a paragraph in twips. Word's exact
+// line breaking depends on font metrics, so wide (Hangul, CJK) characters
+// count as one em and everything else as about half an em.
+func (b *docxBuilder) paragraphHeight(p docxParagraph) int {
+	if p.Table != nil {
+		// one line per row plus the cell padding
+		return len(p.Table) * (p.HalfPts*13*b.spacing/240 + 60)
+	}
+	var ems float64
+	for _, r := range p.Runs {
+		for _, c := range r.Text {
+			if unicode.Is(unicode.Hangul, c) || unicode.Is(unicode.Han, c) || c >= 0x2460 && c <= 0x24FF {
+				ems++
+			} else {
+				ems += 0.55
+			}
+		}
+	}
+	fontTwips := float64(p.HalfPts * 10)
+	lines := math.Max(1, math.Ceil(ems*fontTwips/float64(previewPageWidth-2*previewMargin)))
+	lineHeight := fontTwips * 1.3 * float64(b.spacing) / 240
+	return int(lines*lineHeight) + p.SpaceAfter
+}
+
+func (b *docxBuilder) paragraphHTML(p docxParagraph) string {
+	if p.Table != nil {
+		var t strings.Builder
+		fmt.Fprintf(&t, `<table style="width:100%%;border-collapse:collapse;font-size:%.1fpt">`, float64(p.HalfPts)/2)
+		for _, row := range p.Table {
+			t.WriteString("<tr>")
+			for _, cell := range row {
+				fmt.Fprintf(&t, `<td style="border:1px solid #000;text-align:center">%s</td>`, html.EscapeString(cell))
+			}
+			t.WriteString("</tr>")
+		}
+		t.WriteString("</table>")
+		return t.String()
+	}
+	style := fmt.Sprintf("margin:0 0 %.1fpt;font-size:%.1fpt;min-height:1em", float64(p.SpaceAfter)/20, float64(p.HalfPts)/2)
+	if p.Align != "" {
+		style += ";text-align:" + p.Align
+	}
+	var text strings.Builder
+	for _, r := range p.Runs {
+		span := html.EscapeString(r.Text)
+		if r.Bold {
+			span = "<b>" + span + "</b>"
+		}
+		if r.Underline {
+			span = "<u>" + span + "</u>"
+		}
+		text.WriteString(span)
+	}
+	return fmt.Sprintf(`<p style="%s">%s</p>`, style, text.String())
+}
+
+// PreviewPages lays the paragraphs out on pages and renders each page as HTML.
+// Page breaks are estimated, so they may be off by a line or two.
+func (b *docxBuilder) PreviewPages() []string {
+	pageStyle := fmt.Sprintf(`<div class="page" style="width:210mm;height:297mm;padding:%.1fpt;box-sizing:border-box;overflow:hidden;background:#fff;font-family:'%s';line-height:%.2f;white-space:pre-wrap">`,
+		float64(previewMargin)/20, html.EscapeString(b.font), float64(b.spacing)/240)
+	var pages []string
+	var page strings.Builder
+	used := 0
+	flush := func() {
+		pages = append(pages, pageStyle+page.String()+`</div>`)
+		page.Reset()
+		used = 0
+	}
+	for _, p := range b.paras {
+		if p.PageBreak {
+			flush()
+			continue
+		}
+		h := b.paragraphHeight(p)
+		if used > 0 && used+h > previewPageHeight-2*previewMargin {
+			flush()
+		}
+		page.WriteString(b.paragraphHTML(p))
+		used += h
+	}
+	flush()
+	return pages
+}
+
+// --- Go functions callable from Javascript ---
+
+// RenderPreview renders a saved test the way it will be exported, page by
+// page, so page breaks and fonts can be checked before exporting. Only the
+// DOCX layout is available.
+func (a *VocabApp) RenderPreview(testID string, format string) (TestPreview, error) {
+	if format == "" {
+		format = "docx"
+	}
+	if format != "docx" {
+		return TestPreview{}, fmt.Errorf("미리보기를 지원하지 않는 형식입니다: '%s'", format)
+	}
+	test, err := a.GetTest(testID)
+	if err != nil {
+		return TestPreview{}, err
+	}
+	opts, err := a.testExportOptions(test.Name, test.Content)
+	if err != nil {
+		return TestPreview{}, err
+	}
+	return TestPreview{Format: format, Pages: layoutTestDocx(test.Content, opts).PreviewPages()}, nil
+}
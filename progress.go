@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --- Learning Progress ---
+
+// masteryCredit is the credit a student's latest attempt at a word needs for
+// the word to count as mastered.
+const masteryCredit = 1.0
+
+// mostMissedLimit caps the most-missed word list of a progress report.
+const mostMissedLimit = 10
+
+// wordAttempt is one graded question tied to the word it tested.
+type wordAttempt struct {
+	Student  string
+	Class    string
+	Entry    VocabPair
+	Credit   float64
+	GradedAt time.Time
+}
+
+// loadWordAttempts flattens the stored grades into one attempt per graded
+// question whose word is known. Must be called with a.mu held.
+func loadWordAttempts() ([]wordAttempt, error) {
+	grades, err := loadGrades()
+	if err != nil {
+		return nil, err
+	}
+	tests, err := loadTests()
+	if err != nil {
+		return nil, err
+	}
+	runs, err := loadRuns()
+	if err != nil {
+		return nil, err
+	}
+	testsByID := map[string]SavedTest{}
+	for _, t := range tests {
+		testsByID[t.ID] = t
+	}
+
+	var attempts []wordAttempt
+	for _, g := range grades {
+		test, ok := testsByID[g.TestID]
+		if !ok {
+			continue
+		}
+		words := questionWords(test, runs)
+		for _, item := range g.Items {
+			e, ok := words[item.Question]
+			if !ok {
+				continue
+			}
+			attempts = append(attempts, wordAttempt{
+				Student:  g.Student,
+				Class:    g.Class,
+				Entry:    e,
+				Credit:   item.Credit,
+				GradedAt: g.GradedAt,
+			})
+		}
+	}
+	return attempts, nil
+}
+
+// ProgressPoint is the accuracy of the answers graded on one day.
+type ProgressPoint struct {
+	Date     string  `json:"date"` // 2006-01-02
+	Answers  int     `json:"answers"`
+	Accuracy float64 `json:"accuracy"` // 0-100
+}
+
+// WordStat is how one word was answered.
+type WordStat struct {
+	Word     string  `json:"word"`
+	Attempts int     `json:"attempts"`
+	Missed   int     `json:"missed"`
+	Accuracy float64 `json:"accuracy"` // 0-100
+}
+
+// ProgressReport backs the progress dashboard for a class, a student or a
+// word list.
+type ProgressReport struct {
+	Trend []ProgressPoint `json:"trend"`
+	// Mastery is the share of (student, word) pairs whose latest attempt got
+	// full credit.
+	Mastery    float64    `json:"mastery"` // 0-100
+	Mastered   int        `json:"mastered"`
+	Tracked    int        `json:"tracked"`
+	MostMissed []WordStat `json:"mostMissed"`
+}
+
+func percent(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return part / whole * 100
+}
+
+// progressReport aggregates the attempts into a dashboard report.
+func progressReport(attempts []wordAttempt) ProgressReport {
+	type tally struct {
+		answers int
+		credit  float64
+	}
+	days := map[string]*tally{}
+	latest := map[string]wordAttempt{}
+	stats := map[string]*WordStat{}
+	credits := map[string]float64{}
+	for _, at := range attempts {
+		day := at.GradedAt.Format("2006-01-02")
+		if days[day] == nil {
+			days[day] = &tally{}
+		}
+		days[day].answers++
+		days[day].credit += at.Credit
+
+		word := strings.ToLower(at.Entry.Word)
+		pair := at.Student + "\x00" + word
+		if prev, ok := latest[pair]; !ok || !at.GradedAt.Before(prev.GradedAt) {
+			latest[pair] = at
+		}
+		if stats[word] == nil {
+			stats[word] = &WordStat{Word: at.Entry.Word}
+		}
+		stats[word].Attempts++
+		credits[word] += at.Credit
+		if at.Credit < 1 {
+			stats[word].Missed++
+		}
+	}
+
+	report := ProgressReport{Trend: []ProgressPoint{}, MostMissed: []WordStat{}, Tracked: len(latest)}
+	for day, t := range days {
+		report.Trend = append(report.Trend, ProgressPoint{Date: day, Answers: t.answers, Accuracy: percent(t.credit, float64(t.answers))})
+	}
+	sort.Slice(report.Trend, func(i, j int) bool { return report.Trend[i].Date < report.Trend[j].Date })
+
+	for _, at := range latest {
+		if at.Credit >= masteryCredit {
+			report.Mastered++
+		}
+	}
+	report.Mastery = percent(float64(report.Mastered), float64(report.Tracked))
+
+	for word, s := range stats {
+		if s.Missed == 0 {
+			continue
+		}
+		s.Accuracy = percent(credits[word], float64(s.Attempts))
+		report.MostMissed = append(report.MostMissed, *s)
+	}
+	sort.Slice(report.MostMissed, func(i, j int) bool {
+		a, b := report.MostMissed[i], report.MostMissed[j]
+		if a.Missed != b.Missed {
+			return a.Missed > b.Missed
+		}
+		if a.Accuracy != b.Accuracy {
+			return a.Accuracy < b.Accuracy
+		}
+		return a.Word < b.Word
+	})
+	if len(report.MostMissed) > mostMissedLimit {
+		report.MostMissed = report.MostMissed[:mostMissedLimit]
+	}
+	return report
+}
+
+// progressFor builds a report over the attempts that keep returns true for.
+func (a *VocabApp) progressFor(keep func(wordAttempt) bool) (ProgressReport, error) {
+	a.mu.Lock()
+	attempts, err := loadWordAttempts()
+	a.mu.Unlock()
+	if err != nil {
+		return ProgressReport{}, err
+	}
+	var kept []wordAttempt
+	for _, at := range attempts {
+		if keep(at) {
+			kept = append(kept, at)
+		}
+	}
+	return progressReport(kept), nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetClassProgress reports the progress of every graded student of a class.
+func (a *VocabApp) GetClassProgress(class string) (ProgressReport, error) {
+	return a.progressFor(func(at wordAttempt) bool { return at.Class == class })
+}
+
+// GetStudentProgress reports the progress of one student.
+func (a *VocabApp) GetStudentProgress(student string) (ProgressReport, error) {
+	return a.progressFor(func(at wordAttempt) bool { return at.Student == student })
+}
+
+// GetWordListProgress reports how the words of a saved list were answered,
+// across all students.
+func (a *VocabApp) GetWordListProgress(listID string) (ProgressReport, error) {
+	a.mu.Lock()
+	lists, err := loadWordLists()
+	a.mu.Unlock()
+	if err != nil {
+		return ProgressReport{}, err
+	}
+	inList := map[string]bool{}
+	found := false
+	for _, l := range lists {
+		if l.ID == listID && l.DeletedAt == nil {
+			found = true
+			for _, e := range l.Entries {
+				inList[strings.ToLower(e.Word)] = true
+			}
+		}
+	}
+	if !found {
+		return ProgressReport{}, fmt.Errorf("단어장을 찾을 수 없습니다")
+	}
+	return a.progressFor(func(at wordAttempt) bool { return inList[strings.ToLower(at.Entry.Word)] })
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// --- API Profiles ---
+
+const (
+	ProviderOpenAI = "openai"
+	ProviderAzure  = "azure"
+
+	defaultAzureAPIVersion = "2024-06-01"
+)
+
+var errNoAPIKey = errors.New("API 키를 찾을 수 없습니다. api.json 파일이나 프로필 설정을 확인하세요.")
+
+// Profile is one named API connection. Schools that only reach OpenAI
+// through Azure keep an Azure profile next to (or instead of) a plain one.
+type Profile struct {
+	Name     string          `json:"name"`
+	Provider string          `json:"provider"`
+	APIKey   string          `json:"apiKey"`
+	Azure    *AzureSettings  `json:"azure,omitempty"`
+	Local    *LocalSettings  `json:"local,omitempty"`
+	Custom   *CustomSettings `json:"custom,omitempty"`
+}
+
+// AzureSettings configures an Azure OpenAI resource. Deployments maps the
+// model IDs used in the UI (gpt-4.1, ...) to the resource's deployment names.
+// With UseAzureAD the API key is ignored and a Microsoft Entra (Azure AD)
+// token is used instead: either the static ADToken, or one fetched with the
+// client-credentials flow from TenantID/ClientID/ClientSecret.
+type AzureSettings struct {
+	Endpoint     string            `json:"endpoint"`
+	APIVersion   string            `json:"apiVersion"`
+	Deployments  map[string]string `json:"deployments"`
+	UseAzureAD   bool              `json:"useAzureAD"`
+	ADToken      string            `json:"adToken"`
+	TenantID     string            `json:"tenantId"`
+	ClientID     string            `json:"clientId"`
+	ClientSecret string            `json:"clientSecret"`
+}
+
+func (s Settings) activeProfile() (Profile, bool) {
+	for _, p := range s.Profiles {
+		if p.Name == s.ActiveProfile {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+func validateProfile(p Profile) error {
+	if p.Name == "" {
+		return fmt.Errorf("프로필 이름이 비어 있습니다")
+	}
+	switch p.Provider {
+	case ProviderOpenAI, "":
+		return nil
+	case ProviderAzure:
+		if p.Azure == nil || p.Azure.Endpoint == "" {
+			return fmt.Errorf("%s: Azure 엔드포인트를 입력하세요", p.Name)
+		}
+		if _, err := url.ParseRequestURI(p.Azure.Endpoint); err != nil {
+			return fmt.Errorf("%s: Azure 엔드포인트 형식 오류: %w", p.Name, err)
+		}
+		if p.Azure.UseAzureAD {
+			if p.Azure.ADToken == "" && (p.Azure.TenantID == "" || p.Azure.ClientID == "" || p.Azure.ClientSecret == "") {
+				return fmt.Errorf("%s: Azure AD 토큰 또는 테넌트/클라이언트 정보를 입력하세요", p.Name)
+			}
+		} else if p.APIKey == "" {
+			return fmt.Errorf("%s: API 키를 입력하세요", p.Name)
+		}
+		return nil
+	case ProviderLocal:
+		return validateLocalSettings(p.Name, p.Local)
+	case ProviderCustom:
+		return validateCustomSettings(p.Name, p.Custom)
+	default:
+		return fmt.Errorf("%s: 지원하지 않는 제공자입니다: '%s'", p.Name, p.Provider)
+	}
+}
+
+// clientConfigForProfile builds the API client configuration of a profile.
+func clientConfigForProfile(p Profile) (openai.ClientConfig, error) {
+	if err := validateProfile(p); err != nil {
+		return openai.ClientConfig{}, err
+	}
+	if p.Provider == ProviderLocal {
+		config := openai.DefaultConfig("local")
+		config.BaseURL = p.Local.baseURL()
+		return config, nil
+	}
+	if p.Provider == ProviderCustom {
+		// Local servers ignore the key, but the header must not be empty.
+		key := p.APIKey
+		if key == "" {
+			key = "none"
+		}
+		config := openai.DefaultConfig(key)
+		config.BaseURL = strings.TrimRight(p.Custom.BaseURL, "/")
+		return config, nil
+	}
+	if p.Provider != ProviderAzure {
+		return openai.DefaultConfig(p.APIKey), nil
+	}
+
+	az := p.Azure
+	authToken := p.APIKey
+	var httpClient *http.Client
+	if az.UseAzureAD {
+		authToken = az.ADToken
+		if authToken == "" {
+			// Placeholder only; azureADTransport sets a fresh token per request.
+			authToken = "pending"
+			httpClient = &http.Client{Transport: &azureADTransport{settings: *az}}
+		}
+	}
+
+	config := openai.DefaultAzureConfig(authToken, strings.TrimRight(az.Endpoint, "/"))
+	config.APIVersion = az.APIVersion
+	if config.APIVersion == "" {
+		config.APIVersion = defaultAzureAPIVersion
+	}
+	deployments := az.Deployments
+	config.AzureModelMapperFunc = func(model string) string {
+		if d, ok := deployments[model]; ok && d != "" {
+			return d
+		}
+		return model
+	}
+	if az.UseAzureAD {
+		config.APIType = openai.APITypeAzureAD
+	}
+	if httpClient != nil {
+		config.HTTPClient = httpClient
+	}
+	return config, nil
+}
+
+// azureADTransport fetches Microsoft Entra tokens with the client-credentials
+// flow and refreshes them shortly before they expire.
+type azureADTransport struct {
+	settings AzureSettings
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (t *azureADTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.currentToken()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (t *azureADTransport) currentToken() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && time.Until(t.expires) > 2*time.Minute {
+		return t.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {t.settings.ClientID},
+		"client_secret": {t.settings.ClientSecret},
+		"scope":         {"https://cognitiveservices.azure.com/.default"},
+	}
+	tokenURL := fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", url.PathEscape(t.settings.TenantID))
+	resp, err := http.PostForm(tokenURL, form)
+	if err != nil {
+		return "", fmt.Errorf("Azure AD 토큰 요청 오류: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("Azure AD 토큰 응답 오류: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return "", fmt.Errorf("Azure AD 인증 실패 (%d): %s", resp.StatusCode, body.Error)
+	}
+	t.token = body.AccessToken
+	t.expires = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	return t.token, nil
+}
+
+// reloadClient rebuilds the API client from the active profile, falling back
+// to the key in api.json when no profile is selected.
+func (a *VocabApp) reloadClient() error {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if p, ok := settings.activeProfile(); ok {
+		config, err := clientConfigForProfile(p)
+		if err != nil {
+			return err
+		}
+		a.client = a.newClient(config)
+		a.provider = p.Provider
+		a.quirks = quirksForProfile(p)
+		a.caps.reset()
+		return nil
+	}
+	if apiKey := loadAPIKey(); apiKey != "" {
+		a.client = a.newClient(openai.DefaultConfig(apiKey))
+		a.provider = ProviderOpenAI
+		a.quirks = endpointQuirks{}
+		a.caps.reset()
+		return nil
+	}
+	a.client = nil
+	return errNoAPIKey
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetActiveProfile switches the API connection used by Generate.
+func (a *VocabApp) SetActiveProfile(name string) error {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	if err == nil {
+		settings.ActiveProfile = name
+		if _, ok := settings.activeProfile(); !ok && name != "" {
+			err = fmt.Errorf("프로필을 찾을 수 없습니다: '%s'", name)
+		} else {
+			err = saveJSON(settingsFile, settings)
+		}
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return a.reloadClient()
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Question Cap ---
+
+const (
+	TrimRandom   = "random"   // keep a random subset
+	TrimWeighted = "weighted" // prefer words the question bank has tested less often
+)
+
+// TrimReport is emitted as "generate:trimmed" when the cap dropped words.
+type TrimReport struct {
+	MaxQuestions int      `json:"maxQuestions"`
+	Requested    int      `json:"requested"`
+	Skipped      []string `json:"skipped"` // "word = senses" lines left out
+}
+
+// questionCost is how many questions an entry turns into. Only 빈칸 추론
+// with the default coverage asks for one question per sense.
+func (g GenerationSettings) questionCost(pair VocabPair, questionType string) int {
+	if questionType == "빈칸 추론" && g.SenseCoverage == "" {
+		return len(pair.Senses)
+	}
+	return 1
+}
+
+// capQuestions keeps entries until MaxQuestions is reached and returns the
+// rest as skipped. timesTested is only used by TrimWeighted. The kept entries
+// stay in their original order.
+func (g GenerationSettings) capQuestions(entries []VocabPair, questionType string, timesTested map[string]int) (kept []VocabPair, skipped []VocabPair, requested int) {
+	for _, e := range entries {
+		requested += g.questionCost(e, questionType)
+	}
+	if g.MaxQuestions <= 0 || requested <= g.MaxQuestions {
+		return entries, nil, requested
+	}
+
+	// Entries are already shuffled, so the random strategy just walks them in
+	// order. The weighted one draws an order with weights 1/(1+times tested)
+	// (Efraimidis-Spirakis keys).
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	if g.TrimStrategy == TrimWeighted {
+		keys := make([]float64, len(entries))
+		for i, e := range entries {
+			weight := 1 / float64(1+timesTested[strings.ToLower(e.Word)])
+			keys[i] = math.Pow(rand.Float64(), 1/weight)
+		}
+		sort.SliceStable(order, func(i, j int) bool { return keys[order[i]] > keys[order[j]] })
+	}
+
+	keep := make([]bool, len(entries))
+	total := 0
+	for _, i := range order {
+		if cost := g.questionCost(entries[i], questionType); total+cost <= g.MaxQuestions {
+			keep[i] = true
+			total += cost
+		}
+	}
+	for i, e := range entries {
+		if keep[i] {
+			kept = append(kept, e)
+		} else {
+			skipped = append(skipped, e)
+		}
+	}
+	return kept, skipped, requested
+}
+
+// bankTestCounts counts how often each word has been the answer of a
+// question in the bank. Must be called with a.mu held.
+func bankTestCounts() (map[string]int, error) {
+	bank, err := loadBank()
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for _, b := range bank {
+		q := b.Question
+		if b.DeletedAt == nil && q.Answer >= 1 && q.Answer <= len(q.Choices) {
+			counts[strings.ToLower(q.Choices[q.Answer-1])]++
+		}
+	}
+	return counts, nil
+}
+
+// cappedEntries splits the shuffled word list into question entries and
+// applies the question cap, telling the frontend which words were left out.
+func (a *VocabApp) cappedEntries(g GenerationSettings, parsed []VocabPair, questionType string) ([]VocabPair, error) {
+	entries := g.questionEntries(parsed, questionType)
+	var counts map[string]int
+	if g.MaxQuestions > 0 && g.TrimStrategy == TrimWeighted {
+		a.mu.Lock()
+		var err error
+		counts, err = bankTestCounts()
+		a.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+	kept, skipped, requested := g.capQuestions(entries, questionType, counts)
+	if len(skipped) == 0 {
+		return entries, nil
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("최대 문항 수(%d)가 너무 작아 출제할 단어가 없습니다", g.MaxQuestions)
+	}
+	report := TrimReport{MaxQuestions: g.MaxQuestions, Requested: requested}
+	for _, e := range skipped {
+		report.Skipped = append(report.Skipped, fmt.Sprintf("%s = %s", e.Word, strings.Join(e.Senses, ", ")))
+	}
+	runtime.EventsEmit(a.ctx, "generate:trimmed", report)
+	return kept, nil
+}
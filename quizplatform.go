@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Kahoot / Wooclap Spreadsheet Export ---
+
+// quizPlatform describes the import template of an online quiz platform.
+type quizPlatform struct {
+	Name           string
+	MaxQuestionLen int
+	MaxAnswerLen   int
+	MaxChoices     int
+	MaxQuestions   int
+	TimeLimits     []int // allowed values in seconds; the nearest one is used
+	header         []string
+	row            func(question string, choices []string, correct int, timeLimit int) []xlsxCell
+}
+
+var quizPlatforms = map[string]quizPlatform{
+	"kahoot": {
+		Name:           "Kahoot",
+		MaxQuestionLen: 120,
+		MaxAnswerLen:   75,
+		MaxChoices:     4,
+		MaxQuestions:   100,
+		TimeLimits:     []int{5, 10, 20, 30, 60, 90, 120, 240},
+		header: []string{"Question - max 120 characters", "Answer 1 - max 75 characters", "Answer 2 - max 75 characters",
+			"Answer 3 - max 75 characters", "Answer 4 - max 75 characters", "Time limit (sec) – 5, 10, 20, 30, 60, 90, 120, or 240 secs",
+			"Correct answer(s) - choose at least one"},
+		row: func(question string, choices []string, correct int, timeLimit int) []xlsxCell {
+			row := []xlsxCell{xlsxText(question)}
+			for i := 0; i < 4; i++ {
+				c := ""
+				if i < len(choices) {
+					c = choices[i]
+				}
+				row = append(row, xlsxText(c))
+			}
+			return append(row, xlsxNumber(float64(timeLimit)), xlsxNumber(float64(correct)))
+		},
+	},
+	"wooclap": {
+		Name:           "Wooclap",
+		MaxQuestionLen: 250,
+		MaxAnswerLen:   250,
+		MaxChoices:     4,
+		MaxQuestions:   200,
+		TimeLimits:     []int{10, 20, 30, 45, 60, 90, 120, 180, 240},
+		header:         []string{"Type", "Title", "Correct", "Choice", "Choice", "Choice", "Choice", "Time limit"},
+		row: func(question string, choices []string, correct int, timeLimit int) []xlsxCell {
+			row := []xlsxCell{xlsxText("MCQ"), xlsxText(question), xlsxNumber(float64(correct))}
+			for _, c := range choices {
+				row = append(row, xlsxText(c))
+			}
+			for i := len(choices); i < 4; i++ {
+				row = append(row, xlsxText(""))
+			}
+			return append(row, xlsxNumber(float64(timeLimit)))
+		},
+	},
+}
+
+// truncateRunes shortens s to at most max characters, marking the cut with "…".
+func truncateRunes(s string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:max-1]) + "…"
+}
+
+func nearestTimeLimit(allowed []int, seconds int) int {
+	best := allowed[0]
+	for _, t := range allowed {
+		if abs(t-seconds) < abs(best-seconds) {
+			best = t
+		}
+	}
+	return best
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// trimChoices drops distractors from the end until the platform's choice
+// limit is met, always keeping the correct answer, and returns the new
+// 1-based index of the correct choice.
+func trimChoices(choices []string, answer int, max int) ([]string, int) {
+	kept := append([]string(nil), choices...)
+	for len(kept) > max {
+		drop := len(kept) - 1
+		if drop == answer-1 {
+			drop--
+		}
+		kept = append(kept[:drop], kept[drop+1:]...)
+		if drop < answer-1 {
+			answer--
+		}
+	}
+	return kept, answer
+}
+
+type QuizSheetReport struct {
+	Exported  int      `json:"exported"`
+	Skipped   []int    `json:"skipped"`
+	Truncated []int    `json:"truncated"`
+	Warnings  []string `json:"warnings"`
+	Message   string   `json:"message"`
+}
+
+func buildQuizSheet(p quizPlatform, questions []Question, timeLimit int) ([]byte, QuizSheetReport, error) {
+	var report QuizSheetReport
+	rows := [][]xlsxCell{}
+	var header []xlsxCell
+	for _, h := range p.header {
+		header = append(header, xlsxText(h))
+	}
+	rows = append(rows, header)
+	limit := nearestTimeLimit(p.TimeLimits, timeLimit)
+
+	for _, q := range questions {
+		if q.Answer < 1 || q.Answer > len(q.Choices) {
+			report.Skipped = append(report.Skipped, q.Number)
+			continue
+		}
+		if report.Exported >= p.MaxQuestions {
+			report.Skipped = append(report.Skipped, q.Number)
+			continue
+		}
+		// The stem alone is the same for every question of a type; the
+		// sentences are what players actually need to see.
+		text := q.Title
+		if len(q.Body) > 0 {
+			text = strings.Join(q.Body, " / ")
+		}
+		choices, correct := trimChoices(q.Choices, q.Answer, p.MaxChoices)
+		truncated := utf8.RuneCountInString(text) > p.MaxQuestionLen
+		text = truncateRunes(text, p.MaxQuestionLen)
+		for i := range choices {
+			if utf8.RuneCountInString(choices[i]) > p.MaxAnswerLen {
+				truncated = true
+			}
+			choices[i] = truncateRunes(choices[i], p.MaxAnswerLen)
+		}
+		if truncated {
+			report.Truncated = append(report.Truncated, q.Number)
+		}
+		rows = append(rows, p.row(text, choices, correct, limit))
+		report.Exported++
+	}
+	if limit != timeLimit {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("제한 시간을 %d초로 맞췄습니다", limit))
+	}
+
+	data, err := buildXlsx(p.Name, rows)
+	return data, report, err
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExportQuizSheet writes an xlsx file in the import template of the given
+// platform ("kahoot" or "wooclap"). Questions are cut down to four choices and
+// to the platform's text limits; the report lists what was changed.
+func (a *VocabApp) ExportQuizSheet(contentToExport string, suggestedFilename string, platform string, timeLimitSeconds int) (QuizSheetReport, error) {
+	p, ok := quizPlatforms[platform]
+	if !ok {
+		return QuizSheetReport{}, fmt.Errorf("지원하지 않는 플랫폼입니다: '%s'", platform)
+	}
+	out := parseOutput(contentToExport)
+	if len(out.Questions) == 0 {
+		return QuizSheetReport{}, fmt.Errorf("내보낼 문제를 찾을 수 없습니다")
+	}
+	data, report, err := buildQuizSheet(p, out.Questions, timeLimitSeconds)
+	if err != nil {
+		return report, fmt.Errorf("스프레드시트 생성 오류: %w", err)
+	}
+
+	name, err := a.planExportName(contentToExport, "", suggestedFilename)
+	if err != nil {
+		return report, err
+	}
+	filePath, auto, err := a.savePath(&name, "_"+platform+".xlsx", runtime.SaveDialogOptions{
+		Title: p.Name + " 퀴즈 저장",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Excel 파일 (*.xlsx)",
+				Pattern:     "*.xlsx",
+			},
+		},
+	})
+	if err != nil {
+		return report, err
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return report, fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.exportSaved(name, filePath, auto)
+	report.Message = fmt.Sprintf("저장 완료: %s", filepath.Base(filePath))
+	return report, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Personalized Remedial Quizzes ---
+
+// PersonalizedQuiz is a remedial quiz generated from one student's missed
+// words.
+type PersonalizedQuiz struct {
+	Student string      `json:"student"`
+	Words   []VocabPair `json:"words"`
+	Output  string      `json:"output"`
+	Error   string      `json:"error"`
+}
+
+// questionWords maps the questions of a saved test to the entries they
+// test. The generation run gives the senses; without one, the keyed word is
+// used on its own.
+func questionWords(test SavedTest, runs []GenerationRun) map[int]VocabPair {
+	words := map[int]VocabPair{}
+	run, hasRun := findRun(runs, test.Content)
+	for _, q := range parseOutput(test.Content).Questions {
+		if hasRun {
+			if e, ok := sourceEntry(q, run.Entries); ok {
+				words[q.Number] = e
+				continue
+			}
+		}
+		switch {
+		case q.AnswerText != "":
+			words[q.Number] = VocabPair{Word: q.AnswerText}
+		case q.Answer >= 1 && q.Answer <= len(q.Choices) && wordChoiceTypes[test.QuestionType]:
+			words[q.Number] = VocabPair{Word: q.Choices[q.Answer-1]}
+		}
+	}
+	return words
+}
+
+// missedWords returns the words a student lost points on, most often missed
+// first. Must be called with a.mu held.
+func missedWords(student string) ([]VocabPair, error) {
+	attempts, err := loadWordAttempts()
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	entries := map[string]VocabPair{}
+	for _, at := range attempts {
+		if at.Student != student || at.Credit >= 1 {
+			continue
+		}
+		key := strings.ToLower(at.Entry.Word)
+		counts[key]++
+		if len(entries[key].Senses) == 0 {
+			entries[key] = at.Entry
+		}
+	}
+	missed := make([]VocabPair, 0, len(entries))
+	for _, e := range entries {
+		missed = append(missed, e)
+	}
+	sort.SliceStable(missed, func(i, j int) bool {
+		ci, cj := counts[strings.ToLower(missed[i].Word)], counts[strings.ToLower(missed[j].Word)]
+		if ci != cj {
+			return ci > cj
+		}
+		return missed[i].Word < missed[j].Word
+	})
+	return missed, nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// GeneratePersonalized generates a remedial quiz from the words the student
+// missed in their graded tests.
+func (a *VocabApp) GeneratePersonalized(student string, modelID string, questionType string, numSentences int) (PersonalizedQuiz, error) {
+	if a.client == nil {
+		return PersonalizedQuiz{}, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	if _, ok := defaultQuestionTitles[questionType]; !ok {
+		return PersonalizedQuiz{}, fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", questionType)
+	}
+	a.mu.Lock()
+	settings, err := loadSettings()
+	var words []VocabPair
+	if err == nil {
+		words, err = missedWords(student)
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return PersonalizedQuiz{}, err
+	}
+	quiz := PersonalizedQuiz{Student: student, Words: words}
+	if len(words) == 0 {
+		return quiz, fmt.Errorf("%s 학생이 틀린 단어가 없습니다", student)
+	}
+	quiz.Output, err = a.generate(settings, words, modelID, questionType, numSentences)
+	return quiz, err
+}
+
+// GenerateClassPersonalized generates a remedial quiz for every student of
+// the class that has graded results. Students without missed words are left
+// out; a failed student carries its error instead of failing the others.
+func (a *VocabApp) GenerateClassPersonalized(class string, modelID string, questionType string, numSentences int) ([]PersonalizedQuiz, error) {
+	a.mu.Lock()
+	grades, err := loadGrades()
+	a.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var students []string
+	for _, g := range grades {
+		if g.Class == class && !seen[g.Student] {
+			seen[g.Student] = true
+			students = append(students, g.Student)
+		}
+	}
+	if len(students) == 0 {
+		return nil, fmt.Errorf("%s 반의 채점 결과가 없습니다", class)
+	}
+	sort.Strings(students)
+
+	var quizzes []PersonalizedQuiz
+	for _, student := range students {
+		quiz, err := a.GeneratePersonalized(student, modelID, questionType, numSentences)
+		if len(quiz.Words) == 0 {
+			continue
+		}
+		if err != nil {
+			quiz.Error = err.Error()
+		}
+		quizzes = append(quizzes, quiz)
+	}
+	return quizzes, nil
+}
+
+// ExportPersonalized saves each quiz as "<student>_복습.docx" in the output
+// directory, or in a folder chosen by the user when none is configured.
+func (a *VocabApp) ExportPersonalized(quizzes []PersonalizedQuiz) (string, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	dir := settings.OutputDir
+	if dir == "" {
+		dir, err = runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{Title: "복습 퀴즈 저장 폴더 선택"})
+		if err != nil {
+			return "", err
+		}
+		if dir == "" {
+			return "", fmt.Errorf("저장 경로가 선택되지 않았습니다")
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("저장 폴더 생성 오류: %w", err)
+	}
+	saved := 0
+	for _, quiz := range quizzes {
+		if quiz.Output == "" {
+			continue
+		}
+		title := quiz.Student + " 복습 퀴즈"
+		opts, err := a.testExportOptions(title, quiz.Output)
+		if err != nil {
+			return "", err
+		}
+		content, err := renderTestDocx(quiz.Output, opts)
+		if err != nil {
+			return "", fmt.Errorf("문서 생성 오류: %w", err)
+		}
+		name := exportName{Title: invalidFilenameChars.Replace(quiz.Student) + "_복습"}
+		path := autoSavePath(dir, &name, ".docx")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return "", fmt.Errorf("파일 저장 오류: %w", err)
+		}
+		saved++
+	}
+	return fmt.Sprintf("복습 퀴즈 %d개 저장 완료: %s", saved, filepath.Base(dir)), nil
+}
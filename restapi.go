@@ -0,0 +1,175 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// --- REST API ---
+
+const defaultAPIPort = 8766
+
+// APISettings configures the optional REST API that lets scripts drive the
+// generator while the app is running. It listens on localhost only and every
+// request needs "Authorization: Bearer <Token>".
+type APISettings struct {
+	Enabled bool   `json:"enabled"`
+	Port    int    `json:"port"` // default 8766
+	Token   string `json:"token"`
+}
+
+func (s APISettings) port() int {
+	if s.Port == 0 {
+		return defaultAPIPort
+	}
+	return s.Port
+}
+
+func validateAPISettings(s APISettings) error {
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("API 포트는 1에서 65535 사이여야 합니다")
+	}
+	if s.Enabled && len(s.Token) < 16 {
+		return fmt.Errorf("API 서버를 사용하려면 16자 이상의 토큰이 필요합니다")
+	}
+	return nil
+}
+
+type apiEntry struct {
+	Word   string   `json:"word"`
+	Senses []string `json:"senses"`
+}
+
+type apiParseRequest struct {
+	Vocab string `json:"vocab"`
+}
+
+type apiGenerateRequest struct {
+	Vocab        string `json:"vocab"`
+	Model        string `json:"model"`
+	QuestionType string `json:"questionType"`
+	NumSentences int    `json:"numSentences"`
+}
+
+type apiExportRequest struct {
+	Content string `json:"content"`
+	Format  string `json:"format"` // an exporter ID, see GetExporters
+	Title   string `json:"title"`
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// apiHandler wraps an endpoint with the token check, POST-only routing and
+// decoding of the JSON request into req.
+func (a *VocabApp) apiHandler(token string, newReq func() interface{}, serve func(w http.ResponseWriter, req interface{})) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, fmt.Errorf("invalid token"))
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+			return
+		}
+		req := newReq()
+		if err := json.NewDecoder(io.LimitReader(r.Body, 10<<20)).Decode(req); err != nil {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		serve(w, req)
+	}
+}
+
+func (a *VocabApp) apiMux(token string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/api/parse", a.apiHandler(token, func() interface{} { return &apiParseRequest{} }, func(w http.ResponseWriter, req interface{}) {
+		entries := []apiEntry{}
+		for _, p := range parseVocabBlock(req.(*apiParseRequest).Vocab) {
+			entries = append(entries, apiEntry{Word: p.Word, Senses: p.Senses})
+		}
+		writeAPIJSON(w, http.StatusOK, map[string]interface{}{"entries": entries})
+	}))
+	mux.Handle("/api/generate", a.apiHandler(token, func() interface{} { return &apiGenerateRequest{} }, func(w http.ResponseWriter, req interface{}) {
+		g := req.(*apiGenerateRequest)
+		output, err := a.Generate(g.Vocab, g.Model, g.QuestionType, g.NumSentences)
+		if err != nil {
+			writeAPIError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, map[string]string{"output": output})
+	}))
+	mux.Handle("/api/export", a.apiHandler(token, func() interface{} { return &apiExportRequest{} }, func(w http.ResponseWriter, req interface{}) {
+		e := req.(*apiExportRequest)
+		title := strings.TrimSpace(e.Title)
+		if title == "" {
+			title = "vocab-test"
+		}
+		opts, err := a.testExportOptions(title, e.Content)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		data, ext, mimeType, err := renderExport(e.Format, e.Content, opts)
+		if err != nil {
+			writeAPIError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		w.Header().Set("Content-Type", mimeType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": title + ext}))
+		w.Write(data)
+	}))
+	return mux
+}
+
+// --- Go functions callable from Javascript ---
+
+// StartAPIServer starts the REST API on localhost and returns its base URL.
+func (a *VocabApp) StartAPIServer() (string, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	s := settings.API
+	if len(s.Token) < 16 {
+		return "", fmt.Errorf("API 토큰을 먼저 만드세요")
+	}
+	addr := fmt.Sprintf("127.0.0.1:%d", s.port())
+	if err := a.api.start(a, addr, a.apiMux(s.Token), "REST API"); err != nil {
+		return "", err
+	}
+	return "http://" + addr, nil
+}
+
+// StopAPIServer stops the REST API.
+func (a *VocabApp) StopAPIServer() error {
+	return a.api.stop()
+}
+
+// NewAPIToken replaces the REST API token. A running server keeps the old
+// token until it is restarted.
+func (a *VocabApp) NewAPIToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return "", err
+	}
+	settings.API.Token = randomURLSafe(24)
+	return settings.API.Token, saveJSON(settingsFile, settings)
+}
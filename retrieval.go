@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// --- Sentence Retrieval ---
+
+const sentenceIndexFile = "sentences.json"
+
+// embeddingModel embeds indexed sentences and queries; both must use the
+// same model, so changing it means rebuilding the index.
+const embeddingModel = openai.SmallEmbedding3
+
+// maxExemplars caps GenerationSettings.Exemplars.
+const maxExemplars = 5
+
+// embeddingBatch is the number of texts sent per embeddings call.
+const embeddingBatch = 100
+
+// IndexedSentence is one context sentence of an approved bank question with
+// its embedding.
+type IndexedSentence struct {
+	BankID       string    `json:"bankId"`
+	QuestionType string    `json:"questionType"`
+	Sentence     string    `json:"sentence"`
+	Vector       []float32 `json:"vector"`
+}
+
+// SentenceIndexStats describes the index for the settings UI.
+type SentenceIndexStats struct {
+	Sentences int `json:"sentences"`
+	Questions int `json:"questions"`
+	Added     int `json:"added"`
+}
+
+func loadSentenceIndex() ([]IndexedSentence, error) {
+	var index []IndexedSentence
+	return index, loadJSON(sentenceIndexFile, &index)
+}
+
+// embed returns the embeddings of texts in order.
+func (a *VocabApp) embed(texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embeddingBatch {
+		end := start + embeddingBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		resp, err := a.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{Input: texts[start:end], Model: embeddingModel})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("임베딩 API 오류: %w", err)
+		}
+		a.recordUsage(string(embeddingModel), resp.Usage)
+		if len(resp.Data) != end-start {
+			return nil, fmt.Errorf("임베딩 응답 개수가 맞지 않습니다")
+		}
+		sort.Slice(resp.Data, func(i, j int) bool { return resp.Data[i].Index < resp.Data[j].Index })
+		for _, d := range resp.Data {
+			vectors = append(vectors, d.Embedding)
+		}
+	}
+	return vectors, nil
+}
+
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// retrieveExemplars finds the k indexed sentences closest to each entry,
+// preferring sentences of the same question type, and returns them per word.
+func retrieveExemplars(index []IndexedSentence, queries [][]float32, entries []VocabPair, questionType string, k int) map[string][]string {
+	exemplars := map[string][]string{}
+	for i, e := range entries {
+		if _, done := exemplars[e.Word]; done {
+			continue
+		}
+		type scored struct {
+			sentence string
+			score    float64
+		}
+		var ranked []scored
+		for _, s := range index {
+			score := cosine(queries[i], s.Vector)
+			if s.QuestionType == questionType {
+				score += 0.05
+			}
+			ranked = append(ranked, scored{s.Sentence, score})
+		}
+		sort.Slice(ranked, func(x, y int) bool { return ranked[x].score > ranked[y].score })
+		var picked []string
+		for _, r := range ranked {
+			if len(picked) == k {
+				break
+			}
+			if !containsSentence(picked, r.sentence) {
+				picked = append(picked, r.sentence)
+			}
+		}
+		exemplars[e.Word] = picked
+	}
+	return exemplars
+}
+
+func containsSentence(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// exemplarSection quotes retrieved sentences for the system prompt.
+func exemplarSection(entries []VocabPair, exemplars map[string][]string) string {
+	lines := []string{
+		"### Style Exemplars",
+		"Sentences from earlier approved tests, retrieved for each word. Match their style, length and tone, but write new sentences.",
+	}
+	found := false
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if seen[e.Word] || len(exemplars[e.Word]) == 0 {
+			continue
+		}
+		seen[e.Word] = true
+		found = true
+		lines = append(lines, e.Word+":")
+		for _, s := range exemplars[e.Word] {
+			lines = append(lines, "- "+s)
+		}
+	}
+	if !found {
+		return ""
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exemplarsFor embeds the entries and returns the exemplar section for the
+// system prompt, or "" when the index is empty.
+func (a *VocabApp) exemplarsFor(entries []VocabPair, questionType string, k int) (string, error) {
+	a.mu.Lock()
+	index, err := loadSentenceIndex()
+	a.mu.Unlock()
+	if err != nil || len(index) == 0 {
+		return "", err
+	}
+	queries := make([]string, len(entries))
+	for i, e := range entries {
+		queries[i] = fmt.Sprintf("%s: %s", e.Word, strings.Join(e.Senses, ", "))
+	}
+	vectors, err := a.embed(queries)
+	if err != nil {
+		return "", err
+	}
+	return exemplarSection(entries, retrieveExemplars(index, vectors, entries, questionType, k)), nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// IndexSentences embeds the context sentences of the active bank questions
+// that are not indexed yet and drops sentences of questions that were
+// deleted or retired since.
+func (a *VocabApp) IndexSentences() (SentenceIndexStats, error) {
+	if a.client == nil {
+		return SentenceIndexStats{}, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	a.mu.Lock()
+	bank, err := loadBank()
+	var index []IndexedSentence
+	if err == nil {
+		index, err = loadSentenceIndex()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return SentenceIndexStats{}, err
+	}
+
+	live := map[string]BankQuestion{}
+	for _, q := range bank {
+		if q.DeletedAt == nil && !q.retired() && q.Status != QuestionNeedsReview {
+			live[q.ID] = q
+		}
+	}
+	kept := []IndexedSentence{}
+	indexed := map[string]bool{}
+	for _, s := range index {
+		if _, ok := live[s.BankID]; ok {
+			kept = append(kept, s)
+			indexed[s.BankID] = true
+		}
+	}
+	var pending []IndexedSentence
+	for id, q := range live {
+		if indexed[id] {
+			continue
+		}
+		for _, line := range q.Question.Body {
+			if line = strings.TrimSpace(line); len(strings.Fields(line)) >= 4 {
+				pending = append(pending, IndexedSentence{BankID: id, QuestionType: q.QuestionType, Sentence: line})
+			}
+		}
+	}
+	if len(pending) > 0 {
+		texts := make([]string, len(pending))
+		for i, s := range pending {
+			texts[i] = s.Sentence
+		}
+		vectors, err := a.embed(texts)
+		if err != nil {
+			return SentenceIndexStats{}, err
+		}
+		for i := range pending {
+			pending[i].Vector = vectors[i]
+		}
+		kept = append(kept, pending...)
+	}
+
+	a.mu.Lock()
+	err = saveJSON(sentenceIndexFile, kept)
+	a.mu.Unlock()
+	stats := SentenceIndexStats{Sentences: len(kept), Added: len(pending)}
+	questions := map[string]bool{}
+	for _, s := range kept {
+		questions[s.BankID] = true
+	}
+	stats.Questions = len(questions)
+	return stats, err
+}
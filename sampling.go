@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Candidate Sampling ---
+
+// maxCandidates caps GenerationSettings.Candidates; every candidate is a
+// full generation call.
+const maxCandidates = 5
+
+// candidateTemperatures are used in turn for the candidates, the first one
+// being the temperature of a normal run.
+var candidateTemperatures = []float32{1.0, 0.7, 1.2, 0.85, 1.1}
+
+// SamplingReport is emitted as "generate:sampled" for runs with more than
+// one candidate.
+type SamplingReport struct {
+	Candidates int   `json:"candidates"`
+	Failed     int   `json:"failed"`  // candidate calls that returned an error
+	Swapped    []int `json:"swapped"` // questions taken from another candidate than the best one overall
+	Issues     int   `json:"issues"`  // validation issues left in the picked questions
+}
+
+// fixedTemperature reports whether the model only accepts the default
+// temperature (reasoning models); its candidates differ by seed alone.
+func fixedTemperature(model string) bool {
+	if strings.HasPrefix(model, "ft:") {
+		model = strings.Split(model, ":")[1]
+	}
+	return strings.HasPrefix(model, "gpt-5") || strings.HasPrefix(model, "o1") || strings.HasPrefix(model, "o3") || strings.HasPrefix(model, "o4")
+}
+
+// callChatGPTSample is callChatGPT with a set temperature and seed. The
+// request is built like a normal run's, so the token log probabilities come
+// back for models that support them.
+func (a *VocabApp) callChatGPTSample(model string, systemPrompt string, userPrompt string, temperature float32, seed int) (string, []openai.ChatCompletionTokenLogprob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+	defer cancel()
+
+	req := a.generationRequest(model, systemPrompt, userPrompt)
+	req.Temperature = temperature
+	req.Seed = &seed
+	resp, err := a.client.CreateChatCompletion(ctx, req)
+	if err != nil && req.LogProbs && unsupportedRequest(err) {
+		a.caps.degrade(model, featureLogprobs)
+		req.LogProbs = false
+		resp, err = a.client.CreateChatCompletion(ctx, req)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("ChatGPT API 오류: %w", err)
+	}
+	a.recordUsage(model, resp.Usage)
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		return "", nil, fmt.Errorf("API가 빈 텍스트를 반환했습니다")
+	}
+	return resp.Choices[0].Message.Content, tokenLogprobs(resp.Choices[0].LogProbs), nil
+}
+
+// issueCounts counts the validation issues of every question.
+func issueCounts(r ValidationResult) map[int]int {
+	counts := map[int]int{}
+	for _, issue := range append(r.Failures, r.AnswerKeyErrors...) {
+		counts[issue.Question]++
+	}
+	return counts
+}
+
+// pickCandidates builds one output from the candidates. The candidate with
+// the fewest issues overall is the base; each of its questions is replaced by
+// the version with the fewest issues among the candidates that numbered the
+// same questions. The versions not picked are returned as alternatives per
+// question number, and the answer confidence of the picked versions from the
+// candidates' confidences.
+func pickCandidates(candidates []string, confidences []map[int]float64, entries []VocabPair, questionType string, numSentences int) (string, map[int][]Question, map[int]float64, SamplingReport) {
+	report := SamplingReport{Candidates: len(candidates)}
+	parsed := make([]ParsedOutput, len(candidates))
+	counts := make([]map[int]int, len(candidates))
+	base, fewest := -1, 0
+	for i, c := range candidates {
+		parsed[i] = parseOutput(c)
+		result := validateOutput(c, entries, questionType, numSentences)
+		counts[i] = issueCounts(result)
+		total := len(result.Failures) + len(result.AnswerKeyErrors)
+		if len(parsed[i].Questions) > 0 && (base < 0 || total < fewest) {
+			base, fewest = i, total
+		}
+	}
+	if base < 0 {
+		return candidates[0], nil, confidences[0], report
+	}
+
+	out := parsed[base]
+	alternatives := map[int][]Question{}
+	confidence := map[int]float64{}
+	for n, q := range out.Questions {
+		best := base
+		for i := range candidates {
+			if len(parsed[i].Questions) == len(out.Questions) && counts[i][q.Number] < counts[best][q.Number] {
+				best = i
+			}
+		}
+		for i := range candidates {
+			if i != best && len(parsed[i].Questions) == len(out.Questions) {
+				alternatives[q.Number] = append(alternatives[q.Number], parsed[i].Questions[n])
+			}
+		}
+		if best != base {
+			picked := parsed[best].Questions[n]
+			picked.Number = q.Number
+			out.Questions[n] = picked
+			report.Swapped = append(report.Swapped, q.Number)
+		}
+		if c, ok := confidences[best][parsed[best].Questions[n].Number]; ok {
+			confidence[q.Number] = c
+		}
+		report.Issues += counts[best][q.Number]
+	}
+	if len(report.Swapped) == 0 {
+		return candidates[base], alternatives, confidence, report
+	}
+	return formatOutput(out), alternatives, confidence, report
+}
+
+// sampleCandidates generates k versions of the same prompt concurrently and
+// keeps the best version of every question, with its answer confidence.
+func (a *VocabApp) sampleCandidates(modelID string, systemPrompt string, userPrompt string, k int, entries []VocabPair, questionType string, numSentences int) (string, map[int][]Question, map[int]float64, error) {
+	outputs := make([]string, k)
+	logprobs := make([][]openai.ChatCompletionTokenLogprob, k)
+	errs := make([]error, k)
+	var wg sync.WaitGroup
+	for i := 0; i < k; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			temperature := candidateTemperatures[i%len(candidateTemperatures)]
+			if fixedTemperature(modelID) {
+				temperature = 1.0
+			}
+			outputs[i], logprobs[i], errs[i] = a.callChatGPTSample(modelID, systemPrompt, userPrompt, temperature, i+1)
+		}(i)
+	}
+	wg.Wait()
+
+	var candidates []string
+	var confidences []map[int]float64
+	var lastErr error
+	for i, err := range errs {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		candidates = append(candidates, outputs[i])
+		confidences = append(confidences, answerConfidence(outputs[i], logprobs[i]))
+	}
+	if len(candidates) == 0 {
+		return "", nil, nil, lastErr
+	}
+	output, alternatives, confidence, report := pickCandidates(candidates, confidences, entries, questionType, numSentences)
+	report.Candidates = k
+	report.Failed = k - len(candidates)
+	runtime.EventsEmit(a.ctx, "generate:sampled", report)
+	return output, alternatives, confidence, nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// SwapBankAlternative swaps a bank question with one of the alternative
+// versions sampled alongside it; the replaced version becomes an alternative.
+func (a *VocabApp) SwapBankAlternative(id string, index int) (BankQuestion, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bank, err := loadBank()
+	if err != nil {
+		return BankQuestion{}, err
+	}
+	for i := range bank {
+		b := &bank[i]
+		if b.ID != id || b.DeletedAt != nil {
+			continue
+		}
+		if index < 0 || index >= len(b.Alternatives) {
+			return BankQuestion{}, fmt.Errorf("대체 문항을 찾을 수 없습니다")
+		}
+		alt := b.Alternatives[index]
+		alt.Number = b.Question.Number
+		b.Alternatives[index] = b.Question
+		b.Question = alt
+		return *b, saveJSON(bankFile, bank)
+	}
+	return BankQuestion{}, fmt.Errorf("문항을 찾을 수 없습니다")
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Scheduled Generation ---
+
+const schedulesFile = "schedules.json"
+
+// Schedule is a recurring generation job, e.g. every Monday at 7:00, 30
+// random words of a saved list as 빈칸 추론, exported as Word into a folder.
+// Jobs run while the app is open; a run missed while it was closed happens
+// once at the next start.
+type Schedule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Weekdays are time.Weekday values (0 = Sunday); Hour and Minute are
+	// local time.
+	Weekdays []int `json:"weekdays"`
+	Hour     int   `json:"hour"`
+	Minute   int   `json:"minute"`
+
+	WordListID   string `json:"wordListId"`
+	WordCount    int    `json:"wordCount"` // random words per run, 0 = the whole list
+	QuestionType string `json:"questionType"`
+	Model        string `json:"model"`
+	NumSentences int    `json:"numSentences"`
+	Format       string `json:"format"`    // exporter ID, see GetExporters
+	OutputDir    string `json:"outputDir"` // "" = the output folder from the settings
+
+	CreatedAt time.Time  `json:"createdAt"`
+	LastRun   *time.Time `json:"lastRun,omitempty"`
+	LastPath  string     `json:"lastPath,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+}
+
+// ScheduleRun is emitted as "schedule:ran" after every scheduled run.
+type ScheduleRun struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+func loadSchedules() ([]Schedule, error) {
+	var schedules []Schedule
+	return schedules, loadJSON(schedulesFile, &schedules)
+}
+
+func validateSchedule(s Schedule) error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("예약 이름을 입력하세요")
+	}
+	if len(s.Weekdays) == 0 {
+		return fmt.Errorf("실행할 요일을 하나 이상 선택하세요")
+	}
+	for _, d := range s.Weekdays {
+		if d < 0 || d > 6 {
+			return fmt.Errorf("잘못된 요일입니다: %d", d)
+		}
+	}
+	if s.Hour < 0 || s.Hour > 23 || s.Minute < 0 || s.Minute > 59 {
+		return fmt.Errorf("실행 시각이 올바르지 않습니다")
+	}
+	if s.WordListID == "" {
+		return fmt.Errorf("단어장을 선택하세요")
+	}
+	if s.WordCount < 0 {
+		return fmt.Errorf("단어 수는 0 이상이어야 합니다")
+	}
+	if _, ok := defaultQuestionTitles[s.QuestionType]; !ok {
+		return fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", s.QuestionType)
+	}
+	if err := validateModelID(s.Model); err != nil {
+		return err
+	}
+	if s.Format != "" {
+		if _, err := findExporter(s.Format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextRun returns the first scheduled time after t.
+func (s Schedule) nextRun(t time.Time) time.Time {
+	for day := 0; day <= 7; day++ {
+		d := t.AddDate(0, 0, day)
+		at := time.Date(d.Year(), d.Month(), d.Day(), s.Hour, s.Minute, 0, 0, t.Location())
+		if !at.After(t) {
+			continue
+		}
+		for _, w := range s.Weekdays {
+			if int(at.Weekday()) == w {
+				return at
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// due reports whether a scheduled time has passed since the last run.
+func (s Schedule) due(now time.Time) bool {
+	if !s.Enabled {
+		return false
+	}
+	last := s.CreatedAt
+	if s.LastRun != nil {
+		last = *s.LastRun
+	}
+	next := s.nextRun(last)
+	return !next.IsZero() && !next.After(now)
+}
+
+// sampleWords picks n random entries, or all of them when n is 0 or larger
+// than the list.
+func sampleWords(entries []VocabPair, n int) []VocabPair {
+	picked := append([]VocabPair(nil), entries...)
+	rand.Shuffle(len(picked), func(i, j int) { picked[i], picked[j] = picked[j], picked[i] })
+	if n > 0 && n < len(picked) {
+		picked = picked[:n]
+	}
+	return picked
+}
+
+// runSchedule generates and exports one run of the job and returns the
+// saved file.
+func (a *VocabApp) runSchedule(s Schedule) (string, error) {
+	if a.client == nil {
+		return "", fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	a.mu.Lock()
+	lists, err := loadWordLists()
+	var settings Settings
+	if err == nil {
+		settings, err = loadSettings()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	var entries []VocabPair
+	for _, l := range lists {
+		if l.ID == s.WordListID && l.DeletedAt == nil {
+			entries = l.Entries
+		}
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("단어장을 찾을 수 없습니다")
+	}
+
+	output, err := a.generate(settings, sampleWords(entries, s.WordCount), s.Model, s.QuestionType, s.NumSentences)
+	if err != nil {
+		return "", err
+	}
+
+	dir := s.OutputDir
+	if dir == "" {
+		dir = settings.OutputDir
+	}
+	if dir == "" {
+		return "", fmt.Errorf("저장 폴더를 지정하세요")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("저장 폴더 생성 오류: %w", err)
+	}
+	format := s.Format
+	if format == "" {
+		format = "docx"
+	}
+	name, err := a.planExportName(output, s.QuestionType, s.Name+"_"+time.Now().Format("20060102"))
+	if err != nil {
+		return "", err
+	}
+	opts, err := a.testExportOptions(name.Title, output)
+	if err != nil {
+		return "", err
+	}
+	data, ext, _, err := renderExport(format, output, opts)
+	if err != nil {
+		return "", fmt.Errorf("문서 생성 오류: %w", err)
+	}
+	path := autoSavePath(dir, &name, ext)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.exportSaved(name, path, true)
+	return path, nil
+}
+
+// runDueSchedules runs every job whose time has come. The run is recorded
+// before it starts so a slow generation is not started twice.
+func (a *VocabApp) runDueSchedules() {
+	now := time.Now()
+	a.mu.Lock()
+	schedules, err := loadSchedules()
+	var due []Schedule
+	if err == nil {
+		for i := range schedules {
+			if schedules[i].due(now) {
+				schedules[i].LastRun = &now
+				due = append(due, schedules[i])
+			}
+		}
+		if len(due) > 0 {
+			err = saveJSON(schedulesFile, schedules)
+		}
+	}
+	a.mu.Unlock()
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "예약 작업 오류: %v", err)
+		return
+	}
+	for _, s := range due {
+		a.finishScheduleRun(s.ID, s.Name)
+	}
+}
+
+// finishScheduleRun runs the job, stores the outcome and tells the UI.
+func (a *VocabApp) finishScheduleRun(id string, name string) ScheduleRun {
+	a.mu.Lock()
+	schedules, err := loadSchedules()
+	a.mu.Unlock()
+	result := ScheduleRun{ID: id, Name: name}
+	var job *Schedule
+	for i := range schedules {
+		if schedules[i].ID == id {
+			job = &schedules[i]
+		}
+	}
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case job == nil:
+		result.Error = "예약 작업을 찾을 수 없습니다"
+	default:
+		result.Path, err = a.runSchedule(*job)
+		if err != nil {
+			result.Error = err.Error()
+		}
+	}
+
+	a.mu.Lock()
+	if schedules, err := loadSchedules(); err == nil {
+		for i := range schedules {
+			if schedules[i].ID == id {
+				schedules[i].LastPath, schedules[i].LastError = result.Path, result.Error
+			}
+		}
+		if err := saveJSON(schedulesFile, schedules); err != nil {
+			runtime.LogErrorf(a.ctx, "예약 작업 저장 오류: %v", err)
+		}
+	}
+	a.mu.Unlock()
+	runtime.EventsEmit(a.ctx, "schedule:ran", result)
+	return result
+}
+
+// runScheduler checks the jobs every minute until the app closes.
+func (a *VocabApp) runScheduler() {
+	a.runDueSchedules()
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.runDueSchedules()
+		}
+	}
+}
+
+// --- Go functions callable from Javascript ---
+
+// ListSchedules returns the scheduled jobs.
+func (a *VocabApp) ListSchedules() ([]Schedule, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	schedules, err := loadSchedules()
+	if schedules == nil {
+		schedules = []Schedule{}
+	}
+	return schedules, err
+}
+
+// SaveSchedule creates a job (empty ID) or updates the one with the same ID.
+func (a *VocabApp) SaveSchedule(s Schedule) (Schedule, error) {
+	s.Name = strings.TrimSpace(s.Name)
+	if err := validateSchedule(s); err != nil {
+		return Schedule{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	schedules, err := loadSchedules()
+	if err != nil {
+		return Schedule{}, err
+	}
+	if s.ID == "" {
+		s.ID = newID()
+		s.CreatedAt = time.Now()
+		s.LastRun, s.LastPath, s.LastError = nil, "", ""
+		schedules = append(schedules, s)
+		return s, saveJSON(schedulesFile, schedules)
+	}
+	for i := range schedules {
+		if schedules[i].ID == s.ID {
+			s.CreatedAt, s.LastRun = schedules[i].CreatedAt, schedules[i].LastRun
+			s.LastPath, s.LastError = schedules[i].LastPath, schedules[i].LastError
+			schedules[i] = s
+			return s, saveJSON(schedulesFile, schedules)
+		}
+	}
+	return Schedule{}, fmt.Errorf("예약 작업을 찾을 수 없습니다")
+}
+
+func (a *VocabApp) DeleteSchedule(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	schedules, err := loadSchedules()
+	if err != nil {
+		return err
+	}
+	kept := schedules[:0]
+	for _, s := range schedules {
+		if s.ID != id {
+			kept = append(kept, s)
+		}
+	}
+	return saveJSON(schedulesFile, kept)
+}
+
+// RunScheduleNow runs a job immediately, outside its schedule.
+func (a *VocabApp) RunScheduleNow(id string) (ScheduleRun, error) {
+	a.mu.Lock()
+	schedules, err := loadSchedules()
+	a.mu.Unlock()
+	if err != nil {
+		return ScheduleRun{}, err
+	}
+	for _, s := range schedules {
+		if s.ID == id {
+			run := a.finishScheduleRun(s.ID, s.Name)
+			if run.Error != "" {
+				return run, fmt.Errorf("%s", run.Error)
+			}
+			return run, nil
+		}
+	}
+	return ScheduleRun{}, fmt.Errorf("예약 작업을 찾을 수 없습니다")
+}
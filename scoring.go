@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// --- Score Weighting ---
+
+// PointSettings assigns points to questions. A question is worth its
+// per-test override (SetTestPoints), else the points of its question type,
+// else Default (1 when unset).
+type PointSettings struct {
+	// Enabled prints the points of each question and the total on exports.
+	Enabled bool               `json:"enabled"`
+	Default float64            `json:"default"`
+	ByType  map[string]float64 `json:"byType"`
+}
+
+func validatePointSettings(p PointSettings) error {
+	if p.Default < 0 {
+		return fmt.Errorf("기본 배점은 0 이상이어야 합니다")
+	}
+	for qType, points := range p.ByType {
+		if points < 0 {
+			return fmt.Errorf("%s 배점은 0 이상이어야 합니다", qType)
+		}
+	}
+	return nil
+}
+
+func (p PointSettings) forType(questionType string) float64 {
+	if points, ok := p.ByType[questionType]; ok && points > 0 {
+		return points
+	}
+	if p.Default > 0 {
+		return p.Default
+	}
+	return 1
+}
+
+// formatPoints prints 2 as "2" and 1.5 as "1.5".
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+func totalPoints(points map[int]float64) float64 {
+	total := 0.0
+	for _, p := range points {
+		total += p
+	}
+	return total
+}
+
+// questionPoints works out the points of each question in content, keyed by
+// question number. The question type and overrides come from the saved test
+// or generation run the content belongs to.
+func (a *VocabApp) questionPoints(content string, settings PointSettings) map[int]float64 {
+	questionType := ""
+	var overrides map[int]float64
+	a.mu.Lock()
+	tests, err := loadTests()
+	a.mu.Unlock()
+	if err == nil {
+		for i := len(tests) - 1; i >= 0; i-- {
+			if tests[i].DeletedAt == nil && strings.TrimSpace(tests[i].Content) == strings.TrimSpace(content) {
+				questionType, overrides = tests[i].QuestionType, tests[i].Points
+				break
+			}
+		}
+	}
+	if questionType == "" {
+		if meta := a.exportMetadata(content); meta != nil {
+			questionType = meta.QuestionType
+		}
+	}
+	points := map[int]float64{}
+	for _, q := range parseOutput(content).Questions {
+		points[q.Number] = settings.forType(questionType)
+		if p, ok := overrides[q.Number]; ok {
+			points[q.Number] = p
+		}
+	}
+	return points
+}
+
+// formPoints rounds points for Google Forms, which only takes whole points.
+func formPoints(points float64) int {
+	return int(math.Max(1, math.Round(points)))
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetTestPoints sets per-question points for a saved test, keyed by question
+// number. Questions not listed keep the points of their question type.
+func (a *VocabApp) SetTestPoints(testID string, points map[int]float64) (SavedTest, error) {
+	for n, p := range points {
+		if p < 0 {
+			return SavedTest{}, fmt.Errorf("%d번 배점은 0 이상이어야 합니다", n)
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests, err := loadTests()
+	if err != nil {
+		return SavedTest{}, err
+	}
+	for i := range tests {
+		if tests[i].ID == testID && tests[i].DeletedAt == nil {
+			if err := errIfLocked(tests[i]); err != nil {
+				return SavedTest{}, err
+			}
+			tests[i].Points = points
+			return tests[i], saveJSON(testsFile, tests)
+		}
+	}
+	return SavedTest{}, fmt.Errorf("시험지를 찾을 수 없습니다")
+}
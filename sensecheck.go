@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Sense Disambiguation Check ---
+
+const senseCheckPrompt = `You are a strict reviewer of English vocabulary tests for Korean students.
+Each question below tests a word that has several senses. For every question you get the sense(s) it is meant to test and the word's other senses.
+Decide whether the question unambiguously targets an intended sense: the sentence context must rule out the other senses, and a student who only knows one of the other senses must not be able to justify a different answer or the same answer for the wrong reason.
+Respond with JSON only: {"results": [{"question": 1, "targets": "the sense the question actually tests", "ambiguous": false, "reason": "short reason"}]}. Set "ambiguous" to true when another sense also fits or the question tests a sense that is not intended.`
+
+// SenseCheckReport is emitted as "generate:senses-checked".
+type SenseCheckReport struct {
+	Checked   int               `json:"checked"`
+	Conflicts []ValidationIssue `json:"conflicts"`
+}
+
+type senseCheckResult struct {
+	Results []struct {
+		Question  int    `json:"question"`
+		Targets   string `json:"targets"`
+		Ambiguous bool   `json:"ambiguous"`
+		Reason    string `json:"reason"`
+	} `json:"results"`
+}
+
+// wordSenses collects every sense of every word in the list, keyed by the
+// lowercased word.
+func wordSenses(parsed []VocabPair) map[string][]string {
+	senses := map[string][]string{}
+	for _, e := range parsed {
+		key := strings.ToLower(e.Word)
+		for _, s := range e.Senses {
+			if !containsString(senses[key], s) {
+				senses[key] = append(senses[key], s)
+			}
+		}
+	}
+	return senses
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSenses asks the model whether the questions on polysemous words
+// target their intended sense and returns one issue per conflicting
+// question. Questions on single-sense words are not sent.
+func (a *VocabApp) checkSenses(modelID string, output string, entries []VocabPair, parsed []VocabPair) (SenseCheckReport, error) {
+	report := SenseCheckReport{Conflicts: []ValidationIssue{}}
+	all := wordSenses(parsed)
+	var request strings.Builder
+	for _, q := range parseOutput(output).Questions {
+		e, ok := sourceEntry(q, entries)
+		if !ok {
+			continue
+		}
+		var others []string
+		for _, s := range all[strings.ToLower(e.Word)] {
+			if !containsString(e.Senses, s) {
+				others = append(others, s)
+			}
+		}
+		if len(e.Senses)+len(others) < 2 {
+			continue
+		}
+		report.Checked++
+		fmt.Fprintf(&request, "Question %d on '%s'\n", q.Number, e.Word)
+		fmt.Fprintf(&request, "  Intended sense(s): %s\n", strings.Join(e.Senses, ", "))
+		if len(others) > 0 {
+			fmt.Fprintf(&request, "  Other senses: %s\n", strings.Join(others, ", "))
+		} else {
+			request.WriteString("  (All senses are intended; the question must clearly test one of them.)\n")
+		}
+		fmt.Fprintf(&request, "  %s\n", q.Title)
+		for _, line := range q.Body {
+			fmt.Fprintf(&request, "  %s\n", line)
+		}
+		for i, c := range q.Choices {
+			fmt.Fprintf(&request, "  %d) %s\n", i+1, c)
+		}
+		fmt.Fprintf(&request, "  Answer: %s\n\n", answerKeyEntry(q))
+	}
+	if report.Checked == 0 {
+		return report, nil
+	}
+
+	var result senseCheckResult
+	if err := a.callChatGPTJSON(modelID, senseCheckPrompt, request.String(), &result); err != nil {
+		return report, err
+	}
+	for _, r := range result.Results {
+		if r.Ambiguous {
+			report.Conflicts = append(report.Conflicts, ValidationIssue{
+				Question: r.Question,
+				Message:  fmt.Sprintf("의도한 뜻이 분명하지 않습니다 (출제된 뜻: %s): %s", r.Targets, r.Reason),
+			})
+		}
+	}
+	return report, nil
+}
+
+// checkSensesAndReport runs checkSenses for Generate. The check only flags
+// conflicts; a failed check is logged and generation goes on.
+func (a *VocabApp) checkSensesAndReport(modelID string, output string, entries []VocabPair, parsed []VocabPair) {
+	report, err := a.checkSenses(modelID, output, entries, parsed)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "뜻 구분 검사 오류: %v", err)
+		return
+	}
+	runtime.EventsEmit(a.ctx, "generate:senses-checked", report)
+}
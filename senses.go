@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// --- Sense Flags ---
+
+// Flags of single senses, set in the word list editor or typed in a vocab
+// block as a mark in front of the sense ("abandon = 버리다, !포기하다").
+const (
+	SenseSkip     = "skip"     // never tested
+	SensePriority = "priority" // tested before the other senses
+)
+
+var senseMarks = map[string]string{SenseSkip: "!", SensePriority: "*"}
+
+// splitSenseMark strips a flag mark from a sense as typed in a vocab block.
+func splitSenseMark(sense string) (string, string) {
+	for flag, mark := range senseMarks {
+		if strings.HasPrefix(sense, mark) {
+			return strings.TrimSpace(strings.TrimPrefix(sense, mark)), flag
+		}
+	}
+	return sense, ""
+}
+
+// markedSenses renders the senses of an entry with their flag marks and
+// notes, the inverse of addSense.
+func markedSenses(pair VocabPair) []string {
+	senses := make([]string, len(pair.Senses))
+	for i, s := range pair.Senses {
+		senses[i] = senseMarks[pair.SenseFlags[s]] + s
+		if note := pair.SenseNotes[s]; note != "" {
+			senses[i] += "(" + note + ")"
+		}
+	}
+	return senses
+}
+
+// addSense appends a sense as typed in a vocab block or the editor, taking
+// off its flag mark and note.
+func (p *VocabPair) addSense(raw string) {
+	sense, flag := splitSenseMark(strings.TrimSpace(raw))
+	sense, note := splitSenseNote(sense)
+	if sense == "" {
+		return
+	}
+	p.Senses = append(p.Senses, sense)
+	p.setSenseFlag(sense, flag)
+	p.setSenseNote(sense, note)
+}
+
+// copySense appends sense with the flag and note it has in from.
+func (p *VocabPair) copySense(from VocabPair, sense string) {
+	p.Senses = append(p.Senses, sense)
+	p.setSenseFlag(sense, from.SenseFlags[sense])
+	p.setSenseNote(sense, from.SenseNotes[sense])
+}
+
+// setSenseFlag records the flag of a sense; an empty flag clears it.
+func (p *VocabPair) setSenseFlag(sense string, flag string) {
+	if flag == "" {
+		delete(p.SenseFlags, sense)
+		if len(p.SenseFlags) == 0 {
+			p.SenseFlags = nil
+		}
+		return
+	}
+	if p.SenseFlags == nil {
+		p.SenseFlags = map[string]string{}
+	}
+	p.SenseFlags[sense] = flag
+}
+
+// --- Sense Notes ---
+
+// senseNoteRe matches a sense with a trailing annotation: "은행(銀行)",
+// "은행(금융기관)", "bank (money)".
+var senseNoteRe = regexp.MustCompile(`^(.+?)\s*[(（]([^()（）]+)[)）]$`)
+
+// senseNoteRule is added to the system prompt with
+// GenerationSettings.HideSenseNotes.
+const senseNoteRule = "### Sense Notes\nThe annotations in parentheses after the Korean senses are hints for you only. Never print them in the questions, the choices or the answer key."
+
+// splitSenseNote separates the annotation of a sense.
+func splitSenseNote(sense string) (string, string) {
+	if m := senseNoteRe.FindStringSubmatch(sense); m != nil {
+		return strings.TrimSpace(m[1]), strings.TrimSpace(m[2])
+	}
+	return sense, ""
+}
+
+// setSenseNote records the note of a sense; an empty note clears it.
+func (p *VocabPair) setSenseNote(sense string, note string) {
+	if note == "" {
+		delete(p.SenseNotes, sense)
+		if len(p.SenseNotes) == 0 {
+			p.SenseNotes = nil
+		}
+		return
+	}
+	if p.SenseNotes == nil {
+		p.SenseNotes = map[string]string{}
+	}
+	p.SenseNotes[sense] = note
+}
+
+// splitSenseList splits the senses of a line at commas and semicolons that
+// are not inside parentheses, so notes may contain them.
+func splitSenseList(senses string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range senses {
+		switch r {
+		case '(', '（':
+			depth++
+		case ')', '）':
+			if depth > 0 {
+				depth--
+			}
+		case ',', ';':
+			if depth == 0 {
+				parts = append(parts, senses[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, senses[start:])
+}
+
+func hasSenseNotes(entries []VocabPair) bool {
+	for _, e := range entries {
+		if len(e.SenseNotes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// stripSenseNotes removes the notes of the entries from generated output,
+// for models that printed them despite senseNoteRule.
+func stripSenseNotes(output string, entries []VocabPair) string {
+	for _, e := range entries {
+		for sense, note := range e.SenseNotes {
+			output = strings.ReplaceAll(output, sense+"("+note+")", sense)
+			output = strings.ReplaceAll(output, sense+" ("+note+")", sense)
+		}
+	}
+	return output
+}
+
+// applySenseFlags prepares a list for generation: skipped senses are dropped,
+// priority senses move to the front of their word, and words left without
+// senses are dropped.
+func applySenseFlags(parsed []VocabPair) []VocabPair {
+	kept := make([]VocabPair, 0, len(parsed))
+	for _, e := range parsed {
+		if len(e.SenseFlags) == 0 {
+			kept = append(kept, e)
+			continue
+		}
+		var priority, rest []string
+		for _, s := range e.Senses {
+			switch e.SenseFlags[s] {
+			case SenseSkip:
+			case SensePriority:
+				priority = append(priority, s)
+			default:
+				rest = append(rest, s)
+			}
+		}
+		if len(priority)+len(rest) == 0 {
+			continue
+		}
+		pair := VocabPair{Word: e.Word, Seed: e.Seed, Note: e.Note}
+		for _, s := range append(priority, rest...) {
+			pair.copySense(e, s)
+		}
+		kept = append(kept, pair)
+	}
+	return kept
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetSenseFlag marks one sense of the entry at index as SenseSkip or
+// SensePriority, or clears its flag with an empty flag.
+func (a *VocabApp) SetSenseFlag(index int, sense string, flag string) (ListState, error) {
+	switch flag {
+	case "", SenseSkip, SensePriority:
+	default:
+		return a.GetWorkingList(), fmt.Errorf("지원하지 않는 뜻 표시입니다: '%s'", flag)
+	}
+	return a.list.apply("뜻 표시 변경", func(entries []VocabPair) ([]VocabPair, error) {
+		if index < 0 || index >= len(entries) {
+			return nil, fmt.Errorf("잘못된 항목 번호입니다: %d", index)
+		}
+		for _, s := range entries[index].Senses {
+			if s == sense {
+				entries[index].setSenseFlag(sense, flag)
+				return entries, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s'에 없는 뜻입니다: %s", entries[index].Word, sense)
+	})
+}
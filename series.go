@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Weekly Quiz Series ---
+
+const seriesFile = "series.json"
+
+const (
+	RotationSequential = "sequential" // list order, WordsPerWeek at a time
+	RotationShuffled   = "shuffled"   // one shuffled order fixed when the series is created
+)
+
+// SeriesPart is one question type of a series quiz.
+type SeriesPart struct {
+	QuestionType string `json:"questionType"`
+	NumSentences int    `json:"numSentences"`
+}
+
+// Series is a weekly quiz configured once: every GenerateNextInSeries call
+// takes the next WordsPerWeek words of the list (wrapping around), mixes in
+// ReviewWords from earlier weeks and produces the next week's quiz.
+type Series struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	WordListID   string       `json:"wordListId"`
+	Rotation     string       `json:"rotation"`
+	WordsPerWeek int          `json:"wordsPerWeek"`
+	ReviewWords  int          `json:"reviewWords"`
+	Parts        []SeriesPart `json:"parts"`
+	Model        string       `json:"model"`
+	Format       string       `json:"format"`    // exporter ID, "" = Word
+	OutputDir    string       `json:"outputDir"` // "" = the output folder from the settings
+
+	// Plan fixes the words of every week (see CreateSemesterPlan) instead of
+	// the rotation; the series ends after the last planned week.
+	Plan []PlanWeek `json:"plan,omitempty"`
+
+	// Week is the number of weeks generated so far; Order is the word order
+	// of the rotation.
+	Week      int       `json:"week"`
+	Order     []string  `json:"order"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SeriesWeek is the outcome of GenerateNextInSeries.
+type SeriesWeek struct {
+	Week   int      `json:"week"`
+	Words  []string `json:"words"`
+	Review []string `json:"review"`
+	Output string   `json:"output"`
+	TestID string   `json:"testId"`
+	Path   string   `json:"path"`
+}
+
+func loadSeries() ([]Series, error) {
+	var series []Series
+	return series, loadJSON(seriesFile, &series)
+}
+
+func validateSeries(s Series) error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("시리즈 이름을 입력하세요")
+	}
+	if s.WordListID == "" {
+		return fmt.Errorf("단어장을 선택하세요")
+	}
+	switch s.Rotation {
+	case "", RotationSequential, RotationShuffled:
+	default:
+		return fmt.Errorf("지원하지 않는 단어 순환 방식입니다: '%s'", s.Rotation)
+	}
+	if s.WordsPerWeek < 1 {
+		return fmt.Errorf("주당 단어 수는 1 이상이어야 합니다")
+	}
+	if s.ReviewWords < 0 {
+		return fmt.Errorf("복습 단어 수는 0 이상이어야 합니다")
+	}
+	if len(s.Parts) == 0 {
+		return fmt.Errorf("문제 유형을 하나 이상 선택하세요")
+	}
+	for _, p := range s.Parts {
+		if _, ok := defaultQuestionTitles[p.QuestionType]; !ok {
+			return fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", p.QuestionType)
+		}
+	}
+	if err := validateModelID(s.Model); err != nil {
+		return err
+	}
+	if s.Format != "" {
+		if _, err := findExporter(s.Format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncOrder brings the rotation order in line with the list: removed words
+// drop out and new words join at the end, so earlier weeks stay put.
+func (s *Series) syncOrder(entries []VocabPair) {
+	inList := map[string]bool{}
+	for _, e := range entries {
+		inList[strings.ToLower(e.Word)] = true
+	}
+	kept := s.Order[:0]
+	inOrder := map[string]bool{}
+	for _, w := range s.Order {
+		if inList[strings.ToLower(w)] && !inOrder[strings.ToLower(w)] {
+			kept = append(kept, w)
+			inOrder[strings.ToLower(w)] = true
+		}
+	}
+	var added []string
+	for _, e := range entries {
+		if !inOrder[strings.ToLower(e.Word)] {
+			added = append(added, e.Word)
+			inOrder[strings.ToLower(e.Word)] = true
+		}
+	}
+	if s.Rotation == RotationShuffled {
+		rand.Shuffle(len(added), func(i, j int) { added[i], added[j] = added[j], added[i] })
+	}
+	s.Order = append(kept, added...)
+}
+
+// weekWords returns the new words and the review words of a week (1-based).
+func (s Series) weekWords(week int) ([]string, []string) {
+	if len(s.Plan) > 0 {
+		if week > len(s.Plan) {
+			return nil, nil
+		}
+		return s.Plan[week-1].New, s.Plan[week-1].Review
+	}
+	n := len(s.Order)
+	if n == 0 {
+		return nil, nil
+	}
+	per := s.WordsPerWeek
+	if per > n {
+		per = n
+	}
+	start := (week - 1) * per
+	var words []string
+	picked := map[string]bool{}
+	for i := 0; i < per; i++ {
+		w := s.Order[(start+i)%n]
+		words = append(words, w)
+		picked[w] = true
+	}
+
+	var earlier []string
+	for i := 0; i < start && i < n; i++ {
+		if w := s.Order[i]; !picked[w] {
+			earlier = append(earlier, w)
+		}
+	}
+	rand.Shuffle(len(earlier), func(i, j int) { earlier[i], earlier[j] = earlier[j], earlier[i] })
+	if len(earlier) > s.ReviewWords {
+		earlier = earlier[:s.ReviewWords]
+	}
+	return words, earlier
+}
+
+// joinSections combines the outputs of several question types into one test
+// with continuous question numbers and a single answer key.
+func joinSections(outputs []string) string {
+	var joined ParsedOutput
+	var appendices []string
+	for _, output := range outputs {
+		out := parseOutput(output)
+		for _, q := range out.Questions {
+			q.Number = len(joined.Questions) + 1
+			joined.Questions = append(joined.Questions, q)
+		}
+		joined.HasKey = joined.HasKey || out.HasKey
+		if out.Appendix != "" {
+			appendices = append(appendices, out.Appendix)
+		}
+	}
+	joined.Appendix = strings.Join(appendices, "\n\n")
+	return formatOutput(joined)
+}
+
+func findSeries(series []Series, id string) (int, bool) {
+	for i := range series {
+		if series[i].ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// --- Go functions callable from Javascript ---
+
+func (a *VocabApp) ListSeries() ([]Series, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	series, err := loadSeries()
+	if series == nil {
+		series = []Series{}
+	}
+	return series, err
+}
+
+// SaveSeries creates a series (empty ID) or updates its settings; the week
+// counter and word order of an existing series are kept.
+func (a *VocabApp) SaveSeries(s Series) (Series, error) {
+	s.Name = strings.TrimSpace(s.Name)
+	if err := validateSeries(s); err != nil {
+		return Series{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	series, err := loadSeries()
+	if err != nil {
+		return Series{}, err
+	}
+	if s.ID == "" {
+		s.ID = newID()
+		s.CreatedAt = time.Now()
+		s.Week = 0
+		series = append(series, s)
+		return s, saveJSON(seriesFile, series)
+	}
+	i, ok := findSeries(series, s.ID)
+	if !ok {
+		return Series{}, fmt.Errorf("시리즈를 찾을 수 없습니다")
+	}
+	s.CreatedAt, s.Week = series[i].CreatedAt, series[i].Week
+	if s.Order == nil || s.Rotation != series[i].Rotation {
+		s.Order = series[i].Order
+	}
+	series[i] = s
+	return s, saveJSON(seriesFile, series)
+}
+
+func (a *VocabApp) DeleteSeries(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	series, err := loadSeries()
+	if err != nil {
+		return err
+	}
+	kept := series[:0]
+	for _, s := range series {
+		if s.ID != id {
+			kept = append(kept, s)
+		}
+	}
+	return saveJSON(seriesFile, kept)
+}
+
+// GenerateNextInSeries generates the next week's quiz of a series, saves it
+// as a test named "<series> N주차" and, when an output folder is set, exports
+// it there. The week only counts once every step succeeded.
+func (a *VocabApp) GenerateNextInSeries(id string) (SeriesWeek, error) {
+	if a.client == nil {
+		return SeriesWeek{}, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	a.mu.Lock()
+	series, err := loadSeries()
+	var lists []SavedWordList
+	var settings Settings
+	if err == nil {
+		lists, err = loadWordLists()
+	}
+	if err == nil {
+		settings, err = loadSettings()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return SeriesWeek{}, err
+	}
+	i, ok := findSeries(series, id)
+	if !ok {
+		return SeriesWeek{}, fmt.Errorf("시리즈를 찾을 수 없습니다")
+	}
+	s := series[i]
+	var entries []VocabPair
+	for _, l := range lists {
+		if l.ID == s.WordListID && l.DeletedAt == nil {
+			entries = l.Entries
+		}
+	}
+	if len(entries) == 0 {
+		return SeriesWeek{}, fmt.Errorf("단어장을 찾을 수 없습니다")
+	}
+	s.syncOrder(entries)
+
+	week := SeriesWeek{Week: s.Week + 1}
+	week.Words, week.Review = s.weekWords(week.Week)
+	byWord := map[string]VocabPair{}
+	for _, e := range entries {
+		byWord[strings.ToLower(e.Word)] = e
+	}
+	var picked []VocabPair
+	for _, w := range append(append([]string{}, week.Words...), week.Review...) {
+		if e, ok := byWord[strings.ToLower(w)]; ok {
+			picked = append(picked, e)
+		}
+	}
+	if len(picked) == 0 {
+		if len(s.Plan) > 0 && week.Week > len(s.Plan) {
+			return week, fmt.Errorf("계획된 %d주차가 모두 끝났습니다", len(s.Plan))
+		}
+		return week, fmt.Errorf("이번 주차에 출제할 단어가 없습니다")
+	}
+	rand.Shuffle(len(picked), func(i, j int) { picked[i], picked[j] = picked[j], picked[i] })
+
+	outputs := make([]string, len(s.Parts))
+	errs := make([]error, len(s.Parts))
+	var wg sync.WaitGroup
+	for p, part := range s.Parts {
+		wg.Add(1)
+		go func(p int, part SeriesPart) {
+			defer wg.Done()
+			outputs[p], errs[p] = a.generate(settings, picked, s.Model, part.QuestionType, part.NumSentences)
+		}(p, part)
+	}
+	wg.Wait()
+	for p, err := range errs {
+		if err != nil {
+			return week, fmt.Errorf("%s: %w", s.Parts[p].QuestionType, err)
+		}
+	}
+	week.Output = outputs[0]
+	questionType := s.Parts[0].QuestionType
+	if len(outputs) > 1 {
+		week.Output = joinSections(outputs)
+		questionType = ""
+	}
+
+	title := fmt.Sprintf("%s %d주차", s.Name, week.Week)
+	test, err := a.SaveTest(title, questionType, s.Model, week.Output)
+	if err != nil {
+		return week, err
+	}
+	week.TestID = test.ID
+
+	dir := s.OutputDir
+	if dir == "" {
+		dir = settings.OutputDir
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return week, fmt.Errorf("저장 폴더 생성 오류: %w", err)
+		}
+		name, err := a.planExportName(week.Output, questionType, title)
+		if err != nil {
+			return week, err
+		}
+		opts, err := a.testExportOptions(name.Title, week.Output)
+		if err != nil {
+			return week, err
+		}
+		format := s.Format
+		if format == "" {
+			format = "docx"
+		}
+		data, ext, _, err := renderExport(format, week.Output, opts)
+		if err != nil {
+			return week, fmt.Errorf("문서 생성 오류: %w", err)
+		}
+		week.Path = autoSavePath(dir, &name, ext)
+		if err := os.WriteFile(week.Path, data, 0644); err != nil {
+			return week, fmt.Errorf("파일 저장 오류: %w", err)
+		}
+		a.exportSaved(name, week.Path, true)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	series, err = loadSeries()
+	if err != nil {
+		return week, err
+	}
+	if i, ok := findSeries(series, id); ok {
+		series[i].Order = s.Order
+		series[i].Week = week.Week
+		if err := saveJSON(seriesFile, series); err != nil {
+			return week, err
+		}
+	}
+	runtime.EventsEmit(a.ctx, "series:generated", week)
+	return week, nil
+}
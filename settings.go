@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Settings ---
+
+const settingsFile = "settings.json"
+
+// CustomModel is a user-entered model ID, typically a fine-tuned model
+// (ft:gpt-4o-mini:org::id). SystemPrompts optionally replaces the built-in
+// system prompt per question type; the values are prompt templates, so they
+// can be as short as the fine-tune allows.
+type CustomModel struct {
+	ID            string            `json:"id"`
+	Label         string            `json:"label"`
+	SystemPrompts map[string]string `json:"systemPrompts"`
+}
+
+type Settings struct {
+	CustomModels  []CustomModel `json:"customModels"`
+	Profiles      []Profile     `json:"profiles"`
+	ActiveProfile string        `json:"activeProfile"`
+
+	Google GoogleSettings `json:"google"`
+	Sync   SyncSettings   `json:"sync"`
+	SMTP   SMTPSettings   `json:"smtp"`
+
+	SharedBank SharedBankSettings `json:"sharedBank"`
+	Webhook    WebhookSettings    `json:"webhook"`
+	API        APISettings        `json:"api"`
+	IPC        IPCSettings        `json:"ipc"`
+
+	Generation GenerationSettings `json:"generation"`
+	Normalize  NormalizeSettings  `json:"normalize"`
+	// StrictParse makes Generate fail on malformed lines instead of
+	// skipping them (see CheckVocabBlock).
+	StrictParse bool `json:"strictParse"`
+
+	// MonthlyBudgetUSD is the API spending limit shown in the status bar
+	// (0 = no budget).
+	MonthlyBudgetUSD float64 `json:"monthlyBudgetUSD"`
+
+	// TrashRetentionDays is how long deleted items stay restorable (default 30).
+	TrashRetentionDays int `json:"trashRetentionDays"`
+
+	Classes     []ClassProfile `json:"classes"`
+	ActiveClass string         `json:"activeClass"`
+	// ExportHeader is a template printed above exported tests, e.g.
+	// "{{.School}} {{.ClassName}} 영어 어휘 평가 ({{.Unit}})".
+	ExportHeader string `json:"exportHeader"`
+	// FilenameTemplate names exported files, e.g. "{class}_{date}_{type}_v{n}",
+	// where {n} counts up with every export of the same name.
+	FilenameTemplate string `json:"filenameTemplate"`
+	// ExportFont is the font of exported documents, picked from ListFonts.
+	ExportFont ExportFont `json:"exportFont"`
+	// ExportLayout is the layout preset of exported documents (see
+	// GetExportLayouts).
+	ExportLayout string `json:"exportLayout"`
+	// AnswerKeyFormat is the default answer key format of exports.
+	AnswerKeyFormat string        `json:"answerKeyFormat"`
+	Points          PointSettings `json:"points"`
+	Grading         GradingRules  `json:"grading"`
+	// WordNotes prints the teacher notes of words in exports.
+	WordNotes WordNoteSettings `json:"wordNotes"`
+	// WordBank prints a boxed word bank on tests without choices.
+	WordBank WordBankSettings `json:"wordBank"`
+	// Dictionary checks the definitions of 영영풀이 questions against a
+	// real dictionary.
+	Dictionary DictionarySettings `json:"dictionary"`
+	// Grammar checks the English sentences of generated tests with a
+	// LanguageTool server.
+	Grammar GrammarSettings `json:"grammar"`
+	// OutputDir is where exports are saved. With AutoSave the save dialog is
+	// skipped and files go straight into OutputDir.
+	OutputDir string `json:"outputDir"`
+	AutoSave  bool   `json:"autoSave"`
+	// OpenAfterExport opens saved files in their default application.
+	OpenAfterExport bool `json:"openAfterExport"`
+
+	// QuestionTitles overrides the question stem per question type. Titles
+	// are templates, so they may use the class variables.
+	QuestionTitles map[string]string `json:"questionTitles"`
+}
+
+func loadSettings() (Settings, error) {
+	var s Settings
+	if err := loadJSON(settingsFile, &s); err != nil {
+		return Settings{}, err
+	}
+	return s, nil
+}
+
+func (s Settings) customModel(id string) (CustomModel, bool) {
+	for _, m := range s.CustomModels {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return CustomModel{}, false
+}
+
+// validateModelID rejects IDs that can never be valid so typos in a
+// fine-tuned ID are caught when saving rather than on the first Generate.
+func validateModelID(id string) error {
+	if id == "" || strings.ContainsAny(id, " \t\n") {
+		return fmt.Errorf("잘못된 모델 ID입니다: '%s'", id)
+	}
+	if strings.HasPrefix(id, "ft:") {
+		// ft:<base-model>:<org>:<suffix>:<id>, where org and suffix may be empty
+		parts := strings.Split(id, ":")
+		if len(parts) < 3 || parts[1] == "" {
+			return fmt.Errorf("fine-tuned 모델 ID 형식이 올바르지 않습니다 (예: ft:gpt-4o-mini:org::abc123): '%s'", id)
+		}
+	}
+	return nil
+}
+
+// questionTitle returns the configured stem for the question type, or the
+// built-in one.
+func (s Settings) questionTitle(questionType string) (string, error) {
+	title := strings.TrimSpace(s.QuestionTitles[questionType])
+	if title == "" {
+		return defaultQuestionTitles[questionType], nil
+	}
+	rendered, err := renderTemplateText(title, classVars(s.activeClass()))
+	if err != nil {
+		return "", fmt.Errorf("%s 문제 제목: %w", questionType, err)
+	}
+	return strings.TrimSpace(rendered), nil
+}
+
+// promptOptions fills in the prompt options configured in the settings.
+func (s Settings) promptOptions(questionType string, numSentences int) (promptOptions, error) {
+	title, err := s.questionTitle(questionType)
+	if err != nil {
+		return promptOptions{}, err
+	}
+	opts := promptOptions{
+		QuestionType: questionType,
+		NumSentences: numSentences,
+		Title:        title,
+	}
+	if theme, ok := findContextTheme(s.Generation.ContextTheme); ok {
+		opts.ContextTheme = theme.description
+	}
+	opts.Level, _ = findGradeLevel(s.Generation.GradeLevel)
+	opts.Spelling = s.Generation.Spelling
+	opts.Names = s.Generation.Names
+	opts.Places = s.Generation.Places
+	opts.SenseCoverage = s.Generation.SenseCoverage
+	opts.AllowInflections = s.Generation.AllowInflections
+	opts.MatchChoicePOS = s.Generation.MatchChoicePOS && wordChoiceTypes[questionType]
+	opts.StyleReference = strings.TrimSpace(s.Generation.StyleReferences[questionType])
+	opts.ForbiddenWords = s.Generation.ForbiddenWords
+	opts.AvoidTargets = s.Generation.AvoidOtherTargets
+	return opts, nil
+}
+
+// hasPromptOverride reports whether a per-model system prompt replaces the
+// built-in one for the question type.
+func (s Settings) hasPromptOverride(modelID string, questionType string) bool {
+	m, ok := s.customModel(modelID)
+	return ok && strings.TrimSpace(m.SystemPrompts[questionType]) != ""
+}
+
+// systemPromptForModel returns the per-model override for the question type if
+// one is configured, otherwise the built-in system prompt.
+func (s Settings) systemPromptForModel(modelID string, questionType string, numSentences int) (string, error) {
+	opts, err := s.promptOptions(questionType, numSentences)
+	if err != nil {
+		return "", err
+	}
+	if m, ok := s.customModel(modelID); ok {
+		if override := strings.TrimSpace(m.SystemPrompts[questionType]); override != "" {
+			return renderPromptTemplate(override, opts, s.activeClass())
+		}
+	}
+	return buildSystemPrompt(opts), nil
+}
+
+// --- Go functions callable from Javascript ---
+
+func (a *VocabApp) GetSettings() (Settings, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return loadSettings()
+}
+
+func (a *VocabApp) SaveSettings(s Settings) error {
+	for _, p := range s.Profiles {
+		if err := validateProfile(p); err != nil {
+			return err
+		}
+	}
+	if s.MonthlyBudgetUSD < 0 {
+		return fmt.Errorf("월 예산은 0 이상이어야 합니다")
+	}
+	if err := validateGenerationSettings(s.Generation); err != nil {
+		return err
+	}
+	for _, c := range s.Classes {
+		if err := validateClassProfile(c); err != nil {
+			return err
+		}
+	}
+	if _, err := parseTemplateText(s.ExportHeader); err != nil {
+		return fmt.Errorf("머리말 템플릿: %w", err)
+	}
+	if err := validateFilenameTemplate(s.FilenameTemplate); err != nil {
+		return err
+	}
+	if err := validateExportFont(s.ExportFont); err != nil {
+		return err
+	}
+	if err := validateExportLayout(s.ExportLayout); err != nil {
+		return err
+	}
+	if err := validateAnswerKeyFormat(s.AnswerKeyFormat); err != nil {
+		return err
+	}
+	if err := validatePointSettings(s.Points); err != nil {
+		return err
+	}
+	if err := validateWordBankSettings(s.WordBank); err != nil {
+		return err
+	}
+	if err := validateDictionarySettings(s.Dictionary); err != nil {
+		return err
+	}
+	if err := validateGrammarSettings(s.Grammar); err != nil {
+		return err
+	}
+	if err := validateGradingRules(s.Grading); err != nil {
+		return err
+	}
+	if err := validateSharedBankSettings(s.SharedBank); err != nil {
+		return err
+	}
+	if err := validateWebhookSettings(s.Webhook); err != nil {
+		return err
+	}
+	if err := validateAPISettings(s.API); err != nil {
+		return err
+	}
+	if err := validateIPCSettings(s.IPC, s.API); err != nil {
+		return err
+	}
+	if s.AutoSave && strings.TrimSpace(s.OutputDir) == "" {
+		return fmt.Errorf("자동 저장을 사용하려면 저장 폴더를 지정하세요")
+	}
+	for qType, title := range s.QuestionTitles {
+		if _, err := parseTemplateText(title); err != nil {
+			return fmt.Errorf("%s 문제 제목: %w", qType, err)
+		}
+	}
+	for _, m := range s.CustomModels {
+		if err := validateModelID(m.ID); err != nil {
+			return err
+		}
+		for qType, prompt := range m.SystemPrompts {
+			if _, err := parseTemplateText(prompt); err != nil {
+				return fmt.Errorf("%s (%s): %w", m.ID, qType, err)
+			}
+		}
+	}
+
+	a.mu.Lock()
+	err := saveJSON(settingsFile, s)
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// Saving without any key configured yet is fine; Generate reports it later.
+	if err := a.reloadClient(); err != nil && err != errNoAPIKey {
+		return err
+	}
+	return nil
+}
+
+// GetCustomModels lists the user-entered model IDs for the model combo box.
+func (a *VocabApp) GetCustomModels() ([]CustomModel, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	return settings.CustomModels, nil
+}
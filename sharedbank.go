@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Shared Question Bank (LAN) ---
+
+const defaultSharedBankPort = 8765
+
+// SharedBankSettings configures sharing the question bank over the local
+// network. A hosting instance serves its own bank to the listed tokens; the
+// others connect with URL and Token.
+type SharedBankSettings struct {
+	Host   bool         `json:"host"`
+	Port   int          `json:"port"` // default 8765
+	Tokens []ShareToken `json:"tokens"`
+
+	URL   string `json:"url"` // e.g. http://192.168.0.10:8765
+	Token string `json:"token"`
+}
+
+// ShareToken lets one teacher use the hosted bank; without Write the bank is
+// read-only for them.
+type ShareToken struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+	Write bool   `json:"write"`
+}
+
+func (s SharedBankSettings) port() int {
+	if s.Port == 0 {
+		return defaultSharedBankPort
+	}
+	return s.Port
+}
+
+func validateSharedBankSettings(s SharedBankSettings) error {
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("공유 문제은행 포트는 1에서 65535 사이여야 합니다")
+	}
+	seen := map[string]bool{}
+	for _, t := range s.Tokens {
+		if strings.TrimSpace(t.Name) == "" || len(t.Token) < 16 {
+			return fmt.Errorf("공유 토큰에는 이름과 16자 이상의 토큰이 필요합니다")
+		}
+		if seen[t.Token] {
+			return fmt.Errorf("중복된 공유 토큰이 있습니다: '%s'", t.Name)
+		}
+		seen[t.Token] = true
+	}
+	return nil
+}
+
+// localServer is an HTTP server the app runs in the background (the shared
+// bank host, the REST API).
+type localServer struct {
+	mu     sync.Mutex
+	server *http.Server
+}
+
+// start listens on addr and serves handler until stop. Starting a running
+// server does nothing.
+func (s *localServer) start(a *VocabApp, addr string, handler http.Handler, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return nil
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%s 서버 시작 오류: %w", name, err)
+	}
+	s.server = &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			runtime.LogErrorf(a.ctx, "%s 서버 오류: %v", name, err)
+		}
+	}(s.server)
+	return nil
+}
+
+func (s *localServer) stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.server.Shutdown(ctx)
+	s.server = nil
+	return err
+}
+
+// sameQuestion reports whether two bank questions ask the same thing.
+func sameQuestion(a, b BankQuestion) bool {
+	return a.QuestionType == b.QuestionType &&
+		strings.Join(a.Question.Body, "\n") == strings.Join(b.Question.Body, "\n") &&
+		strings.Join(a.Question.Choices, "\n") == strings.Join(b.Question.Choices, "\n")
+}
+
+// mergeBankQuestions appends the incoming questions that the bank does not
+// already hold as new entries and returns the bank and the number added.
+func mergeBankQuestions(bank []BankQuestion, incoming []BankQuestion, contributor string) ([]BankQuestion, int) {
+	added := 0
+	now := time.Now()
+next:
+	for _, q := range incoming {
+		for _, b := range bank {
+			if b.DeletedAt == nil && sameQuestion(b, q) {
+				continue next
+			}
+		}
+		q.ID = newID()
+		q.TestID = ""
+		q.CreatedAt = now
+		q.DeletedAt = nil
+		q.Stats = nil
+		q.Status, q.StatusReason, q.StatusManual = "", "", false
+		if contributor != "" {
+			q.Contributor = contributor
+		}
+		bank = append(bank, q)
+		added++
+	}
+	return bank, added
+}
+
+// shareToken finds the token of a request ("Authorization: Bearer <token>").
+func shareToken(tokens []ShareToken, r *http.Request) (ShareToken, bool) {
+	given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(given), []byte(t.Token)) == 1 {
+			return t, true
+		}
+	}
+	return ShareToken{}, false
+}
+
+// serveBank answers GET (list live, non-retired questions, ?type= filters)
+// and POST (contribute questions) on /api/bank.
+func (a *VocabApp) serveBank(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token, ok := shareToken(settings.SharedBank.Tokens, r)
+	if !ok {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		questions, err := a.ListBankQuestions(r.URL.Query().Get("type"), false)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(questions)
+	case http.MethodPost:
+		if !token.Write {
+			http.Error(w, "read-only token", http.StatusForbidden)
+			return
+		}
+		var incoming []BankQuestion
+		if err := json.NewDecoder(io.LimitReader(r.Body, 20<<20)).Decode(&incoming); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		bank, err := loadBank()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		bank, added := mergeBankQuestions(bank, incoming, token.Name)
+		if err := saveJSON(bankFile, bank); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"added": added})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// lanAddresses lists the URLs colleagues can use to reach the bank server.
+func lanAddresses(port int) []string {
+	var urls []string
+	addrs, _ := net.InterfaceAddrs()
+	for _, addr := range addrs {
+		if ip, ok := addr.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+			urls = append(urls, fmt.Sprintf("http://%s:%d", ip.IP, port))
+		}
+	}
+	return urls
+}
+
+// sharedBankRequest calls the hosted bank configured in the settings.
+func sharedBankRequest(s SharedBankSettings, method string, query string, body interface{}, v interface{}) error {
+	if s.URL == "" || s.Token == "" {
+		return fmt.Errorf("공유 문제은행 주소와 토큰을 설정하세요")
+	}
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, strings.TrimRight(s.URL, "/")+"/api/bank"+query, payload)
+	if err != nil {
+		return fmt.Errorf("공유 문제은행 주소 오류: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return fmt.Errorf("공유 문제은행 연결 오류: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("공유 문제은행 오류 (%d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (a *VocabApp) sharedBankSettings() (SharedBankSettings, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	return settings.SharedBank, err
+}
+
+// --- Go functions callable from Javascript ---
+
+// StartBankServer starts serving the local question bank on the configured
+// port and returns the addresses colleagues can connect to.
+func (a *VocabApp) StartBankServer() ([]string, error) {
+	s, err := a.sharedBankSettings()
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Tokens) == 0 {
+		return nil, fmt.Errorf("공유 토큰을 먼저 만드세요")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bank", a.serveBank)
+	if err := a.shared.start(a, fmt.Sprintf(":%d", s.port()), mux, "공유 문제은행"); err != nil {
+		return nil, err
+	}
+	return lanAddresses(s.port()), nil
+}
+
+// StopBankServer stops serving the question bank.
+func (a *VocabApp) StopBankServer() error {
+	return a.shared.stop()
+}
+
+// NewShareToken adds a token for a colleague to the hosting settings.
+func (a *VocabApp) NewShareToken(name string, write bool) (ShareToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ShareToken{}, fmt.Errorf("토큰 이름을 입력하세요")
+	}
+	token := ShareToken{Name: name, Token: randomURLSafe(24), Write: write}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return ShareToken{}, err
+	}
+	settings.SharedBank.Tokens = append(settings.SharedBank.Tokens, token)
+	return token, saveJSON(settingsFile, settings)
+}
+
+// ListSharedBank returns the questions of the colleague's hosted bank,
+// optionally filtered by type.
+func (a *VocabApp) ListSharedBank(questionType string) ([]BankQuestion, error) {
+	s, err := a.sharedBankSettings()
+	if err != nil {
+		return nil, err
+	}
+	var questions []BankQuestion
+	query := ""
+	if questionType != "" {
+		query = "?type=" + url.QueryEscape(questionType)
+	}
+	return questions, sharedBankRequest(s, http.MethodGet, query, nil, &questions)
+}
+
+// ContributeToSharedBank sends local bank questions to the hosted bank and
+// returns how many were new there.
+func (a *VocabApp) ContributeToSharedBank(ids []string) (int, error) {
+	s, err := a.sharedBankSettings()
+	if err != nil {
+		return 0, err
+	}
+	want := map[string]bool{}
+	for _, id := range ids {
+		want[id] = true
+	}
+	a.mu.Lock()
+	bank, err := loadBank()
+	a.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	var questions []BankQuestion
+	for _, q := range bank {
+		if want[q.ID] && q.DeletedAt == nil {
+			questions = append(questions, q)
+		}
+	}
+	if len(questions) == 0 {
+		return 0, fmt.Errorf("보낼 문항을 선택하세요")
+	}
+	var result struct {
+		Added int `json:"added"`
+	}
+	return result.Added, sharedBankRequest(s, http.MethodPost, "", questions, &result)
+}
+
+// CopyFromSharedBank copies questions of the hosted bank into the local bank
+// and returns how many were new.
+func (a *VocabApp) CopyFromSharedBank(ids []string) (int, error) {
+	shared, err := a.ListSharedBank("")
+	if err != nil {
+		return 0, err
+	}
+	want := map[string]bool{}
+	for _, id := range ids {
+		want[id] = true
+	}
+	var picked []BankQuestion
+	for _, q := range shared {
+		if want[q.ID] {
+			picked = append(picked, q)
+		}
+	}
+	if len(picked) == 0 {
+		return 0, fmt.Errorf("가져올 문항을 선택하세요")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	bank, err := loadBank()
+	if err != nil {
+		return 0, err
+	}
+	bank, added := mergeBankQuestions(bank, picked, "")
+	return added, saveJSON(bankFile, bank)
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Graceful Shutdown ---
+
+const checkpointsFile = "checkpoints.json"
+
+// Close modes for ConfirmClose.
+const (
+	// CloseNow quits at once; generations in flight are lost.
+	CloseNow = "now"
+	// CloseAfterChunk lets every running generation finish its current
+	// chunk, saves the rest as a checkpoint and then quits.
+	CloseAfterChunk = "checkpoint"
+)
+
+var errClosing = errors.New("앱 종료로 생성을 중단했습니다. 다음 실행 때 이어서 생성할 수 있습니다")
+
+// closeGuard tracks what would be lost by closing the window.
+type closeGuard struct {
+	mu      sync.Mutex
+	unsaved bool // output in the editor not saved yet, reported by the UI
+	closing bool // CloseAfterChunk chosen; generations checkpoint and stop
+	force   bool // close without asking again
+}
+
+func (g *closeGuard) isClosing() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closing
+}
+
+// CloseState is sent with "app:close-requested" so the UI can ask the user
+// how to close.
+type CloseState struct {
+	Running int  `json:"running"`
+	Unsaved bool `json:"unsaved"`
+}
+
+// Checkpoint is a generation stopped by closing the app: the output of the
+// chunks that finished and the entries still to be generated.
+type Checkpoint struct {
+	ID           string      `json:"id"`
+	Model        string      `json:"model"`
+	QuestionType string      `json:"questionType"`
+	NumSentences int         `json:"numSentences"`
+	Outputs      []string    `json:"outputs"`
+	Remaining    []VocabPair `json:"remaining,omitempty"`
+	CreatedAt    time.Time   `json:"createdAt"`
+}
+
+func loadCheckpoints() ([]Checkpoint, error) {
+	var checkpoints []Checkpoint
+	return checkpoints, loadJSON(checkpointsFile, &checkpoints)
+}
+
+// saveCheckpoint stores a stopped generation. A generation that completed
+// while the app was closing is saved with nothing remaining, so its output
+// is not lost either.
+func (a *VocabApp) saveCheckpoint(c Checkpoint) {
+	c.ID = newID()
+	c.CreatedAt = time.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	checkpoints, err := loadCheckpoints()
+	if err == nil {
+		err = saveJSON(checkpointsFile, append(checkpoints, c))
+	}
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "체크포인트 저장 오류: %v", err)
+	}
+}
+
+// takeCheckpoint removes a checkpoint from the store and returns it.
+func (a *VocabApp) takeCheckpoint(id string) (Checkpoint, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	checkpoints, err := loadCheckpoints()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	for i, c := range checkpoints {
+		if c.ID == id {
+			checkpoints = append(checkpoints[:i], checkpoints[i+1:]...)
+			return c, saveJSON(checkpointsFile, checkpoints)
+		}
+	}
+	return Checkpoint{}, fmt.Errorf("체크포인트를 찾을 수 없습니다: '%s'", id)
+}
+
+// beforeClose keeps the window open while generations are running or the
+// output is unsaved, and lets the UI ask the user what to do.
+func (a *VocabApp) beforeClose(ctx context.Context) (prevent bool) {
+	state := CloseState{Running: int(atomic.LoadInt32(&a.running))}
+	a.guard.mu.Lock()
+	state.Unsaved = a.guard.unsaved
+	force, closing := a.guard.force, a.guard.closing
+	a.guard.mu.Unlock()
+	if force || (state.Running == 0 && !state.Unsaved) {
+		return false
+	}
+	if !closing {
+		runtime.EventsEmit(a.ctx, "app:close-requested", state)
+	}
+	return true
+}
+
+// quitWhenIdle quits once the last running generation has stopped.
+func (a *VocabApp) quitWhenIdle() {
+	for atomic.LoadInt32(&a.running) > 0 {
+		time.Sleep(200 * time.Millisecond)
+	}
+	a.guard.mu.Lock()
+	a.guard.force = true
+	a.guard.mu.Unlock()
+	runtime.Quit(a.ctx)
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetUnsavedOutput tells the backend whether the editor holds output that
+// has not been saved, so closing the window asks first.
+func (a *VocabApp) SetUnsavedOutput(unsaved bool) {
+	a.guard.mu.Lock()
+	a.guard.unsaved = unsaved
+	a.guard.mu.Unlock()
+}
+
+// ConfirmClose closes the app after "app:close-requested" in the chosen
+// mode (CloseNow or CloseAfterChunk).
+func (a *VocabApp) ConfirmClose(mode string) error {
+	a.guard.mu.Lock()
+	switch mode {
+	case CloseNow:
+		a.guard.force = true
+	case CloseAfterChunk:
+		a.guard.closing = true
+	default:
+		a.guard.mu.Unlock()
+		return fmt.Errorf("알 수 없는 종료 방식입니다: '%s'", mode)
+	}
+	a.guard.mu.Unlock()
+	if mode == CloseNow {
+		runtime.Quit(a.ctx)
+		return nil
+	}
+	go a.quitWhenIdle()
+	return nil
+}
+
+// ListCheckpoints returns the generations stopped by closing the app.
+func (a *VocabApp) ListCheckpoints() ([]Checkpoint, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	checkpoints, err := loadCheckpoints()
+	if checkpoints == nil {
+		checkpoints = []Checkpoint{}
+	}
+	return checkpoints, err
+}
+
+// ResumeCheckpoint generates the remaining entries of a checkpoint with the
+// current settings and joins them to the saved output. The follow-up checks
+// (review, verification, ...) run on the newly generated part only.
+func (a *VocabApp) ResumeCheckpoint(id string) (string, error) {
+	if a.client == nil {
+		return "", fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	c, err := a.takeCheckpoint(id)
+	if err != nil {
+		return "", err
+	}
+	outputs := c.Outputs
+	if len(c.Remaining) > 0 {
+		settings, err := a.GetSettings()
+		if err != nil {
+			a.saveCheckpoint(c)
+			return "", err
+		}
+		output, err := a.generate(settings, c.Remaining, c.Model, c.QuestionType, c.NumSentences)
+		if err != nil {
+			a.saveCheckpoint(c)
+			return "", err
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) == 1 {
+		return outputs[0], nil
+	}
+	return joinSections(outputs), nil
+}
+
+// DiscardCheckpoint deletes a checkpoint without generating the rest.
+func (a *VocabApp) DiscardCheckpoint(id string) error {
+	_, err := a.takeCheckpoint(id)
+	return err
+}
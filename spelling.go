@@ -0,0 +1,97 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// --- British / American Spelling ---
+
+const (
+	SpellingBritish  = "british"
+	SpellingAmerican = "american"
+)
+
+// spellingPairs maps American spellings to British ones. It covers the common
+// textbook words; the prompt rule handles everything else.
+var spellingPairs = map[string]string{
+	"color": "colour", "colors": "colours", "colored": "coloured", "colorful": "colourful",
+	"favor": "favour", "favors": "favours", "favorite": "favourite", "favorites": "favourites",
+	"honor": "honour", "honors": "honours", "honored": "honoured",
+	"humor": "humour", "labor": "labour", "neighbor": "neighbour", "neighbors": "neighbours",
+	"neighborhood": "neighbourhood", "behavior": "behaviour", "behaviors": "behaviours",
+	"flavor": "flavour", "flavors": "flavours", "harbor": "harbour", "rumor": "rumour",
+	"vapor": "vapour", "vigor": "vigour", "endeavor": "endeavour", "savor": "savour",
+	"center": "centre", "centers": "centres", "theater": "theatre", "theaters": "theatres",
+	"liter": "litre", "liters": "litres", "fiber": "fibre",
+	"organize": "organise", "organized": "organised", "organization": "organisation",
+	"organizations": "organisations", "realize": "realise", "realized": "realised",
+	"recognize": "recognise", "recognized": "recognised", "apologize": "apologise",
+	"criticize": "criticise", "emphasize": "emphasise", "analyze": "analyse", "analyzed": "analysed",
+	"paralyze": "paralyse", "memorize": "memorise", "summarize": "summarise",
+	"defense": "defence", "offense": "offence", "pretense": "pretence",
+	"traveled": "travelled", "traveling": "travelling", "traveler": "traveller", "travelers": "travellers",
+	"canceled": "cancelled", "canceling": "cancelling", "labeled": "labelled", "modeling": "modelling",
+	"jewelry": "jewellery", "catalog": "catalogue", "dialog": "dialogue",
+	"gray": "grey", "mold": "mould", "plow": "plough",
+	"aluminum": "aluminium", "pajamas": "pyjamas", "cozy": "cosy", "skeptical": "sceptical",
+	"fulfill": "fulfil", "enroll": "enrol", "practicing": "practising", "aging": "ageing",
+}
+
+var spellingWordRe = regexp.MustCompile(`[A-Za-z]+`)
+
+// spellingRule is the prompt rule for the chosen variant.
+func spellingRule(variant string) string {
+	switch variant {
+	case SpellingBritish:
+		return "SPELLING: Use British English spelling and vocabulary throughout (e.g., colour, centre, organise, travelled)."
+	case SpellingAmerican:
+		return "SPELLING: Use American English spelling and vocabulary throughout (e.g., color, center, organize, traveled)."
+	}
+	return ""
+}
+
+// matchCase copies the capitalization of like onto word.
+func matchCase(word string, like string) string {
+	if strings.ToUpper(like) == like && len(like) > 1 {
+		return strings.ToUpper(word)
+	}
+	if unicode.IsUpper(rune(like[0])) {
+		return strings.ToUpper(word[:1]) + word[1:]
+	}
+	return word
+}
+
+// normalizeSpelling rewrites words of the other variant in the output and
+// returns the number of replacements. The vocabulary words themselves are
+// left alone, since changing an answer would break the test.
+func normalizeSpelling(text string, variant string, vocab []VocabPair) (string, int) {
+	table := spellingPairs
+	switch variant {
+	case SpellingBritish:
+	case SpellingAmerican:
+		table = make(map[string]string, len(spellingPairs))
+		for us, uk := range spellingPairs {
+			table[uk] = us
+		}
+	default:
+		return text, 0
+	}
+	protected := map[string]bool{}
+	for _, p := range vocab {
+		protected[strings.ToLower(p.Word)] = true
+	}
+
+	count := 0
+	text = spellingWordRe.ReplaceAllStringFunc(text, func(w string) string {
+		lower := strings.ToLower(w)
+		replacement, ok := table[lower]
+		if !ok || protected[lower] {
+			return w
+		}
+		count++
+		return matchCase(replacement, w)
+	})
+	return text, count
+}
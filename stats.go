@@ -0,0 +1,132 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Local Analytics ---
+
+// statsFile keeps run counts per month on this computer only; nothing is
+// ever sent anywhere.
+const statsFile = "stats.json"
+
+// MonthlyStats counts the generations of one calendar month.
+type MonthlyStats struct {
+	Runs          int            `json:"runs"`
+	Questions     int            `json:"questions"`
+	QuestionTypes map[string]int `json:"questionTypes"` // runs per question type
+	// FailedRuns counts runs whose output failed validation;
+	// FailedQuestions counts the questions with a failure or key error.
+	FailedRuns      int `json:"failedRuns"`
+	FailedQuestions int `json:"failedQuestions"`
+}
+
+// StatsMonth is one row of the stats view.
+type StatsMonth struct {
+	Month string `json:"month"` // "2006-01"
+	MonthlyStats
+	// RunFailureRate and QuestionFailureRate are 0..1.
+	RunFailureRate      float64 `json:"runFailureRate"`
+	QuestionFailureRate float64 `json:"questionFailureRate"`
+	CostUSD             float64 `json:"costUSD"`
+	// AvgCostPerTest is the month's API cost, follow-up calls included,
+	// divided by its runs.
+	AvgCostPerTest float64 `json:"avgCostPerTest"`
+}
+
+// StatsReport holds the months, newest first, and their total.
+type StatsReport struct {
+	Months []StatsMonth `json:"months"`
+	Total  StatsMonth   `json:"total"`
+}
+
+// loadStats returns the stats per month. Must be called with a.mu held.
+func loadStats() (map[string]MonthlyStats, error) {
+	stats := map[string]MonthlyStats{}
+	if err := loadJSON(statsFile, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// recordStats counts a finished run and its validation result.
+func (a *VocabApp) recordStats(questionType string, result ValidationResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stats, err := loadStats()
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "통계 읽기 오류: %v", err)
+		return
+	}
+	month := usageMonth(time.Now())
+	m := stats[month]
+	m.Runs++
+	m.Questions += result.QuestionCount
+	if m.QuestionTypes == nil {
+		m.QuestionTypes = map[string]int{}
+	}
+	m.QuestionTypes[questionType]++
+	if !result.Passed() {
+		m.FailedRuns++
+	}
+	m.FailedQuestions += countDistinctQuestions(append(append([]ValidationIssue{}, result.Failures...), result.AnswerKeyErrors...))
+	stats[month] = m
+	if err := saveJSON(statsFile, stats); err != nil {
+		runtime.LogErrorf(a.ctx, "통계 저장 오류: %v", err)
+	}
+}
+
+// add adds the counts and cost of o to s.
+func (s *StatsMonth) add(o StatsMonth) {
+	s.Runs += o.Runs
+	s.Questions += o.Questions
+	s.FailedRuns += o.FailedRuns
+	s.FailedQuestions += o.FailedQuestions
+	s.CostUSD += o.CostUSD
+	if s.QuestionTypes == nil {
+		s.QuestionTypes = map[string]int{}
+	}
+	for t, n := range o.QuestionTypes {
+		s.QuestionTypes[t] += n
+	}
+}
+
+func (s *StatsMonth) computeRates() {
+	if s.Runs > 0 {
+		s.RunFailureRate = float64(s.FailedRuns) / float64(s.Runs)
+		s.AvgCostPerTest = s.CostUSD / float64(s.Runs)
+	}
+	if s.Questions > 0 {
+		s.QuestionFailureRate = float64(s.FailedQuestions) / float64(s.Questions)
+	}
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetStats returns the local usage statistics per month for the stats view.
+func (a *VocabApp) GetStats() (StatsReport, error) {
+	a.mu.Lock()
+	stats, err := loadStats()
+	var usage map[string]MonthlyUsage
+	if err == nil {
+		usage, err = loadUsage()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return StatsReport{}, err
+	}
+
+	report := StatsReport{Months: []StatsMonth{}, Total: StatsMonth{Month: "total"}}
+	for month, m := range stats {
+		row := StatsMonth{Month: month, MonthlyStats: m, CostUSD: usage[month].CostUSD}
+		row.computeRates()
+		report.Months = append(report.Months, row)
+		report.Total.add(row)
+	}
+	report.Total.computeRates()
+	sort.Slice(report.Months, func(i, j int) bool { return report.Months[i].Month > report.Months[j].Month })
+	return report, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// --- App Status ---
+
+// AppStatus is a snapshot for the status bar.
+type AppStatus struct {
+	Profile string `json:"profile"`
+	// APIConnected is false when the API could not be reached at all;
+	// KeyValid is false when it answered but rejected the credentials.
+	APIConnected bool   `json:"apiConnected"`
+	KeyValid     bool   `json:"keyValid"`
+	APIError     string `json:"apiError"`
+
+	MonthSpentUSD  float64 `json:"monthSpentUSD"`
+	MonthBudgetUSD float64 `json:"monthBudgetUSD"`
+	// RemainingUSD is nil when no budget is set.
+	RemainingUSD *float64 `json:"remainingUSD"`
+
+	// QueueDepth is the number of generations currently running.
+	QueueDepth int `json:"queueDepth"`
+
+	DataSizes      map[string]int64 `json:"dataSizes"` // bytes per data file
+	DataTotalBytes int64            `json:"dataTotalBytes"`
+}
+
+// checkAPI makes the cheapest authenticated call (listing models) to tell a
+// network problem apart from a rejected key.
+func (a *VocabApp) checkAPI(status *AppStatus) {
+	if a.client == nil {
+		status.APIError = errNoAPIKey.Error()
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := a.client.ListModels(ctx)
+	if err == nil {
+		status.APIConnected = true
+		status.KeyValid = true
+		return
+	}
+	status.APIError = err.Error()
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		status.APIConnected = true
+		status.KeyValid = apiErr.HTTPStatusCode != http.StatusUnauthorized && apiErr.HTTPStatusCode != http.StatusForbidden
+	}
+}
+
+// dataSizes lists the size of every file in the data directory, keyed by
+// its slash-separated relative path.
+func dataSizes() (map[string]int64, int64, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, 0, err
+	}
+	sizes := map[string]int64{}
+	var total int64
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		sizes[filepath.ToSlash(rel)] = info.Size()
+		total += info.Size()
+		return nil
+	})
+	return sizes, total, err
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetAppStatus gathers API, budget, queue and storage status in one call.
+func (a *VocabApp) GetAppStatus() (AppStatus, error) {
+	status := AppStatus{QueueDepth: int(atomic.LoadInt32(&a.running))}
+
+	a.mu.Lock()
+	settings, err := loadSettings()
+	var usage map[string]MonthlyUsage
+	if err == nil {
+		usage, err = loadUsage()
+	}
+	if err == nil {
+		status.DataSizes, status.DataTotalBytes, err = dataSizes()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return status, err
+	}
+
+	status.Profile = settings.ActiveProfile
+	status.MonthSpentUSD = usage[usageMonth(time.Now())].CostUSD
+	status.MonthBudgetUSD = settings.MonthlyBudgetUSD
+	if settings.MonthlyBudgetUSD > 0 {
+		remaining := settings.MonthlyBudgetUSD - status.MonthSpentUSD
+		status.RemainingUSD = &remaining
+	}
+
+	a.checkAPI(&status)
+	return status, nil
+}
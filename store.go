@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// --- Local Data Store ---
+
+const appDataDirName = "vocab-generator-wails"
+
+// dataDir returns the directory where the app keeps its JSON data files,
+// creating it if necessary.
+func dataDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		// Fallback to the working directory, same as loadAPIKey does for `wails dev`
+		base, err = os.Getwd()
+		if err != nil {
+			return "", err
+		}
+	}
+	dir := filepath.Join(base, appDataDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("데이터 폴더 생성 오류: %w", err)
+	}
+	return dir, nil
+}
+
+// loadJSON reads name from the data directory into v. A missing file is not
+// an error; v is simply left untouched.
+func loadJSON(name string, v interface{}) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s 읽기 오류: %w", name, err)
+	}
+	if content, err = decodeStore(content); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%s 형식 오류: %w", name, err)
+	}
+	return nil
+}
+
+// saveJSON writes v to name in the data directory, encrypted when
+// encryption at rest is on.
+func saveJSON(name string, v interface{}) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if content, err = encodeStore(content); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return writeDataFile(dir, name, content)
+}
+
+// writeDataFile replaces name in dir with content. The file is written to a
+// temporary path first so a crash never leaves a half-written store behind.
+func writeDataFile(dir string, name string, content []byte) error {
+	path := filepath.Join(dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("%s 저장 오류: %w", name, err)
+	}
+	return os.Rename(tmp, path)
+}
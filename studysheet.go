@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Study Sheets ---
+
+const studySheetPrompt = `You are an expert English teacher preparing vocabulary review handouts for Korean students.
+For every word in the list, give its part(s) of speech, its IPA pronunciation, its senses in Korean (use the given senses, in order, and add nothing else), exactly two natural example sentences that use the word in the given senses, and two to four common collocations.
+Respond with JSON only: {"entries": [{"word": "abandon", "pos": "v.", "ipa": "/əˈbændən/", "senses": ["버리다", "포기하다"], "examples": ["...", "..."], "collocations": ["abandon hope", "abandon a plan"]}]}, one entry per word in the order given.`
+
+// StudyEntry is one word of a study sheet.
+type StudyEntry struct {
+	Word         string   `json:"word"`
+	POS          string   `json:"pos"`
+	IPA          string   `json:"ipa"`
+	Senses       []string `json:"senses"`
+	Examples     []string `json:"examples"`
+	Collocations []string `json:"collocations"`
+	// Note is the teacher note of the word, from the working list.
+	Note string `json:"note,omitempty"`
+}
+
+// renderStudySheetDocx lays out a study sheet as a Word document, one block
+// per word.
+func renderStudySheetDocx(entries []StudyEntry, opts ExportOptions) ([]byte, error) {
+	doc := newExportDocx(opts)
+	for _, line := range opts.Header {
+		doc.Text(line)
+	}
+	if opts.Title != "" {
+		doc.Title(opts.Title)
+	}
+	for i, e := range entries {
+		head := []docxRun{{Text: fmt.Sprintf("%d. %s", i+1, e.Word), Bold: true}}
+		if e.POS != "" || e.IPA != "" {
+			head = append(head, docxRun{Text: "  " + strings.TrimSpace(e.POS+" "+e.IPA)})
+		}
+		doc.Paragraph(head...)
+		if len(e.Senses) > 0 {
+			doc.Text("뜻: " + strings.Join(e.Senses, "; "))
+		}
+		for _, ex := range e.Examples {
+			doc.Text("• " + ex)
+		}
+		if len(e.Collocations) > 0 {
+			doc.Text("연어: " + strings.Join(e.Collocations, ", "))
+		}
+		if e.Note != "" {
+			doc.Text("메모: " + e.Note)
+		}
+		doc.Blank()
+	}
+	return doc.Bytes()
+}
+
+// --- Go functions callable from Javascript ---
+
+// GenerateStudySheet asks the model for review material (POS, IPA, examples,
+// collocations) for every word in the list, in list order.
+func (a *VocabApp) GenerateStudySheet(vocabBlock string, modelID string) ([]StudyEntry, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("API 클라이언트가 초기화되지 않았습니다. API 키를 확인하세요.")
+	}
+	parsed := parseVocabBlock(vocabBlock)
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+
+	var result struct {
+		Entries []StudyEntry `json:"entries"`
+	}
+	if err := a.callChatGPTJSON(modelID, studySheetPrompt, formatVocabBlock(parsed), &result); err != nil {
+		return nil, err
+	}
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("API가 빈 학습지를 반환했습니다")
+	}
+	settings, err := a.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.WordNotes.StudySheet {
+		for i := range result.Entries {
+			result.Entries[i].Note = a.workingListNote(result.Entries[i].Word)
+		}
+	}
+	return result.Entries, nil
+}
+
+// ExportStudySheet saves a study sheet from GenerateStudySheet as a Word
+// document, with the same header as test exports.
+func (a *VocabApp) ExportStudySheet(entries []StudyEntry, suggestedFilename string) (string, error) {
+	if len(entries) == 0 {
+		return "", fmt.Errorf("저장할 학습지가 없습니다")
+	}
+	name, err := a.planExportName("", "학습지", suggestedFilename)
+	if err != nil {
+		return "", err
+	}
+	filePath, auto, err := a.savePath(&name, ".docx", runtime.SaveDialogOptions{
+		Title: "학습지 저장",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Word 문서 (*.docx)",
+				Pattern:     "*.docx",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := a.exportOptions(exportTitle(filePath))
+	if err != nil {
+		return "", err
+	}
+	content, err := renderStudySheetDocx(entries, opts)
+	if err != nil {
+		return "", fmt.Errorf("문서 생성 오류: %w", err)
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	a.exportSaved(name, filePath, auto)
+	return fmt.Sprintf("저장 완료: %s", filepath.Base(filePath)), nil
+}
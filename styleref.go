@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Style References ---
+
+// maxStyleReferenceRunes caps a pasted style reference, which is sent with
+// every generation of its question type.
+const maxStyleReferenceRunes = 4000
+
+// styleReferenceSection asks the model to imitate questions pasted from the
+// school's past exams. The examples only set the style; the output structure
+// rules above still decide the layout the parser relies on.
+func (o promptOptions) styleReferenceSection() string {
+	if o.StyleReference == "" {
+		return ""
+	}
+	return strings.Join([]string{
+		"### Style Reference",
+		"The following questions are from the teacher's own school exams. Imitate their formatting details, the wording of their instructions, their tone and their difficulty as closely as the rules above allow.",
+		"Do NOT reuse their words, sentences or answers, and keep the numbering, choice markers, '---' separators and the `[정답]` section exactly as specified above.",
+		"",
+		"[Example Questions]",
+		o.StyleReference,
+		"[End of Example Questions]",
+	}, "\n")
+}
+
+func validateStyleReferences(refs map[string]string) error {
+	for qType, ref := range refs {
+		if _, ok := defaultQuestionTitles[qType]; !ok {
+			return fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", qType)
+		}
+		if len([]rune(ref)) > maxStyleReferenceRunes {
+			return fmt.Errorf("%s 스타일 예시는 %d자 이하여야 합니다", qType, maxStyleReferenceRunes)
+		}
+	}
+	return nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// SetStyleReference stores example questions from a past exam for one
+// question type; an empty text removes them.
+func (a *VocabApp) SetStyleReference(questionType string, text string) error {
+	text = strings.TrimSpace(text)
+	if err := validateStyleReferences(map[string]string{questionType: text}); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		delete(settings.Generation.StyleReferences, questionType)
+	} else {
+		if settings.Generation.StyleReferences == nil {
+			settings.Generation.StyleReferences = map[string]string{}
+		}
+		settings.Generation.StyleReferences[questionType] = text
+	}
+	return saveJSON(settingsFile, settings)
+}
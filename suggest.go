@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --- Next Word Suggestions ---
+
+const maxSuggestions = 200
+
+// Weights of the suggestion score. Frequency dominates; a word on several
+// lists, a word already in a saved word list and a band the class struggles
+// with each move a word up.
+const (
+	suggestMultiListWeight = 0.25
+	suggestPlannedWeight   = 0.25
+	suggestBandErrorWeight = 0.5
+)
+
+const suggestSensesPrompt = `You give the Korean meanings of English words for Korean middle and high school English classes.
+For each word, give its 1-3 most common meanings in Korean, most common first, as short dictionary glosses.
+Respond with JSON only: {"words": [{"word": "...", "senses": ["...", "..."]}]}.`
+
+// WordSuggestion is an untested word worth teaching next.
+type WordSuggestion struct {
+	Word string `json:"word"`
+	// Senses are the meanings from a saved word list or generation run that
+	// had the word, empty when the word was never entered.
+	Senses []string `json:"senses"`
+	Lists  []string `json:"lists"` // names of the standard lists with the word
+	Rank   int      `json:"rank"`  // best 1-based rank in those lists
+	// BandError is the class's error rate on the tested words of the
+	// word's frequency band, 0-100.
+	BandError float64 `json:"bandError"`
+	Score     float64 `json:"score"`
+}
+
+// knownSenses returns the senses of every word entered in a saved word list
+// or generation run. Must be called with a.mu held.
+func knownSenses() (map[string]VocabPair, error) {
+	lists, err := loadWordLists()
+	if err != nil {
+		return nil, err
+	}
+	runs, err := loadRuns()
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]VocabPair{}
+	add := func(entries []VocabPair) {
+		for _, e := range entries {
+			if key := strings.ToLower(e.Word); len(e.Senses) > 0 && len(known[key].Senses) == 0 {
+				known[key] = e
+			}
+		}
+	}
+	for _, l := range lists {
+		if l.DeletedAt == nil {
+			add(l.Entries)
+		}
+	}
+	for _, r := range runs {
+		add(r.Entries)
+	}
+	return known, nil
+}
+
+// bandErrors returns, per frequency band of list, the share of the class's
+// answers on the band's words that lost points.
+func bandErrors(list StandardList, attempts []wordAttempt) map[int]float64 {
+	rank := map[string]int{}
+	for i, w := range list.Words {
+		rank[w] = i
+	}
+	answers := map[int]float64{}
+	credit := map[int]float64{}
+	for _, at := range attempts {
+		if r, ok := rank[strings.ToLower(at.Entry.Word)]; ok {
+			answers[r/coverageBandSize]++
+			credit[r/coverageBandSize] += at.Credit
+		}
+	}
+	rates := map[int]float64{}
+	for band, n := range answers {
+		rates[band] = 1 - credit[band]/n
+	}
+	return rates
+}
+
+// suggestWords scores the untested words of the imported lists.
+func suggestWords(lists []StandardList, tested map[string]bool, planned map[string]VocabPair, attempts []wordAttempt) []WordSuggestion {
+	byWord := map[string]*WordSuggestion{}
+	var order []string
+	for _, l := range lists {
+		if len(l.Words) == 0 {
+			continue
+		}
+		rates := bandErrors(l, attempts)
+		for i, w := range l.Words {
+			if tested[w] {
+				continue
+			}
+			s := byWord[w]
+			if s == nil {
+				s = &WordSuggestion{Word: w, Senses: []string{}, Rank: i + 1}
+				byWord[w] = s
+				order = append(order, w)
+			}
+			s.Lists = append(s.Lists, l.Name)
+			frequency := 1 - float64(i)/float64(len(l.Words))
+			bandError := rates[i/coverageBandSize]
+			if score := frequency + suggestBandErrorWeight*bandError; score > s.Score {
+				s.Score = score
+				s.BandError = bandError * 100
+			}
+			if i+1 < s.Rank {
+				s.Rank = i + 1
+			}
+		}
+	}
+
+	suggestions := make([]WordSuggestion, 0, len(order))
+	for _, w := range order {
+		s := byWord[w]
+		s.Score += suggestMultiListWeight * float64(len(s.Lists)-1)
+		if e, ok := planned[w]; ok {
+			s.Senses = e.Senses
+			s.Score += suggestPlannedWeight
+		}
+		suggestions = append(suggestions, *s)
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].Score > suggestions[j].Score })
+	return suggestions
+}
+
+// fillSuggestedSenses asks the model for the meanings of the entries that
+// have none, dropping the words it gives none for.
+func (a *VocabApp) fillSuggestedSenses(modelID string, entries []VocabPair) ([]VocabPair, error) {
+	var missing []string
+	for _, e := range entries {
+		if len(e.Senses) == 0 {
+			missing = append(missing, e.Word)
+		}
+	}
+	if len(missing) == 0 {
+		return entries, nil
+	}
+	request, _ := json.Marshal(map[string][]string{"words": missing})
+	var result struct {
+		Words []struct {
+			Word   string   `json:"word"`
+			Senses []string `json:"senses"`
+		} `json:"words"`
+	}
+	if err := a.callChatGPTJSON(modelID, suggestSensesPrompt, string(request), &result); err != nil {
+		return nil, err
+	}
+	senses := map[string][]string{}
+	for _, w := range result.Words {
+		senses[strings.ToLower(strings.TrimSpace(w.Word))] = w.Senses
+	}
+	var filled []VocabPair
+	for _, e := range entries {
+		if len(e.Senses) == 0 {
+			for _, s := range senses[strings.ToLower(e.Word)] {
+				e.addSense(s)
+			}
+		}
+		if len(e.Senses) > 0 {
+			filled = append(filled, e)
+		}
+	}
+	return filled, nil
+}
+
+// --- Go functions callable from Javascript ---
+
+// SuggestNextWords proposes the n most valuable untested words of the
+// imported standard lists, weighing frequency, overlap between lists, words
+// already in saved word lists and the active class's error rate in each
+// frequency band.
+func (a *VocabApp) SuggestNextWords(n int) ([]WordSuggestion, error) {
+	if n < 1 || n > maxSuggestions {
+		return nil, fmt.Errorf("추천 단어 수는 1~%d 사이여야 합니다", maxSuggestions)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	lists, err := loadStandardLists()
+	if err != nil {
+		return nil, err
+	}
+	imported := false
+	for _, l := range lists {
+		imported = imported || len(l.Words) > 0
+	}
+	if !imported {
+		return nil, fmt.Errorf("기준 어휘 목록을 먼저 가져오세요")
+	}
+	tested, err := testedWords()
+	if err != nil {
+		return nil, err
+	}
+	planned, err := knownSenses()
+	if err != nil {
+		return nil, err
+	}
+	all, err := loadWordAttempts()
+	if err != nil {
+		return nil, err
+	}
+	var attempts []wordAttempt
+	for _, at := range all {
+		if settings.ActiveClass == "" || at.Class == settings.ActiveClass {
+			attempts = append(attempts, at)
+		}
+	}
+	suggestions := suggestWords(lists, tested, planned, attempts)
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions, nil
+}
+
+// SaveSuggestedWords saves suggested words as a word list under name, ready
+// for generation. Meanings that are not known yet are filled in by modelID.
+func (a *VocabApp) SaveSuggestedWords(modelID string, name string, words []WordSuggestion) (SavedWordList, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return SavedWordList{}, fmt.Errorf("단어장 이름을 입력하세요")
+	}
+	var entries []VocabPair
+	for _, w := range words {
+		e := VocabPair{Word: strings.TrimSpace(w.Word)}
+		for _, s := range w.Senses {
+			e.addSense(s)
+		}
+		if e.Word != "" {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		return SavedWordList{}, fmt.Errorf("저장할 단어가 없습니다")
+	}
+	entries, err := a.fillSuggestedSenses(modelID, entries)
+	if err != nil {
+		return SavedWordList{}, err
+	}
+	if len(entries) == 0 {
+		return SavedWordList{}, fmt.Errorf("단어의 뜻을 찾지 못했습니다")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	lists, err := loadWordLists()
+	if err != nil {
+		return SavedWordList{}, err
+	}
+	now := time.Now()
+	for i := range lists {
+		if lists[i].Name == name && lists[i].DeletedAt == nil {
+			lists[i].Entries = entries
+			lists[i].UpdatedAt = now
+			return lists[i], saveJSON(wordListsFile, lists)
+		}
+	}
+	list := SavedWordList{ID: newID(), Name: name, Entries: entries, CreatedAt: now, UpdatedAt: now}
+	lists = append(lists, list)
+	return list, saveJSON(wordListsFile, lists)
+}
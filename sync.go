@@ -0,0 +1,750 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Cloud Sync ---
+
+const (
+	syncStateFile = "sync-state.json"
+	conflictsDir  = "conflicts"
+
+	SyncBackendWebDAV = "webdav"
+	SyncBackendS3     = "s3"
+	SyncBackendGDrive = "gdrive"
+
+	ConflictNewer  = "newer"
+	ConflictLocal  = "local"
+	ConflictRemote = "remote"
+)
+
+// SyncSettings configures the optional mirror of the data directory. Only the
+// top-level data files are synced; settings.json stays per machine because it
+// holds API keys and machine-specific paths.
+type SyncSettings struct {
+	Enabled        bool   `json:"enabled"`
+	Backend        string `json:"backend"`
+	ConflictPolicy string `json:"conflictPolicy"`
+
+	WebDAVURL      string `json:"webdavUrl"`
+	WebDAVUsername string `json:"webdavUsername"`
+	WebDAVPassword string `json:"webdavPassword"`
+
+	S3Endpoint  string `json:"s3Endpoint"`
+	S3Region    string `json:"s3Region"`
+	S3Bucket    string `json:"s3Bucket"`
+	S3Prefix    string `json:"s3Prefix"`
+	S3AccessKey string `json:"s3AccessKey"`
+	S3SecretKey string `json:"s3SecretKey"`
+
+	DriveFolderID string `json:"driveFolderId"`
+}
+
+type remoteFile struct {
+	id      string
+	Tag     string
+	ModTime time.Time
+}
+
+type syncBackend interface {
+	List() (map[string]remoteFile, error)
+	Get(name string, f remoteFile) ([]byte, error)
+	Put(name string, f remoteFile, content []byte) error
+	Delete(name string, f remoteFile) error
+}
+
+// syncFileState is what both sides looked like after the last successful sync.
+type syncFileState struct {
+	LocalHash string `json:"localHash"`
+	RemoteTag string `json:"remoteTag"`
+}
+
+type SyncConflict struct {
+	Name       string `json:"name"`
+	Resolution string `json:"resolution"`
+	CopyPath   string `json:"copyPath"`
+}
+
+type SyncReport struct {
+	Uploaded   []string       `json:"uploaded"`
+	Downloaded []string       `json:"downloaded"`
+	Deleted    []string       `json:"deleted"` // removed from the remote after a local deletion
+	Removed    []string       `json:"removed"` // removed here after a deletion elsewhere, copy kept under conflicts/
+	Conflicts  []SyncConflict `json:"conflicts"`
+}
+
+func syncExcluded(name string) bool {
+	return name == settingsFile || name == syncStateFile || strings.HasSuffix(name, ".tmp") || strings.HasSuffix(name, ".lock")
+}
+
+// remoteNameOK reports whether a name listed by a backend can be used as a
+// data file name. The names come from the server and are joined to the data
+// directory, so anything that is not a plain file name is ignored.
+func remoteNameOK(name string) bool {
+	return filepath.IsLocal(name) && !strings.ContainsAny(name, `/\`)
+}
+
+// listRemote lists the remote files through backend, leaving out the names
+// remoteNameOK rejects.
+func listRemote(backend syncBackend) (map[string]remoteFile, error) {
+	remote, err := backend.List()
+	if err != nil {
+		return nil, fmt.Errorf("원격 목록 조회 오류: %w", err)
+	}
+	for name := range remote {
+		if !remoteNameOK(name) {
+			delete(remote, name)
+		}
+	}
+	return remote, nil
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *VocabApp) newSyncBackend(s SyncSettings) (syncBackend, error) {
+	switch s.Backend {
+	case SyncBackendWebDAV:
+		if s.WebDAVURL == "" {
+			return nil, fmt.Errorf("WebDAV 주소를 입력하세요")
+		}
+		return &webdavBackend{settings: s, client: &http.Client{Timeout: 60 * time.Second}}, nil
+	case SyncBackendS3:
+		if s.S3Bucket == "" || s.S3AccessKey == "" || s.S3SecretKey == "" {
+			return nil, fmt.Errorf("S3 버킷과 액세스 키를 입력하세요")
+		}
+		return &s3Backend{settings: s, client: &http.Client{Timeout: 60 * time.Second}}, nil
+	case SyncBackendGDrive:
+		client, err := a.googleHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		return &driveSyncBackend{app: a, folderID: s.DriveFolderID, client: client}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 동기화 방식입니다: '%s'", s.Backend)
+	}
+}
+
+// syncSnapshot reads the sync state and the data files. Must be called with
+// a.mu held.
+func syncSnapshot(dir string) (map[string]syncFileState, map[string][]byte, map[string]time.Time, error) {
+	state := map[string]syncFileState{}
+	if err := loadJSON(syncStateFile, &state); err != nil {
+		return nil, nil, nil, err
+	}
+	local := map[string][]byte{}
+	localMod := map[string]time.Time{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || syncExcluded(e.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		local[e.Name()] = content
+		if info, err := e.Info(); err == nil {
+			localMod[e.Name()] = info.ModTime()
+		}
+	}
+	return state, local, localMod, nil
+}
+
+// runSync does a three-way comparison of every data file against the state
+// recorded at the last sync. Files changed on only one side are copied over;
+// files changed on both sides are resolved with the conflict policy and the
+// losing version is kept under conflicts/ so nothing is lost. A file that
+// was synced before and is now missing on one side was deleted there, and
+// the deletion is carried over unless the other side changed the file; the
+// sync state is the record of what existed, so deleted files do not come
+// back on the next pull.
+//
+// The data files are read under a.mu, but the transfers run without it so
+// the app is not blocked on the network. Local changes are applied under the
+// lock at the end, and only to files that did not change in the meantime;
+// the others are compared again on the next sync.
+func (a *VocabApp) runSync(backend syncBackend, policy string) (SyncReport, error) {
+	var report SyncReport
+	dir, err := dataDir()
+	if err != nil {
+		return report, err
+	}
+	a.mu.Lock()
+	state, local, localMod, err := syncSnapshot(dir)
+	a.mu.Unlock()
+	if err != nil {
+		return report, err
+	}
+	remote, err := listRemote(backend)
+	if err != nil {
+		return report, err
+	}
+
+	names := map[string]bool{}
+	for name := range local {
+		names[name] = true
+	}
+	for name := range remote {
+		if !syncExcluded(name) {
+			names[name] = true
+		}
+	}
+
+	// Local changes, applied under the lock once the transfers are done.
+	writes := map[string][]byte{}
+	removes := map[string]bool{}
+
+	upload := func(name string) error {
+		if err := backend.Put(name, remote[name], local[name]); err != nil {
+			return fmt.Errorf("%s 업로드 오류: %w", name, err)
+		}
+		report.Uploaded = append(report.Uploaded, name)
+		return nil
+	}
+	download := func(name string) ([]byte, error) {
+		content, err := backend.Get(name, remote[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s 다운로드 오류: %w", name, err)
+		}
+		return content, nil
+	}
+	keepCopy := func(name string, content []byte) (string, error) {
+		copyDir := filepath.Join(dir, conflictsDir)
+		if err := os.MkdirAll(copyDir, 0755); err != nil {
+			return "", err
+		}
+		copyPath := filepath.Join(copyDir, fmt.Sprintf("%s.%s", name, time.Now().Format("20060102-150405")))
+		return copyPath, os.WriteFile(copyPath, content, 0644)
+	}
+
+	for name := range names {
+		localContent, hasLocal := local[name]
+		remoteInfo, hasRemote := remote[name]
+		prev, known := state[name]
+		localChanged := hasLocal && (!known || hashBytes(localContent) != prev.LocalHash)
+		remoteChanged := hasRemote && (!known || remoteInfo.Tag != prev.RemoteTag)
+
+		switch {
+		case known && !hasLocal && hasRemote && !remoteChanged:
+			// Deleted here since the last sync.
+			if err := backend.Delete(name, remoteInfo); err != nil {
+				return report, fmt.Errorf("%s 삭제 오류: %w", name, err)
+			}
+			report.Deleted = append(report.Deleted, name)
+		case known && hasLocal && !hasRemote && !localChanged:
+			// Deleted on another computer since the last sync.
+			removes[name] = true
+		case hasLocal && !hasRemote:
+			if err := upload(name); err != nil {
+				return report, err
+			}
+		case hasRemote && !hasLocal:
+			content, err := download(name)
+			if err != nil {
+				return report, err
+			}
+			writes[name] = content
+		case localChanged && remoteChanged:
+			remoteContent, err := download(name)
+			if err != nil {
+				return report, err
+			}
+			if hashBytes(remoteContent) == hashBytes(localContent) {
+				break
+			}
+			keepLocal := policy == ConflictLocal ||
+				(policy != ConflictRemote && !localMod[name].Before(remoteInfo.ModTime))
+			conflict := SyncConflict{Name: name}
+			if keepLocal {
+				conflict.Resolution = "로컬 유지"
+				if conflict.CopyPath, err = keepCopy(name, remoteContent); err != nil {
+					return report, err
+				}
+				if err := upload(name); err != nil {
+					return report, err
+				}
+			} else {
+				conflict.Resolution = "원격 유지"
+				if conflict.CopyPath, err = keepCopy(name, localContent); err != nil {
+					return report, err
+				}
+				writes[name] = remoteContent
+			}
+			report.Conflicts = append(report.Conflicts, conflict)
+		case localChanged:
+			if err := upload(name); err != nil {
+				return report, err
+			}
+		case remoteChanged:
+			content, err := download(name)
+			if err != nil {
+				return report, err
+			}
+			writes[name] = content
+		}
+	}
+
+	// Record the new baseline; tags must be re-read since uploads change them.
+	remote, err = listRemote(backend)
+	if err != nil {
+		return report, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// unchanged reports whether name still is as it was in the snapshot.
+	unchanged := func(name string) bool {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			_, had := local[name]
+			return !had
+		}
+		snapshot, had := local[name]
+		return err == nil && had && bytes.Equal(content, snapshot)
+	}
+	skipped := map[string]bool{}
+	for name, content := range writes {
+		if !unchanged(name) {
+			skipped[name] = true
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return report, err
+		}
+		local[name] = content
+		report.Downloaded = append(report.Downloaded, name)
+	}
+	for name := range removes {
+		if !unchanged(name) {
+			skipped[name] = true
+			continue
+		}
+		if _, err := keepCopy(name, local[name]); err != nil {
+			return report, err
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return report, err
+		}
+		delete(local, name)
+		report.Removed = append(report.Removed, name)
+	}
+
+	newState := map[string]syncFileState{}
+	for name, content := range local {
+		newState[name] = syncFileState{LocalHash: hashBytes(content), RemoteTag: remote[name].Tag}
+	}
+	for name := range skipped {
+		if prev, ok := state[name]; ok {
+			newState[name] = prev
+		} else {
+			delete(newState, name)
+		}
+	}
+	return report, saveJSON(syncStateFile, newState)
+}
+
+// --- WebDAV ---
+
+type webdavBackend struct {
+	settings SyncSettings
+	client   *http.Client
+}
+
+func (b *webdavBackend) url(name string) string {
+	return strings.TrimRight(b.settings.WebDAVURL, "/") + "/" + url.PathEscape(name)
+}
+
+func (b *webdavBackend) do(method string, target string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if b.settings.WebDAVUsername != "" {
+		req.SetBasicAuth(b.settings.WebDAVUsername, b.settings.WebDAVPassword)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return b.client.Do(req)
+}
+
+func (b *webdavBackend) List() (map[string]remoteFile, error) {
+	const propfind = `<?xml version="1.0" encoding="utf-8"?><d:propfind xmlns:d="DAV:"><d:prop><d:getetag/><d:getlastmodified/><d:resourcetype/></d:prop></d:propfind>`
+	base := strings.TrimRight(b.settings.WebDAVURL, "/") + "/"
+	resp, err := b.do("PROPFIND", base, strings.NewReader(propfind), map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		mk, err := b.do("MKCOL", base, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		mk.Body.Close()
+		return map[string]remoteFile{}, nil
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("WebDAV 응답 오류: %s", resp.Status)
+	}
+
+	var ms struct {
+		Responses []struct {
+			Href     string `xml:"DAV: href"`
+			Propstat []struct {
+				Prop struct {
+					ETag         string `xml:"DAV: getetag"`
+					LastModified string `xml:"DAV: getlastmodified"`
+					ResourceType struct {
+						Collection *struct{} `xml:"DAV: collection"`
+					} `xml:"DAV: resourcetype"`
+				} `xml:"DAV: prop"`
+			} `xml:"DAV: propstat"`
+		} `xml:"DAV: response"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("WebDAV 응답 해석 오류: %w", err)
+	}
+
+	files := map[string]remoteFile{}
+	for _, r := range ms.Responses {
+		if len(r.Propstat) == 0 || r.Propstat[0].Prop.ResourceType.Collection != nil {
+			continue
+		}
+		href, err := url.PathUnescape(r.Href)
+		if err != nil {
+			href = r.Href
+		}
+		prop := r.Propstat[0].Prop
+		mod, _ := http.ParseTime(prop.LastModified)
+		files[path.Base(href)] = remoteFile{Tag: prop.ETag + prop.LastModified, ModTime: mod}
+	}
+	return files, nil
+}
+
+func (b *webdavBackend) Get(name string, _ remoteFile) ([]byte, error) {
+	resp, err := b.do(http.MethodGet, b.url(name), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("WebDAV 응답 오류: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (b *webdavBackend) Put(name string, _ remoteFile, content []byte) error {
+	resp, err := b.do(http.MethodPut, b.url(name), bytes.NewReader(content), map[string]string{
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("WebDAV 응답 오류: %s", resp.Status)
+	}
+	return nil
+}
+
+func (b *webdavBackend) Delete(name string, _ remoteFile) error {
+	resp, err := b.do(http.MethodDelete, b.url(name), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("WebDAV 응답 오류: %s", resp.Status)
+	}
+	return nil
+}
+
+// --- S3 (and S3-compatible storage such as MinIO or R2) ---
+
+type s3Backend struct {
+	settings SyncSettings
+	client   *http.Client
+}
+
+func (b *s3Backend) key(name string) string {
+	prefix := strings.Trim(b.settings.S3Prefix, "/")
+	if prefix == "" {
+		return name
+	}
+	return prefix + "/" + name
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// do sends a path-style request signed with AWS Signature Version 4.
+func (b *s3Backend) do(method string, key string, query url.Values, body []byte) (*http.Response, error) {
+	region := b.settings.S3Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	endpoint := strings.TrimRight(b.settings.S3Endpoint, "/")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	var segments []string
+	for _, s := range strings.Split(key, "/") {
+		segments = append(segments, url.PathEscape(s))
+	}
+	escapedPath := "/" + url.PathEscape(b.settings.S3Bucket)
+	if key != "" {
+		escapedPath += "/" + strings.Join(segments, "/")
+	}
+	u, err := url.Parse(endpoint + escapedPath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := hashBytes(body)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		u.RawQuery,
+		"host:" + u.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hashBytes([]byte(canonicalRequest))
+	signingKey := hmacSHA256(hmacSHA256(hmacSHA256(hmacSHA256([]byte("AWS4"+b.settings.S3SecretKey), date), region), "s3"), "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.settings.S3AccessKey, scope, signedHeaders, signature))
+	return b.client.Do(req)
+}
+
+func (b *s3Backend) List() (map[string]remoteFile, error) {
+	prefix := b.key("")
+	resp, err := b.do(http.MethodGet, "", url.Values{"list-type": {"2"}, "prefix": {prefix}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("S3 응답 오류: %s %s", resp.Status, msg)
+	}
+	var result struct {
+		Contents []struct {
+			Key          string    `xml:"Key"`
+			ETag         string    `xml:"ETag"`
+			LastModified time.Time `xml:"LastModified"`
+		} `xml:"Contents"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("S3 응답 해석 오류: %w", err)
+	}
+	files := map[string]remoteFile{}
+	for _, c := range result.Contents {
+		name := strings.TrimPrefix(c.Key, prefix)
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		files[name] = remoteFile{Tag: c.ETag, ModTime: c.LastModified}
+	}
+	return files, nil
+}
+
+func (b *s3Backend) Get(name string, _ remoteFile) ([]byte, error) {
+	resp, err := b.do(http.MethodGet, b.key(name), url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("S3 응답 오류: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (b *s3Backend) Put(name string, _ remoteFile, content []byte) error {
+	resp, err := b.do(http.MethodPut, b.key(name), url.Values{}, content)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("S3 응답 오류: %s %s", resp.Status, msg)
+	}
+	return nil
+}
+
+func (b *s3Backend) Delete(name string, _ remoteFile) error {
+	resp, err := b.do(http.MethodDelete, b.key(name), url.Values{}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("S3 응답 오류: %s %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// --- Google Drive ---
+
+const driveSyncFolderName = "vocab-generator-sync"
+
+type driveSyncBackend struct {
+	app      *VocabApp
+	folderID string
+	client   *http.Client
+}
+
+// ensureFolder finds or creates the sync folder and remembers its ID.
+func (b *driveSyncBackend) ensureFolder() error {
+	if b.folderID != "" {
+		return nil
+	}
+	found, err := driveList(b.client, fmt.Sprintf("name = '%s' and mimeType = 'application/vnd.google-apps.folder' and trashed = false", driveSyncFolderName))
+	if err != nil {
+		return err
+	}
+	if len(found) > 0 {
+		b.folderID = found[0].ID
+	} else {
+		folder, err := driveCreateFolder(b.client, driveSyncFolderName)
+		if err != nil {
+			return err
+		}
+		b.folderID = folder.ID
+	}
+
+	b.app.mu.Lock()
+	defer b.app.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	settings.Sync.DriveFolderID = b.folderID
+	return saveJSON(settingsFile, settings)
+}
+
+func (b *driveSyncBackend) List() (map[string]remoteFile, error) {
+	if err := b.ensureFolder(); err != nil {
+		return nil, err
+	}
+	found, err := driveList(b.client, fmt.Sprintf("'%s' in parents and trashed = false", b.folderID))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]remoteFile{}
+	for _, f := range found {
+		files[f.Name] = remoteFile{id: f.ID, Tag: f.MD5Checksum, ModTime: f.ModifiedTime}
+	}
+	return files, nil
+}
+
+func (b *driveSyncBackend) Get(_ string, f remoteFile) ([]byte, error) {
+	resp, err := b.client.Get("https://www.googleapis.com/drive/v3/files/" + url.PathEscape(f.id) + "?alt=media")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkGoogleResponse(resp); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (b *driveSyncBackend) Put(name string, f remoteFile, content []byte) error {
+	metadata := map[string]interface{}{"name": name}
+	if f.id == "" {
+		metadata["parents"] = []string{b.folderID}
+	}
+	_, err := driveUpload(b.client, f.id, metadata, "application/json", content)
+	return err
+}
+
+// Delete moves the file to the Drive trash, where it can still be restored.
+func (b *driveSyncBackend) Delete(_ string, f remoteFile) error {
+	body, _ := json.Marshal(map[string]bool{"trashed": true})
+	req, err := http.NewRequest(http.MethodPatch, "https://www.googleapis.com/drive/v3/files/"+url.PathEscape(f.id), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkGoogleResponse(resp)
+}
+
+// --- Go functions callable from Javascript ---
+
+// SyncNow mirrors the data directory with the configured remote location.
+func (a *VocabApp) SyncNow() (SyncReport, error) {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return SyncReport{}, err
+	}
+	if !settings.Sync.Enabled {
+		return SyncReport{}, fmt.Errorf("동기화가 설정되지 않았습니다")
+	}
+	backend, err := a.newSyncBackend(settings.Sync)
+	if err != nil {
+		return SyncReport{}, err
+	}
+
+	report, err := a.runSync(backend, settings.Sync.ConflictPolicy)
+	if err != nil {
+		return report, err
+	}
+	if len(report.Downloaded) > 0 || len(report.Removed) > 0 {
+		runtime.EventsEmit(a.ctx, "sync:updated", report)
+	}
+	return report, nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Template Packs ---
+
+// templatePackFormat identifies template pack files.
+const templatePackFormat = "vocab-template-pack/1"
+
+// TemplatePack bundles prompt templates, question titles and the export
+// layout so a configuration can be passed on to other teachers. Machine- and
+// school-specific settings (fonts, folders, class profiles) are left out.
+type TemplatePack struct {
+	Format         string            `json:"format"`
+	Name           string            `json:"name"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	Templates      []PromptTemplate  `json:"templates"`
+	QuestionTitles map[string]string `json:"questionTitles,omitempty"`
+	ExportLayout   string            `json:"exportLayout,omitempty"`
+	AnswerKey      string            `json:"answerKeyFormat,omitempty"`
+	ExportHeader   string            `json:"exportHeader,omitempty"`
+}
+
+// TemplatePackReport is what ImportTemplatePack changed.
+type TemplatePackReport struct {
+	Name     string   `json:"name"`
+	Added    int      `json:"added"`
+	Replaced int      `json:"replaced"`
+	Skipped  []string `json:"skipped"` // templates kept because they already existed
+	Titles   int      `json:"titles"`
+	Layout   bool     `json:"layout"` // export layout settings applied
+}
+
+func validateTemplatePack(p TemplatePack) error {
+	if p.Format != templatePackFormat {
+		return fmt.Errorf("템플릿 팩 파일이 아닙니다")
+	}
+	for _, t := range p.Templates {
+		if t.Name == "" || t.Name == builtinTemplateName {
+			return fmt.Errorf("사용할 수 없는 템플릿 이름입니다: '%s'", t.Name)
+		}
+		if _, ok := defaultQuestionTitles[t.QuestionType]; !ok {
+			return fmt.Errorf("지원하지 않는 문제 유형입니다: '%s'", t.QuestionType)
+		}
+		if _, err := parseTemplateText(t.System); err != nil {
+			return fmt.Errorf("%s (%s): %w", t.Name, t.QuestionType, err)
+		}
+	}
+	for qType, title := range p.QuestionTitles {
+		if _, err := parseTemplateText(title); err != nil {
+			return fmt.Errorf("%s 문제 제목: %w", qType, err)
+		}
+	}
+	if _, err := parseTemplateText(p.ExportHeader); err != nil {
+		return fmt.Errorf("머리말 템플릿: %w", err)
+	}
+	if err := validateExportLayout(p.ExportLayout); err != nil {
+		return err
+	}
+	return validateAnswerKeyFormat(p.AnswerKey)
+}
+
+// --- Go functions callable from Javascript ---
+
+// ExportTemplatePack saves all prompt templates, the question titles and the
+// export layout settings as a template pack file.
+func (a *VocabApp) ExportTemplatePack(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("템플릿 팩 이름을 입력하세요")
+	}
+	a.mu.Lock()
+	templates, err := loadPromptTemplates()
+	var settings Settings
+	if err == nil {
+		settings, err = loadSettings()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	pack := TemplatePack{
+		Format:         templatePackFormat,
+		Name:           name,
+		CreatedAt:      time.Now(),
+		Templates:      templates,
+		QuestionTitles: settings.QuestionTitles,
+		ExportLayout:   settings.ExportLayout,
+		AnswerKey:      settings.AnswerKeyFormat,
+		ExportHeader:   settings.ExportHeader,
+	}
+	if pack.Templates == nil {
+		pack.Templates = []PromptTemplate{}
+	}
+	data, err := json.MarshalIndent(pack, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "템플릿 팩 저장",
+		DefaultFilename: invalidFilenameChars.Replace(name) + ".vocabpack.json",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "템플릿 팩 (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if filePath == "" {
+		return "", fmt.Errorf("저장 경로가 선택되지 않았습니다")
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", fmt.Errorf("파일 저장 오류: %w", err)
+	}
+	return fmt.Sprintf("저장 완료: %s", filepath.Base(filePath)), nil
+}
+
+// ImportTemplatePack adds the templates and titles of a template pack file.
+// Templates and titles that already exist are kept unless overwrite is set;
+// the export layout settings are only applied with overwrite.
+func (a *VocabApp) ImportTemplatePack(overwrite bool) (TemplatePackReport, error) {
+	selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "템플릿 팩 선택",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "템플릿 팩 (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+	if err != nil {
+		return TemplatePackReport{}, err
+	}
+	if selection == "" {
+		return TemplatePackReport{}, fmt.Errorf("파일이 선택되지 않았습니다")
+	}
+	data, err := os.ReadFile(selection)
+	if err != nil {
+		return TemplatePackReport{}, fmt.Errorf("파일 읽기 오류: %w", err)
+	}
+	var pack TemplatePack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return TemplatePackReport{}, fmt.Errorf("템플릿 팩 형식 오류: %w", err)
+	}
+	if err := validateTemplatePack(pack); err != nil {
+		return TemplatePackReport{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	templates, err := loadPromptTemplates()
+	if err != nil {
+		return TemplatePackReport{}, err
+	}
+	settings, err := loadSettings()
+	if err != nil {
+		return TemplatePackReport{}, err
+	}
+
+	report := TemplatePackReport{Name: pack.Name, Skipped: []string{}}
+next:
+	for _, t := range pack.Templates {
+		for i := range templates {
+			if templates[i].Name == t.Name && templates[i].QuestionType == t.QuestionType {
+				if overwrite {
+					templates[i] = t
+					report.Replaced++
+				} else {
+					report.Skipped = append(report.Skipped, fmt.Sprintf("%s (%s)", t.Name, t.QuestionType))
+				}
+				continue next
+			}
+		}
+		templates = append(templates, t)
+		report.Added++
+	}
+
+	if len(pack.QuestionTitles) > 0 && settings.QuestionTitles == nil {
+		settings.QuestionTitles = map[string]string{}
+	}
+	for qType, title := range pack.QuestionTitles {
+		if overwrite || strings.TrimSpace(settings.QuestionTitles[qType]) == "" {
+			settings.QuestionTitles[qType] = title
+			report.Titles++
+		}
+	}
+	if overwrite {
+		settings.ExportLayout = pack.ExportLayout
+		settings.AnswerKeyFormat = pack.AnswerKey
+		settings.ExportHeader = pack.ExportHeader
+		report.Layout = true
+	}
+
+	if err := saveJSON(templatesFile, templates); err != nil {
+		return report, err
+	}
+	return report, saveJSON(settingsFile, settings)
+}
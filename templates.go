@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// --- Prompt Templates ---
+
+const (
+	templatesFile       = "templates.json"
+	builtinTemplateName = "기본"
+)
+
+// PromptTemplate is a user-defined system prompt for one question type. System
+// is a Go text/template rendered with promptVars, so a template can reuse the
+// shared rules, wrap the built-in prompt via {{.Default}} and use the active
+// class profile's variables ({{.TeacherName}}, {{.Textbook}}, ...).
+type PromptTemplate struct {
+	Name         string `json:"name"`
+	QuestionType string `json:"questionType"`
+	System       string `json:"system"`
+}
+
+func loadPromptTemplates() ([]PromptTemplate, error) {
+	var templates []PromptTemplate
+	if err := loadJSON(templatesFile, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func findPromptTemplate(templates []PromptTemplate, name string, questionType string) (PromptTemplate, bool) {
+	for _, t := range templates {
+		if t.Name == name && t.QuestionType == questionType {
+			return t, true
+		}
+	}
+	return PromptTemplate{}, false
+}
+
+// promptVars is the data prompt templates are rendered with: the class
+// profile variables plus the built-in rule snippets.
+func promptVars(opts promptOptions, class ClassProfile) map[string]interface{} {
+	vars := classVars(class)
+	vars["QuestionType"] = opts.QuestionType
+	vars["NumSentences"] = opts.NumSentences
+	vars["QuestionTitle"] = opts.Title
+	vars["DistributionRule"] = distributionRule
+	vars["SelfCorrectionRule"] = selfCorrectionRule
+	vars["Default"] = buildSystemPrompt(opts)
+	return vars
+}
+
+// parseTemplateText checks template syntax without executing it, since the
+// variables available at run time depend on the active class.
+func parseTemplateText(text string) (*template.Template, error) {
+	tmpl, err := template.New("template").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 문법 오류: %w", err)
+	}
+	return tmpl, nil
+}
+
+func renderTemplateText(text string, vars map[string]interface{}) (string, error) {
+	tmpl, err := parseTemplateText(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("템플릿 적용 오류: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func renderPromptTemplate(text string, opts promptOptions, class ClassProfile) (string, error) {
+	return renderTemplateText(text, promptVars(opts, class))
+}
+
+// systemPromptFor returns the system prompt of the named template, falling
+// back to the built-in prompt for an empty name or builtinTemplateName.
+func (a *VocabApp) systemPromptFor(templateName string, questionType string, numSentences int) (string, error) {
+	a.mu.Lock()
+	templates, err := loadPromptTemplates()
+	var settings Settings
+	if err == nil {
+		settings, err = loadSettings()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	opts, err := settings.promptOptions(questionType, numSentences)
+	if err != nil {
+		return "", err
+	}
+	if templateName == "" || templateName == builtinTemplateName {
+		return buildSystemPrompt(opts), nil
+	}
+	t, ok := findPromptTemplate(templates, templateName, questionType)
+	if !ok {
+		return "", fmt.Errorf("'%s' 유형의 템플릿 '%s'을(를) 찾을 수 없습니다", questionType, templateName)
+	}
+	return renderPromptTemplate(t.System, opts, settings.activeClass())
+}
+
+// --- Go functions callable from Javascript ---
+
+// GetPromptTemplates lists the templates available for a question type,
+// starting with the built-in one.
+func (a *VocabApp) GetPromptTemplates(questionType string) ([]PromptTemplate, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	templates, err := loadPromptTemplates()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := settings.promptOptions(questionType, 1)
+	if err != nil {
+		return nil, err
+	}
+	result := []PromptTemplate{{
+		Name:         builtinTemplateName,
+		QuestionType: questionType,
+		System:       buildSystemPrompt(opts),
+	}}
+	for _, t := range templates {
+		if t.QuestionType == questionType {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// SavePromptTemplate creates or replaces a template with the same name and type.
+func (a *VocabApp) SavePromptTemplate(t PromptTemplate) error {
+	if t.Name == "" || t.Name == builtinTemplateName {
+		return fmt.Errorf("사용할 수 없는 템플릿 이름입니다: '%s'", t.Name)
+	}
+	if _, err := parseTemplateText(t.System); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	templates, err := loadPromptTemplates()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range templates {
+		if templates[i].Name == t.Name && templates[i].QuestionType == t.QuestionType {
+			templates[i] = t
+			replaced = true
+		}
+	}
+	if !replaced {
+		templates = append(templates, t)
+	}
+	return saveJSON(templatesFile, templates)
+}
+
+func (a *VocabApp) DeletePromptTemplate(name string, questionType string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	templates, err := loadPromptTemplates()
+	if err != nil {
+		return err
+	}
+	kept := templates[:0]
+	for _, t := range templates {
+		if t.Name != name || t.QuestionType != questionType {
+			kept = append(kept, t)
+		}
+	}
+	return saveJSON(templatesFile, kept)
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Textbook Grounding ---
+
+const textbookFile = "textbooks.json"
+
+const (
+	// textbookSentencesPerWord and maxTextbookSentences cap how much of a
+	// unit's passage goes into the prompt.
+	textbookSentencesPerWord = 2
+	maxTextbookSentences     = 30
+	// textbookTopicSentences are quoted when no sentence uses a list word,
+	// so the model still learns what the unit is about.
+	textbookTopicSentences = 3
+)
+
+// TextbookUnit is the reading passage of one textbook unit. Generated
+// sentences are kept consistent with it.
+type TextbookUnit struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Passage   string    `json:"passage"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func loadTextbookUnits() ([]TextbookUnit, error) {
+	var units []TextbookUnit
+	return units, loadJSON(textbookFile, &units)
+}
+
+// splitSentences breaks a passage into sentences at ., ! and ? followed by
+// white space, and at line breaks.
+func splitSentences(passage string) []string {
+	var sentences []string
+	var current strings.Builder
+	runes := []rune(passage)
+	flush := func() {
+		if s := strings.Join(strings.Fields(current.String()), " "); s != "" {
+			sentences = append(sentences, s)
+		}
+		current.Reset()
+	}
+	for i, r := range runes {
+		if r == '\n' {
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+		if strings.ContainsRune(".!?", r) && (i+1 == len(runes) || runes[i+1] == ' ' || runes[i+1] == '\n') {
+			flush()
+		}
+	}
+	flush()
+	return sentences
+}
+
+// usesWord reports whether a sentence contains the word or an inflected form
+// of it.
+func usesWord(sentence string, word string) bool {
+	if wordPattern(word).MatchString(sentence) {
+		return true
+	}
+	for _, token := range strings.FieldsFunc(sentence, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '\'' || r == '-')
+	}) {
+		if isInflectionOf(token, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// textbookSection retrieves the passage sentences that use the list words
+// and quotes them for the system prompt.
+func textbookSection(unit TextbookUnit, entries []VocabPair) string {
+	sentences := splitSentences(unit.Passage)
+	if len(sentences) == 0 {
+		return ""
+	}
+	picked := map[int]bool{}
+	var order []int
+	seen := map[string]bool{}
+	for _, e := range entries {
+		word := strings.ToLower(e.Word)
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		found := 0
+		for i, s := range sentences {
+			if found == textbookSentencesPerWord || len(order) == maxTextbookSentences {
+				break
+			}
+			if usesWord(s, e.Word) {
+				found++
+				if !picked[i] {
+					picked[i] = true
+					order = append(order, i)
+				}
+			}
+		}
+	}
+	intro := "The students have read a textbook passage that contains the sentences below. Keep the question sentences consistent with its topic, characters and vocabulary level, and prefer contexts that echo these sentences, but do not copy them word for word."
+	if len(order) == 0 {
+		intro = "The students have read a textbook passage that begins as below. Keep the question sentences consistent with its topic and vocabulary level."
+		for i := 0; i < len(sentences) && i < textbookTopicSentences; i++ {
+			order = append(order, i)
+		}
+	}
+	lines := []string{"### Textbook Context", fmt.Sprintf("%s (%s)", intro, unit.Name)}
+	for _, i := range order {
+		lines = append(lines, "- "+sentences[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// selectedTextbookUnit returns the unit chosen in the settings, if any. Must
+// be called with a.mu held.
+func selectedTextbookUnit(g GenerationSettings) (TextbookUnit, bool, error) {
+	if g.TextbookUnit == "" {
+		return TextbookUnit{}, false, nil
+	}
+	units, err := loadTextbookUnits()
+	if err != nil {
+		return TextbookUnit{}, false, err
+	}
+	for _, u := range units {
+		if u.ID == g.TextbookUnit {
+			return u, true, nil
+		}
+	}
+	return TextbookUnit{}, false, nil
+}
+
+// --- Go functions callable from Javascript ---
+
+func (a *VocabApp) ListTextbookUnits() ([]TextbookUnit, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	units, err := loadTextbookUnits()
+	if err != nil {
+		return nil, err
+	}
+	if units == nil {
+		units = []TextbookUnit{}
+	}
+	return units, nil
+}
+
+// SaveTextbookUnit creates a unit (empty ID) or replaces the one with the
+// same ID.
+func (a *VocabApp) SaveTextbookUnit(u TextbookUnit) (TextbookUnit, error) {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Passage = strings.TrimSpace(u.Passage)
+	if u.Name == "" {
+		return TextbookUnit{}, fmt.Errorf("단원 이름을 입력하세요")
+	}
+	if u.Passage == "" {
+		return TextbookUnit{}, fmt.Errorf("본문을 입력하세요")
+	}
+	u.UpdatedAt = time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	units, err := loadTextbookUnits()
+	if err != nil {
+		return TextbookUnit{}, err
+	}
+	if u.ID == "" {
+		u.ID = newID()
+		u.CreatedAt = u.UpdatedAt
+		units = append(units, u)
+		return u, saveJSON(textbookFile, units)
+	}
+	for i := range units {
+		if units[i].ID == u.ID {
+			u.CreatedAt = units[i].CreatedAt
+			units[i] = u
+			return u, saveJSON(textbookFile, units)
+		}
+	}
+	return TextbookUnit{}, fmt.Errorf("단원을 찾을 수 없습니다")
+}
+
+func (a *VocabApp) DeleteTextbookUnit(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	units, err := loadTextbookUnits()
+	if err != nil {
+		return err
+	}
+	kept := units[:0]
+	for _, u := range units {
+		if u.ID != id {
+			kept = append(kept, u)
+		}
+	}
+	return saveJSON(textbookFile, kept)
+}
+
+// SelectTextbookUnit grounds Generate in a unit's passage; an empty id turns
+// the grounding off.
+func (a *VocabApp) SelectTextbookUnit(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		units, err := loadTextbookUnits()
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, u := range units {
+			found = found || u.ID == id
+		}
+		if !found {
+			return fmt.Errorf("단원을 찾을 수 없습니다")
+		}
+	}
+	settings.Generation.TextbookUnit = id
+	return saveJSON(settingsFile, settings)
+}
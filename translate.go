@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Teacher Translations ---
+
+// translationHeader starts the teacher-only appendix with Korean translations
+// of the question sentences. It comes after the [정답] section.
+const translationHeader = "[교사용 해석]"
+
+const translationPrompt = `You translate English sentences from vocabulary tests into natural Korean for teachers.
+The blank in each sentence has already been filled with the answer in [brackets]; translate the complete sentence, keeping the translation of the bracketed word recognizable.
+Respond with JSON only: {"questions": [{"question": 1, "translations": ["첫 번째 문장 번역", "두 번째 문장 번역"]}]}, one translation per sentence, in order.`
+
+type translationResult struct {
+	Questions []struct {
+		Question     int      `json:"question"`
+		Translations []string `json:"translations"`
+	} `json:"questions"`
+}
+
+// fillBlank puts the answer into a sentence's blank for translation.
+func fillBlank(line string, q Question) string {
+	if q.Answer < 1 || q.Answer > len(q.Choices) {
+		return line
+	}
+	answer := q.Choices[q.Answer-1]
+	if len(q.Forms) > 0 {
+		answer = strings.Join(q.Forms, "/")
+	}
+	return blankRe.ReplaceAllString(line, "["+answer+"]")
+}
+
+// addTranslations translates the body sentences of every question in a
+// follow-up call and appends them as the teacher appendix. Questions without
+// sentences (뜻풀이 판단) are left out.
+func (a *VocabApp) addTranslations(modelID string, output string) (string, error) {
+	out := parseOutput(output)
+	var request strings.Builder
+	for _, q := range out.Questions {
+		if len(q.Body) == 0 {
+			continue
+		}
+		fmt.Fprintf(&request, "Question %d\n", q.Number)
+		for i, line := range q.Body {
+			fmt.Fprintf(&request, "  %d. %s\n", i+1, fillBlank(line, q))
+		}
+	}
+	if request.Len() == 0 {
+		return output, nil
+	}
+
+	var result translationResult
+	if err := a.callChatGPTJSON(modelID, translationPrompt, request.String(), &result); err != nil {
+		return output, err
+	}
+	var appendix []string
+	for _, t := range result.Questions {
+		if len(t.Translations) == 0 {
+			continue
+		}
+		appendix = append(appendix, fmt.Sprintf("%d.", t.Question))
+		for i, line := range t.Translations {
+			appendix = append(appendix, fmt.Sprintf("(%d) %s", i+1, strings.TrimSpace(line)))
+		}
+	}
+	if len(appendix) == 0 {
+		return output, fmt.Errorf("번역 결과가 비어 있습니다")
+	}
+	return strings.TrimRight(output, "\n") + "\n\n" + translationHeader + "\n" + strings.Join(appendix, "\n"), nil
+}
+
+// addTranslationsOrLog runs addTranslations for Generate; a failed
+// translation is logged and the test returned without the appendix.
+func (a *VocabApp) addTranslationsOrLog(modelID string, output string) string {
+	translated, err := a.addTranslations(modelID, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "예문 번역 오류: %v", err)
+	}
+	return translated
+}
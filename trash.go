@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// --- Trash ---
+
+const (
+	TrashKindWordList = "wordlist"
+	TrashKindTest     = "test"
+	TrashKindQuestion = "question"
+
+	defaultTrashRetentionDays = 30
+)
+
+// TrashItem is a soft-deleted list, test or bank question.
+type TrashItem struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deletedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func trashRetention(s Settings) time.Duration {
+	days := s.TrashRetentionDays
+	if days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// setDeleted marks (or with nil, unmarks) one item as deleted. It must be
+// called with a.mu held.
+func setDeleted(kind string, id string, at *time.Time) error {
+	found := false
+	switch kind {
+	case TrashKindWordList:
+		lists, err := loadWordLists()
+		if err != nil {
+			return err
+		}
+		for i := range lists {
+			if lists[i].ID == id {
+				lists[i].DeletedAt = at
+				found = true
+			}
+		}
+		if found {
+			return saveJSON(wordListsFile, lists)
+		}
+	case TrashKindTest:
+		tests, err := loadTests()
+		if err != nil {
+			return err
+		}
+		for i := range tests {
+			if tests[i].ID == id {
+				if at != nil {
+					if err := errIfLocked(tests[i]); err != nil {
+						return err
+					}
+				}
+				tests[i].DeletedAt = at
+				found = true
+			}
+		}
+		if found {
+			return saveJSON(testsFile, tests)
+		}
+	case TrashKindQuestion:
+		bank, err := loadBank()
+		if err != nil {
+			return err
+		}
+		for i := range bank {
+			if bank[i].ID == id {
+				bank[i].DeletedAt = at
+				found = true
+			}
+		}
+		if found {
+			return saveJSON(bankFile, bank)
+		}
+	default:
+		return fmt.Errorf("알 수 없는 항목 종류입니다: '%s'", kind)
+	}
+	return fmt.Errorf("항목을 찾을 수 없습니다")
+}
+
+// purgeTrash permanently removes trashed items deleted before cutoff (all of
+// them for a zero cutoff). It must be called with a.mu held.
+func purgeTrash(cutoff time.Time) (int, error) {
+	expired := func(at *time.Time) bool {
+		return at != nil && (cutoff.IsZero() || at.Before(cutoff))
+	}
+	removed := 0
+
+	lists, err := loadWordLists()
+	if err != nil {
+		return removed, err
+	}
+	keptLists := lists[:0]
+	for _, l := range lists {
+		if expired(l.DeletedAt) {
+			removed++
+		} else {
+			keptLists = append(keptLists, l)
+		}
+	}
+	if err := saveJSON(wordListsFile, keptLists); err != nil {
+		return removed, err
+	}
+
+	tests, err := loadTests()
+	if err != nil {
+		return removed, err
+	}
+	keptTests := tests[:0]
+	for _, t := range tests {
+		if expired(t.DeletedAt) {
+			removed++
+		} else {
+			keptTests = append(keptTests, t)
+		}
+	}
+	if err := saveJSON(testsFile, keptTests); err != nil {
+		return removed, err
+	}
+
+	bank, err := loadBank()
+	if err != nil {
+		return removed, err
+	}
+	keptBank := bank[:0]
+	for _, q := range bank {
+		if expired(q.DeletedAt) {
+			removed++
+		} else {
+			keptBank = append(keptBank, q)
+		}
+	}
+	return removed, saveJSON(bankFile, keptBank)
+}
+
+// purgeExpiredTrash applies the retention period; called at startup.
+func (a *VocabApp) purgeExpiredTrash() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	_, err = purgeTrash(time.Now().Add(-trashRetention(settings)))
+	return err
+}
+
+// --- Go functions callable from Javascript ---
+
+// DeleteItem moves a word list, test or bank question to the trash.
+func (a *VocabApp) DeleteItem(kind string, id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	return setDeleted(kind, id, &now)
+}
+
+func (a *VocabApp) RestoreItem(kind string, id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return setDeleted(kind, id, nil)
+}
+
+func (a *VocabApp) ListTrash() ([]TrashItem, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+	retention := trashRetention(settings)
+	items := []TrashItem{}
+	add := func(kind, id, name string, at *time.Time) {
+		if at != nil {
+			items = append(items, TrashItem{Kind: kind, ID: id, Name: name, DeletedAt: *at, ExpiresAt: at.Add(retention)})
+		}
+	}
+
+	lists, err := loadWordLists()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		add(TrashKindWordList, l.ID, l.Name, l.DeletedAt)
+	}
+	tests, err := loadTests()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tests {
+		add(TrashKindTest, t.ID, t.Name, t.DeletedAt)
+	}
+	bank, err := loadBank()
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range bank {
+		add(TrashKindQuestion, q.ID, fmt.Sprintf("%s: %s", q.QuestionType, q.Question.Title), q.DeletedAt)
+	}
+	return items, nil
+}
+
+// EmptyTrash permanently removes everything in the trash.
+func (a *VocabApp) EmptyTrash() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return purgeTrash(time.Time{})
+}
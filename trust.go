@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// --- Trusted Programs ---
+
+// Programs the app runs (exporter plugins, the local inference server) only
+// run once the user has approved them on this computer. Approvals are kept
+// next to the data directory, not in it, so no backup, sync or restored file
+// can approve a program.
+const trustFileName = appDataDirName + "-trust.json"
+
+// Kinds of trusted programs.
+const (
+	trustPlugin      = "plugin"
+	trustLocalServer = "local-server"
+)
+
+// trustedProgram is one approval: the file and its contents when approved.
+// A changed file needs a new approval.
+type trustedProgram struct {
+	Kind      string    `json:"kind"`
+	Path      string    `json:"path"`
+	SHA256    string    `json:"sha256"`
+	TrustedAt time.Time `json:"trustedAt"`
+}
+
+func trustFilePath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		if base, err = os.Getwd(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(base, trustFileName), nil
+}
+
+// loadTrusted reads the approvals.
+func loadTrusted() ([]trustedProgram, error) {
+	p, err := trustFilePath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("승인 목록 읽기 오류: %w", err)
+	}
+	var trusted []trustedProgram
+	if err := json.Unmarshal(content, &trusted); err != nil {
+		return nil, fmt.Errorf("승인 목록 형식 오류: %w", err)
+	}
+	return trusted, nil
+}
+
+// hashFiles returns the SHA-256 of the contents of paths, in order.
+func hashFiles(paths ...string) (string, error) {
+	h := sha256.New()
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isTrusted reports whether the program at path was approved with the
+// contents it has now.
+func isTrusted(kind string, path string, hash string) (bool, error) {
+	trusted, err := loadTrusted()
+	if err != nil {
+		return false, err
+	}
+	for _, t := range trusted {
+		if t.Kind == kind && t.Path == path && t.SHA256 == hash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// trustProgram records an approval, replacing an earlier one of the same
+// path. Must be called with a.mu held.
+func trustProgram(kind string, path string, hash string) error {
+	trusted, err := loadTrusted()
+	if err != nil {
+		return err
+	}
+	kept := trusted[:0]
+	for _, t := range trusted {
+		if t.Kind != kind || t.Path != path {
+			kept = append(kept, t)
+		}
+	}
+	kept = append(kept, trustedProgram{Kind: kind, Path: path, SHA256: hash, TrustedAt: time.Now()})
+	content, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return err
+	}
+	p, err := trustFilePath()
+	if err != nil {
+		return err
+	}
+	return writeDataFile(filepath.Dir(p), filepath.Base(p), content)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- API Usage & Cost ---
+
+const usageFile = "usage.json"
+
+// modelPrice is the list price in USD per million tokens.
+type modelPrice struct {
+	Input  float64
+	Output float64
+}
+
+var modelPrices = map[string]modelPrice{
+	"gpt-5-pro":    {15, 120},
+	"gpt-5":        {1.25, 10},
+	"gpt-5-mini":   {0.25, 2},
+	"gpt-5-nano":   {0.05, 0.4},
+	"gpt-4.1":      {2, 8},
+	"gpt-4.1-mini": {0.4, 1.6},
+	"gpt-4.1-nano": {0.1, 0.4},
+	"gpt-4o":       {2.5, 10},
+	"gpt-4o-mini":  {0.15, 0.6},
+
+	"text-embedding-3-small": {0.02, 0},
+}
+
+// priceFor looks up the price of a model, using the base model's price for
+// fine-tuned IDs (ft:<base>:...). Unknown models cost 0.
+func priceFor(model string) modelPrice {
+	if strings.HasPrefix(model, "ft:") {
+		model = strings.Split(model, ":")[1]
+	}
+	return modelPrices[model]
+}
+
+// MonthlyUsage is the API usage of one calendar month.
+type MonthlyUsage struct {
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	CostUSD          float64 `json:"costUSD"`
+}
+
+func usageMonth(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// loadUsage returns the usage per month ("2006-01"). Must be called with
+// a.mu held.
+func loadUsage() (map[string]MonthlyUsage, error) {
+	usage := map[string]MonthlyUsage{}
+	if err := loadJSON(usageFile, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// recordUsage adds the token usage of one API call to the current month.
+func (a *VocabApp) recordUsage(model string, u openai.Usage) {
+	if u.TotalTokens == 0 {
+		return
+	}
+	price := priceFor(model)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	usage, err := loadUsage()
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "사용량 기록 읽기 오류: %v", err)
+		return
+	}
+	month := usageMonth(time.Now())
+	m := usage[month]
+	m.Calls++
+	m.PromptTokens += u.PromptTokens
+	m.CompletionTokens += u.CompletionTokens
+	m.CostUSD += (float64(u.PromptTokens)*price.Input + float64(u.CompletionTokens)*price.Output) / 1e6
+	usage[month] = m
+	if err := saveJSON(usageFile, usage); err != nil {
+		runtime.LogErrorf(a.ctx, "사용량 기록 저장 오류: %v", err)
+	}
+}
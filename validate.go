@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- Structured Output ---
+
+// Question is one question block parsed back out of the model's text output.
+type Question struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title"`
+	Body    []string `json:"body"`
+	Choices []string `json:"choices"`
+	Answer  int      `json:"answer"` // 1-based choice index from the [정답] section, 0 if missing
+	// Forms lists the inflected forms of the answer used in the sentences,
+	// from a key line such as "1. ③ (ran, running)".
+	Forms []string `json:"forms,omitempty"`
+	// Note is the teacher note in square brackets after the key entry, such
+	// as the relation of an analogy ("1. ③ [반의어 / antonym]").
+	Note string `json:"note,omitempty"`
+	// AnswerText is the written answer of production questions, which have
+	// no choices ("1. abandoned").
+	AnswerText string `json:"answerText,omitempty"`
+}
+
+// ParsedOutput is the model output split into question blocks and answer key.
+type ParsedOutput struct {
+	Questions []Question       `json:"questions"`
+	AnswerKey map[int]int      `json:"answerKey"`
+	KeyForms  map[int][]string `json:"keyForms"`
+	KeyNotes  map[int]string   `json:"keyNotes"`
+	KeyText   map[int]string   `json:"keyText"`
+	HasKey    bool             `json:"hasKey"`
+	// Appendix is the teacher-only text after translationHeader, if any.
+	Appendix string `json:"appendix"`
+}
+
+const choiceMarkers = "①②③④⑤"
+
+var (
+	questionNumberRe = regexp.MustCompile(`^(\d+)[.)]\s*(.*)$`)
+	separatorRe      = regexp.MustCompile(`^-{3,}$`)
+	blankRe          = regexp.MustCompile(`_{3,}`)
+	keyCircledRe     = regexp.MustCompile(`(\d+)\s*번?\s*[.):\-–=]?\s*([①②③④⑤])`)
+	keyDigitRe       = regexp.MustCompile(`(\d+)\s*번?\s*[.):\-–=]\s*([1-5])(?:\D|$)`)
+	keyFormsRe       = regexp.MustCompile(`^(\d+)\s*번?\s*[.):\-–=]?\s*[①②③④⑤1-5][^(]*\(([^)]*)\)`)
+	keyNoteRe        = regexp.MustCompile(`^(\d+)\s*번?\s*[.):\-–=]?\s*[①②③④⑤1-5][^\[]*\[([^\]]*)\]`)
+	keyTextRe        = regexp.MustCompile(`^(\d+)\s*번?\s*[.):\-–=]\s*([A-Za-z][A-Za-z' \-]*)`)
+)
+
+// productionTypes are the question types students answer by writing a word
+// instead of picking a choice.
+var productionTypes = map[string]bool{"단어 쓰기": true}
+
+// choiceIndex returns the 1-based index of a circled choice marker, or 0.
+func choiceIndex(r rune) int {
+	for i, m := range []rune(choiceMarkers) {
+		if r == m {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// cleanLine strips markdown decoration the models like to add around numbers and titles.
+func cleanLine(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimLeft(line, "#> ")
+	return strings.TrimSpace(strings.ReplaceAll(line, "**", ""))
+}
+
+// splitChoices splits a line such as "① run ② walk" into its choices.
+func splitChoices(line string) []string {
+	var choices []string
+	var current []rune
+	inChoice := false
+	for _, r := range line {
+		if choiceIndex(r) > 0 {
+			if inChoice {
+				choices = append(choices, strings.TrimSpace(string(current)))
+			}
+			current = current[:0]
+			inChoice = true
+			continue
+		}
+		if inChoice {
+			current = append(current, r)
+		}
+	}
+	if inChoice {
+		choices = append(choices, strings.TrimSpace(string(current)))
+	}
+	return choices
+}
+
+func parseOutput(output string) ParsedOutput {
+	result := ParsedOutput{AnswerKey: map[int]int{}, KeyForms: map[int][]string{}, KeyNotes: map[int]string{}, KeyText: map[int]string{}}
+
+	if idx := strings.Index(output, translationHeader); idx >= 0 {
+		result.Appendix = strings.TrimSpace(output[idx+len(translationHeader):])
+		output = output[:idx]
+	}
+
+	body := output
+	if idx := strings.Index(output, "[정답]"); idx >= 0 {
+		body = output[:idx]
+		result.HasKey = true
+		result.AnswerKey = parseAnswerKey(output[idx+len("[정답]"):])
+		result.KeyForms = parseKeyForms(output[idx+len("[정답]"):])
+		result.KeyNotes = parseKeyNotes(output[idx+len("[정답]"):])
+		result.KeyText = parseKeyText(output[idx+len("[정답]"):])
+	}
+
+	var current *Question
+	flush := func() {
+		if current != nil {
+			result.Questions = append(result.Questions, *current)
+			current = nil
+		}
+	}
+	for _, raw := range strings.Split(body, "\n") {
+		line := cleanLine(raw)
+		if line == "" {
+			continue
+		}
+		if separatorRe.MatchString(line) {
+			flush()
+			continue
+		}
+		if m := questionNumberRe.FindStringSubmatch(line); m != nil && (current == nil || len(current.Choices) > 0) {
+			flush()
+			n, _ := strconv.Atoi(m[1])
+			current = &Question{Number: n, Title: strings.TrimSpace(m[2])}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if strings.ContainsAny(line, choiceMarkers) && choiceIndex([]rune(line)[0]) > 0 {
+			current.Choices = append(current.Choices, splitChoices(line)...)
+			continue
+		}
+		if current.Title == "" {
+			current.Title = line
+		} else {
+			current.Body = append(current.Body, line)
+		}
+	}
+	flush()
+
+	for i := range result.Questions {
+		result.Questions[i].Answer = result.AnswerKey[result.Questions[i].Number]
+		result.Questions[i].Forms = result.KeyForms[result.Questions[i].Number]
+		result.Questions[i].Note = result.KeyNotes[result.Questions[i].Number]
+		result.Questions[i].AnswerText = result.KeyText[result.Questions[i].Number]
+	}
+	return result
+}
+
+// formatOutput renders parsed questions back into the layout the prompts ask
+// for. It is used after questions were repaired in Go, so the result can be
+// parsed again by parseOutput.
+func formatOutput(out ParsedOutput) string {
+	markers := []rune(choiceMarkers)
+	var blocks []string
+	for _, q := range out.Questions {
+		lines := []string{fmt.Sprintf("%d. %s", q.Number, q.Title)}
+		lines = append(lines, q.Body...)
+		for i, c := range q.Choices {
+			if i < len(markers) {
+				lines = append(lines, fmt.Sprintf("%c %s", markers[i], c))
+			}
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	text := strings.Join(blocks, "\n---\n")
+	if out.HasKey {
+		key := []string{"[정답]"}
+		for _, q := range out.Questions {
+			if q.AnswerText != "" {
+				key = append(key, fmt.Sprintf("%d. %s", q.Number, q.AnswerText))
+			}
+			if q.Answer >= 1 && q.Answer <= len(markers) {
+				line := fmt.Sprintf("%d. %c", q.Number, markers[q.Answer-1])
+				if len(q.Forms) > 0 {
+					line += " (" + strings.Join(q.Forms, ", ") + ")"
+				}
+				if q.Note != "" {
+					line += " [" + q.Note + "]"
+				}
+				key = append(key, line)
+			}
+		}
+		text += "\n\n" + strings.Join(key, "\n")
+	}
+	if out.Appendix != "" {
+		text += "\n\n" + translationHeader + "\n" + out.Appendix
+	}
+	return text
+}
+
+func parseAnswerKey(section string) map[int]int {
+	key := map[int]int{}
+	for _, raw := range strings.Split(section, "\n") {
+		line := cleanLine(raw)
+		for _, m := range keyCircledRe.FindAllStringSubmatch(line, -1) {
+			n, _ := strconv.Atoi(m[1])
+			key[n] = choiceIndex([]rune(m[2])[0])
+		}
+		for _, m := range keyDigitRe.FindAllStringSubmatch(line, -1) {
+			n, _ := strconv.Atoi(m[1])
+			if _, ok := key[n]; !ok {
+				key[n], _ = strconv.Atoi(m[2])
+			}
+		}
+	}
+	return key
+}
+
+// parseKeyForms reads the inflected forms listed after key entries, one key
+// entry per line.
+func parseKeyForms(section string) map[int][]string {
+	forms := map[int][]string{}
+	for _, raw := range strings.Split(section, "\n") {
+		m := keyFormsRe.FindStringSubmatch(cleanLine(raw))
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		for _, f := range strings.Split(m[2], ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				forms[n] = append(forms[n], f)
+			}
+		}
+	}
+	return forms
+}
+
+// parseKeyNotes reads the bracketed teacher notes after key entries.
+func parseKeyNotes(section string) map[int]string {
+	notes := map[int]string{}
+	for _, raw := range strings.Split(section, "\n") {
+		if m := keyNoteRe.FindStringSubmatch(cleanLine(raw)); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			notes[n] = strings.TrimSpace(m[2])
+		}
+	}
+	return notes
+}
+
+// parseKeyText reads written answers ("1. abandoned") for production
+// questions.
+func parseKeyText(section string) map[int]string {
+	text := map[int]string{}
+	for _, raw := range strings.Split(section, "\n") {
+		if m := keyTextRe.FindStringSubmatch(cleanLine(raw)); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			text[n] = strings.TrimSpace(m[2])
+		}
+	}
+	return text
+}
+
+// --- Validation ---
+
+// ValidationIssue describes a single problem found in the output. Question is
+// 0 for problems that are not tied to a specific question.
+type ValidationIssue struct {
+	Question int    `json:"question"`
+	Message  string `json:"message"`
+}
+
+type ValidationResult struct {
+	QuestionCount   int               `json:"questionCount"`
+	Failures        []ValidationIssue `json:"failures"`
+	AnswerKeyErrors []ValidationIssue `json:"answerKeyErrors"`
+}
+
+func (r ValidationResult) Passed() bool {
+	return len(r.Failures) == 0 && len(r.AnswerKeyErrors) == 0
+}
+
+// FailedQuestions counts distinct questions with at least one structural failure.
+func (r ValidationResult) FailedQuestions() int {
+	return countDistinctQuestions(r.Failures)
+}
+
+// KeyErrorQuestions counts distinct questions with at least one answer key error.
+func (r ValidationResult) KeyErrorQuestions() int {
+	return countDistinctQuestions(r.AnswerKeyErrors)
+}
+
+func countDistinctQuestions(issues []ValidationIssue) int {
+	seen := map[int]bool{}
+	for _, issue := range issues {
+		if issue.Question > 0 {
+			seen[issue.Question] = true
+		}
+	}
+	return len(seen)
+}
+
+// validateOutput checks the model output against the structural rules given in
+// the system prompt and cross-checks the answer key against the input words.
+func validateOutput(output string, parsed []VocabPair, questionType string, numSentences int) ValidationResult {
+	out := parseOutput(output)
+	result := ValidationResult{QuestionCount: len(out.Questions)}
+	fail := func(q int, format string, args ...interface{}) {
+		result.Failures = append(result.Failures, ValidationIssue{Question: q, Message: fmt.Sprintf(format, args...)})
+	}
+	keyErr := func(q int, format string, args ...interface{}) {
+		result.AnswerKeyErrors = append(result.AnswerKeyErrors, ValidationIssue{Question: q, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(out.Questions) == 0 {
+		fail(0, "문제를 찾을 수 없습니다")
+		return result
+	}
+
+	words := map[string]bool{}
+	for _, pair := range parsed {
+		words[strings.ToLower(pair.Word)] = true
+	}
+
+	numbers := map[int]bool{}
+	for i, q := range out.Questions {
+		if q.Number != i+1 {
+			fail(q.Number, "문제 번호가 순서와 맞지 않습니다 (예상 %d)", i+1)
+		}
+		numbers[q.Number] = true
+
+		if questionType == "문장 삽입" {
+			// The choices are the five positions marked inside the passage.
+			text := strings.Join(q.Body, "\n")
+			for _, m := range choiceMarkers {
+				if n := strings.Count(text, string(m)); n != 1 {
+					fail(q.Number, "삽입 위치 %c가 %d번 나옵니다 (1번 필요)", m, n)
+				}
+			}
+		} else if productionTypes[questionType] {
+			if len(q.Choices) > 0 {
+				fail(q.Number, "쓰기 문제에 선택지가 있습니다")
+			}
+		} else if len(q.Choices) != 5 {
+			fail(q.Number, "선택지가 %d개입니다 (5개 필요)", len(q.Choices))
+		}
+		seen := map[string]bool{}
+		for _, c := range q.Choices {
+			norm := strings.ToLower(c)
+			if seen[norm] {
+				fail(q.Number, "중복된 선택지: %s", c)
+			}
+			seen[norm] = true
+		}
+		if productionTypes[questionType] {
+			blanks := 0
+			for _, line := range q.Body {
+				blanks += len(blankRe.FindAllString(line, -1))
+			}
+			if blanks != 1 {
+				fail(q.Number, "빈칸이 %d개입니다 (1개 필요)", blanks)
+			}
+			text, ok := out.KeyText[q.Number]
+			if !ok {
+				keyErr(q.Number, "정답이 없습니다")
+				continue
+			}
+			inList := false
+			for w := range words {
+				if strings.EqualFold(text, w) || isInflectionOf(text, w) {
+					inList = true
+				}
+			}
+			if !inList {
+				keyErr(q.Number, "정답 '%s'가 단어 목록에 없습니다", text)
+			}
+			continue
+		}
+		if questionType == "빈칸 추론" {
+			blanks := 0
+			for i, line := range q.Body {
+				if n := len(blankRe.FindAllString(line, -1)); n > 0 {
+					blanks++
+					if n > 1 {
+						fail(q.Number, "예문 %d에 빈칸이 %d개입니다 (1개 필요)", i+1, n)
+					}
+				}
+			}
+			if blanks != numSentences {
+				fail(q.Number, "빈칸 예문이 %d개입니다 (%d개 필요)", blanks, numSentences)
+			}
+		}
+
+		answer, ok := out.AnswerKey[q.Number]
+		if !ok {
+			keyErr(q.Number, "정답이 없습니다")
+			continue
+		}
+		if questionType == "문장 삽입" {
+			if answer < 1 || answer > len([]rune(choiceMarkers)) {
+				keyErr(q.Number, "정답 번호 %d가 선택지 범위를 벗어납니다", answer)
+			}
+			continue
+		}
+		if answer < 1 || answer > len(q.Choices) {
+			keyErr(q.Number, "정답 번호 %d가 선택지 범위를 벗어납니다", answer)
+			continue
+		}
+		if questionType == "의미 다른 것" {
+			fromList := false
+			for i, c := range q.Choices {
+				if i != answer-1 && words[strings.ToLower(c)] {
+					fromList = true
+				}
+			}
+			if !fromList {
+				fail(q.Number, "같은 뜻 묶음에 단어 목록의 단어가 없습니다")
+			}
+		}
+		// For these types the keyed choice must be one of the input words.
+		if questionType == "빈칸 추론" || questionType == "영영풀이" || questionType == "유추" {
+			if chosen := strings.ToLower(q.Choices[answer-1]); !words[chosen] {
+				keyErr(q.Number, "정답 선택지 '%s'가 단어 목록에 없습니다", q.Choices[answer-1])
+			}
+		}
+	}
+	// Inflected forms listed in the key must still be forms of the answer.
+	result.Failures = append(result.Failures, checkInflections(output)...)
+
+	if !out.HasKey {
+		keyErr(0, "[정답] 섹션이 없습니다")
+	}
+	for n := range out.AnswerKey {
+		if !numbers[n] {
+			keyErr(n, "존재하지 않는 문제 번호의 정답입니다")
+		}
+	}
+	return result
+}
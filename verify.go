@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Definition Choice Verification (뜻풀이 판단) ---
+
+const definitionCheckPrompt = `You are a strict reviewer of English vocabulary tests for Korean students.
+Each question asks for the correct English definition of a word. Exactly one choice (the keyed answer) should be correct; every other choice must be clearly wrong for that word, not a paraphrase of the correct definition or another valid sense of the word.
+For every question, list the numbers of the NON-keyed choices that a careful teacher could also accept as correct.
+Respond with JSON only: {"results": [{"question": 1, "ambiguous": [2, 4], "reason": "short reason"}]}. Use an empty "ambiguous" list when the question is fine.`
+
+const definitionRepairPrompt = `You are an expert English vocabulary test maker for Korean students.
+Some wrong choices in these definition questions were judged to be acceptable definitions of the word. Rewrite ONLY the listed choices so that each is a plausible but clearly incorrect definition: keep the same style and length as the other choices, and do not touch the keyed answer.
+Respond with JSON only: {"questions": [{"question": 1, "choices": {"2": "new definition", "4": "new definition"}}]}.`
+
+// DefinitionCheckReport is emitted as "generate:definitions-checked".
+type DefinitionCheckReport struct {
+	Checked    int               `json:"checked"`
+	Repaired   []int             `json:"repaired"`   // question numbers whose choices were rewritten
+	Unresolved []ValidationIssue `json:"unresolved"` // still ambiguous after the repair
+}
+
+type definitionCheckResult struct {
+	Results []struct {
+		Question  int    `json:"question"`
+		Ambiguous []int  `json:"ambiguous"`
+		Reason    string `json:"reason"`
+	} `json:"results"`
+}
+
+type choiceRepairResult struct {
+	Questions []struct {
+		Question int               `json:"question"`
+		Choices  map[string]string `json:"choices"`
+	} `json:"questions"`
+}
+
+// describeQuestions renders questions with their keyed answer for the
+// checking calls.
+func describeQuestions(questions []Question) string {
+	var b strings.Builder
+	markers := []rune(choiceMarkers)
+	for _, q := range questions {
+		fmt.Fprintf(&b, "Question %d: %s\n", q.Number, q.Title)
+		for i, c := range q.Choices {
+			fmt.Fprintf(&b, "  %d (%c) %s\n", i+1, markers[i], c)
+		}
+		fmt.Fprintf(&b, "  Keyed answer: %d\n\n", q.Answer)
+	}
+	return b.String()
+}
+
+// checkDefinitions returns the ambiguous choices per question number.
+func (a *VocabApp) checkDefinitions(modelID string, questions []Question) (map[int]ValidationIssue, map[int][]int, error) {
+	var result definitionCheckResult
+	if err := a.callChatGPTJSON(modelID, definitionCheckPrompt, describeQuestions(questions), &result); err != nil {
+		return nil, nil, err
+	}
+	byNumber := map[int]Question{}
+	for _, q := range questions {
+		byNumber[q.Number] = q
+	}
+	issues := map[int]ValidationIssue{}
+	ambiguous := map[int][]int{}
+	for _, r := range result.Results {
+		q, ok := byNumber[r.Question]
+		if !ok {
+			continue
+		}
+		for _, c := range r.Ambiguous {
+			if c >= 1 && c <= len(q.Choices) && c != q.Answer {
+				ambiguous[r.Question] = append(ambiguous[r.Question], c)
+			}
+		}
+		if len(ambiguous[r.Question]) > 0 {
+			issues[r.Question] = ValidationIssue{Question: r.Question, Message: fmt.Sprintf("정답이 될 수 있는 오답 선택지 %v: %s", ambiguous[r.Question], r.Reason)}
+		}
+	}
+	return issues, ambiguous, nil
+}
+
+// verifyDefinitions checks that the wrong choices of 뜻풀이 판단 questions are
+// really wrong, rewrites the ones that are not, and checks the rewritten
+// questions once more. Questions without a valid answer are skipped since
+// there is nothing to compare against.
+func (a *VocabApp) verifyDefinitions(modelID string, output string) (string, DefinitionCheckReport, error) {
+	var report DefinitionCheckReport
+	out := parseOutput(output)
+	var keyed []Question
+	for _, q := range out.Questions {
+		if q.Answer >= 1 && q.Answer <= len(q.Choices) && len(q.Choices) <= len([]rune(choiceMarkers)) {
+			keyed = append(keyed, q)
+		}
+	}
+	report.Checked = len(keyed)
+	if len(keyed) == 0 {
+		return output, report, nil
+	}
+
+	_, ambiguous, err := a.checkDefinitions(modelID, keyed)
+	if err != nil || len(ambiguous) == 0 {
+		return output, report, err
+	}
+
+	var request strings.Builder
+	var flagged []Question
+	for _, q := range keyed {
+		if choices, ok := ambiguous[q.Number]; ok {
+			flagged = append(flagged, q)
+			fmt.Fprintf(&request, "Rewrite choices %v of question %d.\n", choices, q.Number)
+		}
+	}
+	request.WriteString("\n" + describeQuestions(flagged))
+	var repair choiceRepairResult
+	if err := a.callChatGPTJSON(modelID, definitionRepairPrompt, request.String(), &repair); err != nil {
+		return output, report, err
+	}
+
+	index := map[int]int{}
+	for i, q := range out.Questions {
+		index[q.Number] = i
+	}
+	var repaired []Question
+	for _, r := range repair.Questions {
+		i, ok := index[r.Question]
+		if !ok {
+			continue
+		}
+		q := &out.Questions[i]
+		changed := false
+		for _, c := range ambiguous[r.Question] {
+			if text := strings.TrimSpace(r.Choices[strconv.Itoa(c)]); text != "" {
+				q.Choices[c-1] = text
+				changed = true
+			}
+		}
+		if changed {
+			report.Repaired = append(report.Repaired, q.Number)
+			repaired = append(repaired, *q)
+		}
+	}
+
+	// Anything flagged but not rewritten stays unresolved; rewritten
+	// questions get one more check.
+	stillAmbiguous := map[int]ValidationIssue{}
+	if len(repaired) > 0 {
+		if stillAmbiguous, _, err = a.checkDefinitions(modelID, repaired); err != nil {
+			return output, report, err
+		}
+	}
+	for _, q := range flagged {
+		if issue, ok := stillAmbiguous[q.Number]; ok {
+			report.Unresolved = append(report.Unresolved, issue)
+		} else if !containsInt(report.Repaired, q.Number) {
+			report.Unresolved = append(report.Unresolved, ValidationIssue{Question: q.Number, Message: "모호한 선택지를 다시 만들지 못했습니다"})
+		}
+	}
+
+	if len(report.Repaired) == 0 {
+		return output, report, nil
+	}
+	return formatOutput(out), report, nil
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyDefinitionsAndReport runs verifyDefinitions for Generate. A failed
+// check is logged and the unchecked output kept, so a flaky review call never
+// costs the user the generated test.
+func (a *VocabApp) verifyDefinitionsAndReport(modelID string, output string) string {
+	checked, report, err := a.verifyDefinitions(modelID, output)
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "뜻풀이 선택지 검사 오류: %v", err)
+	}
+	runtime.EventsEmit(a.ctx, "generate:definitions-checked", report)
+	return checked
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Webhooks ---
+
+const (
+	WebhookGenerated = "generation.completed"
+	WebhookExported  = "export.saved"
+	webhookPing      = "ping"
+)
+
+// WebhookSettings configures a POST callback to school automation. With a
+// Secret, every request carries "X-Vocab-Signature: sha256=<hex HMAC of the
+// body>" so the receiver can check where it came from.
+type WebhookSettings struct {
+	URL        string `json:"url"`
+	Secret     string `json:"secret"`
+	OnGenerate bool   `json:"onGenerate"`
+	OnExport   bool   `json:"onExport"`
+}
+
+func validateWebhookSettings(w WebhookSettings) error {
+	if w.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("웹훅 주소가 올바르지 않습니다: '%s'", w.URL)
+	}
+	return nil
+}
+
+// WebhookPayload is the JSON body of a webhook call.
+type WebhookPayload struct {
+	Event        string    `json:"event"`
+	RunID        string    `json:"runId,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	QuestionType string    `json:"questionType,omitempty"`
+	Words        int       `json:"words,omitempty"`
+	Questions    int       `json:"questions,omitempty"`
+	Path         string    `json:"path,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+func postWebhook(w WebhookSettings, payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("웹훅 주소 오류: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vocab-Event", payload.Event)
+	if w.Secret != "" {
+		req.Header.Set("X-Vocab-Signature", "sha256="+hex.EncodeToString(hmacSHA256([]byte(w.Secret), string(body))))
+	}
+	resp, err := (&http.Client{Timeout: 15 * time.Second}).Do(req)
+	if err != nil {
+		return fmt.Errorf("웹훅 전송 오류: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("웹훅 응답 오류 (%d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// fireWebhook sends the payload in the background if the settings ask for
+// the event; failures are only logged.
+func (a *VocabApp) fireWebhook(w WebhookSettings, payload WebhookPayload) {
+	if w.URL == "" || (payload.Event == WebhookGenerated && !w.OnGenerate) || (payload.Event == WebhookExported && !w.OnExport) {
+		return
+	}
+	payload.Time = time.Now()
+	go func() {
+		if err := postWebhook(w, payload); err != nil {
+			runtime.LogErrorf(a.ctx, "%v", err)
+		}
+	}()
+}
+
+// --- Go functions callable from Javascript ---
+
+// TestWebhook sends a "ping" event to the configured webhook and waits for
+// the answer.
+func (a *VocabApp) TestWebhook() error {
+	a.mu.Lock()
+	settings, err := loadSettings()
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if settings.Webhook.URL == "" {
+		return fmt.Errorf("웹훅 주소를 입력하세요")
+	}
+	return postWebhook(settings.Webhook, WebhookPayload{Event: webhookPing, Time: time.Now()})
+}
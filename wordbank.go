@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// --- Word Bank ---
+
+const (
+	wordBankHeader    = "<보기>"
+	wordBankPerRow    = 5
+	wordBankMaxDecoys = 10
+)
+
+// WordBankSettings prints a boxed word bank at the top of exported tests
+// whose questions have no choices (production and fill-in formats).
+type WordBankSettings struct {
+	Enabled bool `json:"enabled"`
+	// Decoys is the number of extra words that are not answers, taken from
+	// the untested words of the generation run and then the working list.
+	Decoys int `json:"decoys"`
+}
+
+func validateWordBankSettings(s WordBankSettings) error {
+	if s.Decoys < 0 || s.Decoys > wordBankMaxDecoys {
+		return fmt.Errorf("보기 오답 단어 수는 0~%d 사이여야 합니다", wordBankMaxDecoys)
+	}
+	return nil
+}
+
+// wordBankAnswers returns the answers of the questions without choices, in
+// question order and without duplicates. Cloze passages already carry their
+// own <보기>, so they get none.
+func wordBankAnswers(content string) []string {
+	if strings.Contains(content, wordBankHeader) {
+		return nil
+	}
+	var answers []string
+	seen := map[string]bool{}
+	for _, q := range parseOutput(content).Questions {
+		answer := strings.TrimSpace(blankAnswer(q))
+		if len(q.Choices) > 0 || answer == "" || seen[strings.ToLower(answer)] {
+			continue
+		}
+		seen[strings.ToLower(answer)] = true
+		answers = append(answers, answer)
+	}
+	return answers
+}
+
+// testWordBank builds the shuffled word bank of content, or nil when none of
+// its questions need one.
+func (a *VocabApp) testWordBank(content string, s WordBankSettings) []string {
+	bank := wordBankAnswers(content)
+	if len(bank) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, w := range bank {
+		seen[strings.ToLower(w)] = true
+	}
+	var candidates []string
+	a.mu.Lock()
+	runs, err := loadRuns()
+	a.mu.Unlock()
+	if err == nil {
+		if run, ok := findRun(runs, content); ok {
+			for _, e := range run.Entries {
+				candidates = append(candidates, e.Word)
+			}
+		}
+	}
+	a.list.mu.Lock()
+	for _, e := range a.list.entries {
+		candidates = append(candidates, e.Word)
+	}
+	a.list.mu.Unlock()
+
+	decoys := 0
+	for _, w := range candidates {
+		if decoys >= s.Decoys {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(w))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		bank = append(bank, strings.TrimSpace(w))
+		decoys++
+	}
+	rand.Shuffle(len(bank), func(i, j int) { bank[i], bank[j] = bank[j], bank[i] })
+	return bank
+}
+
+// layoutWordBank adds the word bank as a bordered box of wordBankPerRow
+// words per row.
+func layoutWordBank(doc *docxBuilder, bank []string) {
+	var rows [][]string
+	for i, w := range bank {
+		if i%wordBankPerRow == 0 {
+			rows = append(rows, nil)
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], w)
+	}
+	doc.Paragraph(docxRun{Text: wordBankHeader, Bold: true})
+	doc.Table(rows)
+	doc.Blank()
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// --- Working List ---
+
+// workingList is the word list currently open in the editor. It lives on the
+// Go side so background features (the clipboard watcher) can add to it.
+type workingList struct {
+	mu      sync.Mutex
+	entries []VocabPair
+	undo    []listEdit
+	redo    []listEdit
+
+	watchStop chan struct{}
+}
+
+const maxListHistory = 200
+
+// listEdit is one undoable command on the working list. Lists are small, so
+// keeping the list before and after each command is simpler and safer than
+// writing an inverse for every operation.
+type listEdit struct {
+	name   string
+	before []VocabPair
+	after  []VocabPair
+}
+
+// ListState is returned by every editing call so the editor can redraw and
+// update its undo/redo buttons in one round trip.
+type ListState struct {
+	Entries   []VocabPair `json:"entries"`
+	CanUndo   bool        `json:"canUndo"`
+	CanRedo   bool        `json:"canRedo"`
+	UndoLabel string      `json:"undoLabel"`
+	RedoLabel string      `json:"redoLabel"`
+}
+
+func cloneEntries(entries []VocabPair) []VocabPair {
+	clone := make([]VocabPair, len(entries))
+	for i, e := range entries {
+		clone[i] = VocabPair{Word: e.Word, Seed: e.Seed, Note: e.Note}
+		for _, s := range e.Senses {
+			clone[i].copySense(e, s)
+		}
+	}
+	return clone
+}
+
+// state must be called with l.mu held.
+func (l *workingList) state() ListState {
+	st := ListState{Entries: cloneEntries(l.entries), CanUndo: len(l.undo) > 0, CanRedo: len(l.redo) > 0}
+	if st.CanUndo {
+		st.UndoLabel = l.undo[len(l.undo)-1].name
+	}
+	if st.CanRedo {
+		st.RedoLabel = l.redo[len(l.redo)-1].name
+	}
+	return st
+}
+
+// apply runs a command on the working list and records it for undo. A command
+// that leaves the list unchanged is not recorded.
+func (l *workingList) apply(name string, edit func(entries []VocabPair) ([]VocabPair, error)) (ListState, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	before := cloneEntries(l.entries)
+	after, err := edit(cloneEntries(l.entries))
+	if err != nil {
+		return l.state(), err
+	}
+	if sameEntries(before, after) {
+		return l.state(), nil
+	}
+	l.entries = after
+	l.undo = append(l.undo, listEdit{name: name, before: before, after: cloneEntries(after)})
+	if len(l.undo) > maxListHistory {
+		l.undo = l.undo[len(l.undo)-maxListHistory:]
+	}
+	l.redo = nil
+	return l.state(), nil
+}
+
+// sameEntries reports whether two lists are equal, teacher notes included;
+// the notes are not part of the text form.
+func sameEntries(a, b []VocabPair) bool {
+	if len(a) != len(b) || formatVocabBlock(a) != formatVocabBlock(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Note != b[i].Note {
+			return false
+		}
+	}
+	return true
+}
+
+func findEntry(entries []VocabPair, word string) int {
+	for i := range entries {
+		if strings.EqualFold(entries[i].Word, word) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeEntries adds incoming entries to list: new words are appended and
+// senses of known words are added if missing. It returns the merged list and
+// the entries that actually changed something.
+func mergeEntries(list []VocabPair, incoming []VocabPair) ([]VocabPair, []VocabPair) {
+	merged := append([]VocabPair(nil), list...)
+	var changed []VocabPair
+	for _, in := range incoming {
+		idx := findEntry(merged, in.Word)
+		if idx < 0 {
+			merged = append(merged, in)
+			changed = append(changed, in)
+			continue
+		}
+		var added []string
+		for _, s := range in.Senses {
+			found := false
+			for _, existing := range merged[idx].Senses {
+				if existing == s {
+					found = true
+					break
+				}
+			}
+			if !found {
+				added = append(added, s)
+			}
+		}
+		if len(added) > 0 {
+			entry := VocabPair{Word: merged[idx].Word, Seed: merged[idx].Seed, Note: merged[idx].Note}
+			if entry.Seed == "" {
+				entry.Seed = in.Seed
+			}
+			if entry.Note == "" {
+				entry.Note = in.Note
+			}
+			for _, s := range merged[idx].Senses {
+				entry.copySense(merged[idx], s)
+			}
+			diff := VocabPair{Word: entry.Word}
+			for _, s := range added {
+				entry.copySense(in, s)
+				diff.copySense(in, s)
+			}
+			merged[idx] = entry
+			changed = append(changed, diff)
+		}
+	}
+	return merged, changed
+}
+
+// formatVocabBlock is the inverse of parseVocabBlock.
+func formatVocabBlock(entries []VocabPair) string {
+	var lines []string
+	for _, e := range entries {
+		line := fmt.Sprintf("%s = %s", e.Word, strings.Join(markedSenses(e), ", "))
+		if e.Seed != "" {
+			line += " = " + e.Seed
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- Go functions callable from Javascript ---
+
+func (a *VocabApp) GetWorkingList() ListState {
+	a.list.mu.Lock()
+	defer a.list.mu.Unlock()
+	return a.list.state()
+}
+
+// SetWorkingList replaces the working list with the parsed vocab block, e.g.
+// after opening a file. The edit history starts over for the new list.
+func (a *VocabApp) SetWorkingList(vocabBlock string) ListState {
+	entries := parseVocabBlock(vocabBlock)
+	a.list.mu.Lock()
+	defer a.list.mu.Unlock()
+	a.list.entries = entries
+	a.list.undo = nil
+	a.list.redo = nil
+	return a.list.state()
+}
+
+func (a *VocabApp) GetWorkingListText() string {
+	a.list.mu.Lock()
+	defer a.list.mu.Unlock()
+	return formatVocabBlock(a.list.entries)
+}
+
+func (a *VocabApp) AddWord(word string, senses []string) (ListState, error) {
+	entry, err := newVocabPair(word, senses)
+	if err != nil {
+		return a.GetWorkingList(), err
+	}
+	return a.list.apply(fmt.Sprintf("'%s' 추가", entry.Word), func(entries []VocabPair) ([]VocabPair, error) {
+		if findEntry(entries, entry.Word) >= 0 {
+			return nil, fmt.Errorf("이미 있는 단어입니다: %s", entry.Word)
+		}
+		return append(entries, entry), nil
+	})
+}
+
+// EditWord replaces the word at index; renaming to another existing word is rejected.
+func (a *VocabApp) EditWord(index int, word string, senses []string) (ListState, error) {
+	entry, err := newVocabPair(word, senses)
+	if err != nil {
+		return a.GetWorkingList(), err
+	}
+	return a.list.apply(fmt.Sprintf("'%s' 수정", entry.Word), func(entries []VocabPair) ([]VocabPair, error) {
+		if index < 0 || index >= len(entries) {
+			return nil, fmt.Errorf("잘못된 항목 번호입니다: %d", index)
+		}
+		if other := findEntry(entries, entry.Word); other >= 0 && other != index {
+			return nil, fmt.Errorf("이미 있는 단어입니다: %s", entry.Word)
+		}
+		entries[index] = entry
+		return entries, nil
+	})
+}
+
+// SetWordSeed sets the seed sentence of the word at index; an empty seed
+// removes it.
+func (a *VocabApp) SetWordSeed(index int, seed string) (ListState, error) {
+	return a.list.apply("예문 지정", func(entries []VocabPair) ([]VocabPair, error) {
+		if index < 0 || index >= len(entries) {
+			return nil, fmt.Errorf("잘못된 항목 번호입니다: %d", index)
+		}
+		entries[index].Seed = strings.TrimSpace(seed)
+		return entries, nil
+	})
+}
+
+func (a *VocabApp) RemoveWord(index int) (ListState, error) {
+	return a.list.apply("단어 삭제", func(entries []VocabPair) ([]VocabPair, error) {
+		if index < 0 || index >= len(entries) {
+			return nil, fmt.Errorf("잘못된 항목 번호입니다: %d", index)
+		}
+		return append(entries[:index], entries[index+1:]...), nil
+	})
+}
+
+// ImportMerge merges another vocab block into the working list as one undoable step.
+func (a *VocabApp) ImportMerge(vocabBlock string) (ListState, error) {
+	incoming := parseVocabBlock(vocabBlock)
+	if len(incoming) == 0 {
+		return a.GetWorkingList(), fmt.Errorf("입력에서 유효한 'word = 뜻' 형식을 찾을 수 없습니다.")
+	}
+	return a.list.apply(fmt.Sprintf("%d개 병합", len(incoming)), func(entries []VocabPair) ([]VocabPair, error) {
+		merged, _ := mergeEntries(entries, incoming)
+		return merged, nil
+	})
+}
+
+func (a *VocabApp) UndoListEdit() (ListState, error) {
+	l := &a.list
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.undo) == 0 {
+		return l.state(), fmt.Errorf("되돌릴 작업이 없습니다")
+	}
+	edit := l.undo[len(l.undo)-1]
+	l.undo = l.undo[:len(l.undo)-1]
+	l.entries = cloneEntries(edit.before)
+	l.redo = append(l.redo, edit)
+	return l.state(), nil
+}
+
+func (a *VocabApp) RedoListEdit() (ListState, error) {
+	l := &a.list
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.redo) == 0 {
+		return l.state(), fmt.Errorf("다시 실행할 작업이 없습니다")
+	}
+	edit := l.redo[len(l.redo)-1]
+	l.redo = l.redo[:len(l.redo)-1]
+	l.entries = cloneEntries(edit.after)
+	l.undo = append(l.undo, edit)
+	return l.state(), nil
+}
+
+// --- Clipboard Watcher ---
+
+const clipboardPollInterval = 700 * time.Millisecond
+
+// StartClipboardWatch polls the clipboard and appends every copied
+// "word = 뜻" line (or Excel row) to the working list. Each harvest is an
+// undoable edit and emits a "clipboard:harvested" event with the added
+// entries and the new list state.
+func (a *VocabApp) StartClipboardWatch() error {
+	a.list.mu.Lock()
+	if a.list.watchStop != nil {
+		a.list.mu.Unlock()
+		return fmt.Errorf("클립보드 감시가 이미 실행 중입니다")
+	}
+	stop := make(chan struct{})
+	a.list.watchStop = stop
+	a.list.mu.Unlock()
+
+	// Whatever is on the clipboard already was not copied while watching.
+	last, _ := runtime.ClipboardGetText(a.ctx)
+	go func() {
+		ticker := time.NewTicker(clipboardPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			text, err := runtime.ClipboardGetText(a.ctx)
+			if err != nil || text == last {
+				continue
+			}
+			last = text
+			incoming := parseVocabBlock(tabularToVocab(text))
+			if len(incoming) == 0 {
+				continue
+			}
+
+			var added []VocabPair
+			st, _ := a.list.apply("클립보드 수집", func(entries []VocabPair) ([]VocabPair, error) {
+				merged, changed := mergeEntries(entries, incoming)
+				added = changed
+				return merged, nil
+			})
+			if len(added) > 0 {
+				runtime.EventsEmit(a.ctx, "clipboard:harvested", map[string]interface{}{
+					"added": added,
+					"state": st,
+				})
+			}
+		}
+	}()
+	return nil
+}
+
+func (a *VocabApp) StopClipboardWatch() {
+	a.list.mu.Lock()
+	defer a.list.mu.Unlock()
+	if a.list.watchStop != nil {
+		close(a.list.watchStop)
+		a.list.watchStop = nil
+	}
+}
+
+func (a *VocabApp) IsClipboardWatching() bool {
+	a.list.mu.Lock()
+	defer a.list.mu.Unlock()
+	return a.list.watchStop != nil
+}